@@ -23,6 +23,8 @@
 package cli
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -31,7 +33,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/kpango/glg"
 	comm "github.com/opencurve/curveadm/internal/common"
 	configure "github.com/opencurve/curveadm/internal/configure/curveadm"
 	"github.com/opencurve/curveadm/internal/configure/hosts"
@@ -46,6 +47,17 @@ import (
 	"github.com/opencurve/curveadm/pkg/module"
 )
 
+const (
+	SECRET_KEY_FILE = "secret.key"
+)
+
+// Operator is who performs the operation, it will be recorded in audit log
+type Operator struct {
+	User   string // OS user
+	Client string // address of ssh client or http client
+	Token  string // fingerprint of http token
+}
+
 type CurveAdm struct {
 	// project layout
 	rootDir      string
@@ -63,7 +75,7 @@ type CurveAdm struct {
 	in         io.Reader
 	out        io.Writer
 	err        io.Writer
-	storage    *storage.Storage
+	storage    storage.Storage
 	memStorage *utils.SafeMap
 
 	// properties (hosts/cluster)
@@ -76,6 +88,10 @@ type CurveAdm struct {
 	clusterTopologyData string         // cluster topology
 	clusterPoolData     string         // cluster pool
 	monitor             storage.Monitor
+
+	// audit
+	operator Operator
+	auditId  int64 // id of audit log for current operation, -1 if not audited
 }
 
 /*
@@ -83,6 +99,7 @@ type CurveAdm struct {
  *   - curveadm.cfg
  *   - /bin/curveadm
  *   - /data/curveadm.db
+ *   - /data/secret.key
  *   - /plugins/{shell,file,polarfs}
  *   - /logs/2006-01-02_15-04-05.log
  *   - /temp/
@@ -102,6 +119,7 @@ func NewCurveAdm() (*CurveAdm, error) {
 		tempDir:      path.Join(rootDir, "temp"),
 		httpConfPath: path.Join(rootDir, "http/conf"),
 		httpLogPath:  path.Join(rootDir, "http/logs"),
+		auditId:      -1,
 	}
 
 	err = curveadm.init()
@@ -138,10 +156,13 @@ func (curveadm *CurveAdm) init() error {
 	}
 	configure.ReplaceGlobals(config)
 
-	// (3) Init logger
-	now := time.Now().Format("2006-01-02_15-04-05")
-	logpath := fmt.Sprintf("%s/curveadm-%s.log", curveadm.logDir, now)
-	if err := log.Init(config.GetLogLevel(), logpath); err != nil {
+	// (3) Init logger: json format, rotated by size and age
+	logpath := fmt.Sprintf("%s/curveadm.log", curveadm.logDir)
+	if err := log.Init(config.GetLogLevel(), logpath, log.RotateConfig{
+		MaxSize:    config.GetLogMaxSize(),
+		MaxAge:     config.GetLogMaxAge(),
+		MaxBackups: config.GetLogMaxBackups(),
+	}); err != nil {
 		return errno.ERR_INIT_LOGGER_FAILED.E(err)
 	} else {
 		log.Info("Init logger success",
@@ -152,14 +173,24 @@ func (curveadm *CurveAdm) init() error {
 	// (4) Init error code
 	errno.Init(logpath)
 
-	// (5) New storage: create table in sqlite
+	// (5) New storage: create table in database (default: sqlite)
 	dbpath := fmt.Sprintf("%s/curveadm.db", curveadm.dataDir)
-	s, err := storage.NewStorage(dbpath)
+	dburl := config.GetDBUrl()
+	if len(dburl) == 0 {
+		dburl = fmt.Sprintf("sqlite://%s", dbpath)
+	} else if strings.HasPrefix(dburl, "sqlite://") {
+		dbpath = strings.TrimPrefix(dburl, "sqlite://")
+	}
+	s, err := storage.NewStorage(dburl)
 	if err != nil {
-		log.Error("Init SQLite database failed",
+		log.Error("Init database failed",
 			log.Field("Error", err))
+		if _, ok := err.(*errno.ErrorCode); ok {
+			return err
+		}
 		return errno.ERR_INIT_SQL_DATABASE_FAILED.E(err)
 	}
+	s.SetOperator(utils.GetCurrentUser(), -1)
 
 	// (6) Get hosts
 	var hosts storage.Hosts
@@ -225,11 +256,19 @@ func (curveadm *CurveAdm) init() error {
 	curveadm.clusterTopologyData = cluster.Topology
 	curveadm.clusterPoolData = cluster.Pool
 	curveadm.monitor = monitor
+	curveadm.operator = Operator{
+		User:   utils.GetCurrentUser(),
+		Client: sshClient(),
+	}
 
 	return nil
 }
 
 func (curveadm *CurveAdm) detectVersion() {
+	if !curveadm.config.GetTelemetry() {
+		return
+	}
+
 	latestVersion, err := tools.GetLatestVersion(Version)
 	if err != nil || len(latestVersion) == 0 {
 		return
@@ -249,7 +288,7 @@ func (curveadm *CurveAdm) detectVersion() {
 }
 
 func (curveadm *CurveAdm) Upgrade() (bool, error) {
-	if !curveadm.config.GetAutoUpgrade() {
+	if !curveadm.config.GetAutoUpgrade() || !curveadm.config.GetTelemetry() {
 		return false, nil
 	}
 
@@ -299,7 +338,7 @@ func (curveadm *CurveAdm) Engine() string                    { return curveadm.c
 func (curveadm *CurveAdm) In() io.Reader                     { return curveadm.in }
 func (curveadm *CurveAdm) Out() io.Writer                    { return curveadm.out }
 func (curveadm *CurveAdm) Err() io.Writer                    { return curveadm.err }
-func (curveadm *CurveAdm) Storage() *storage.Storage         { return curveadm.storage }
+func (curveadm *CurveAdm) Storage() storage.Storage          { return curveadm.storage }
 func (curveadm *CurveAdm) MemStorage() *utils.SafeMap        { return curveadm.memStorage }
 func (curveadm *CurveAdm) Hosts() string                     { return curveadm.hosts }
 func (curveadm *CurveAdm) Disks() string                     { return curveadm.disks }
@@ -310,7 +349,10 @@ func (curveadm *CurveAdm) ClusterName() string               { return curveadm.c
 func (curveadm *CurveAdm) ClusterTopologyData() string       { return curveadm.clusterTopologyData }
 func (curveadm *CurveAdm) ClusterPoolData() string           { return curveadm.clusterPoolData }
 func (curveadm *CurveAdm) Monitor() storage.Monitor          { return curveadm.monitor }
-func (curveadm *CurveAdm) SetDebugLevel()                    { glg.Get().SetLevel(glg.DEBG) }
+func (curveadm *CurveAdm) Operator() Operator                { return curveadm.operator }
+func (curveadm *CurveAdm) AuditId() int64                    { return curveadm.auditId }
+func (curveadm *CurveAdm) SetDebugLevel()                    { log.SetLevel("debug") }
+func (curveadm *CurveAdm) SetOperator(operator Operator)     { curveadm.operator = operator }
 
 func (curveadm *CurveAdm) GetHost(host string) (*hosts.HostConfig, error) {
 	if len(curveadm.Hosts()) == 0 {
@@ -418,6 +460,44 @@ func (curveadm *CurveAdm) GetFilesystemId(host, mountPoint string) string {
 	return utils.MD5Sum(filesystemId)[:12]
 }
 
+func (curveadm *CurveAdm) GetTargetId(host, user, volume string) string {
+	targetId := fmt.Sprintf("curvebs_target_%s_%s_%s", host, user, volume)
+	return utils.MD5Sum(targetId)[:12]
+}
+
+func (curveadm *CurveAdm) GetExportId(host, user, volume string) string {
+	exportId := fmt.Sprintf("curvebs_export_%s_%s_%s", host, user, volume)
+	return utils.MD5Sum(exportId)[:12]
+}
+
+// SecretKey returns the key which used to encrypt sensitive data stored in
+// database (e.g. target CHAP credentials), it will be generated at first use.
+// NOTE: the key file should be shared by all curveadm which use the same
+// database (e.g. MySQL), it can be specified by `secret_key_file` in curveadm.cfg
+func (curveadm *CurveAdm) SecretKey() (string, error) {
+	keypath := curveadm.config.GetSecretKeyFile()
+	if len(keypath) == 0 {
+		keypath = path.Join(curveadm.dataDir, SECRET_KEY_FILE)
+	}
+	if utils.PathExist(keypath) {
+		data, err := os.ReadFile(keypath)
+		if err != nil {
+			return "", errno.ERR_READ_SECRET_KEY_FILE_FAILED.E(err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := crand.Read(key); err != nil {
+		return "", errno.ERR_WRITE_SECRET_KEY_FILE_FAILED.E(err)
+	}
+	secret := hex.EncodeToString(key)
+	if err := os.WriteFile(keypath, []byte(secret), 0600); err != nil {
+		return "", errno.ERR_WRITE_SECRET_KEY_FILE_FAILED.E(err)
+	}
+	return secret, nil
+}
+
 func (curveadm *CurveAdm) ExecOptions() module.ExecOptions {
 	return module.ExecOptions{
 		ExecWithSudo:   true,
@@ -520,6 +600,33 @@ func (curveadm *CurveAdm) DiffTopology(data1, data2 string) ([]topology.Topology
 	return topology.DiffTopology(data1, data2, ctx)
 }
 
+// e.g. SSH_CLIENT="10.0.0.1 54321 22"
+func sshClient() string {
+	for _, key := range []string{"SSH_CLIENT", "SSH_CONNECTION"} {
+		if fields := strings.Fields(os.Getenv(key)); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+func (curveadm *CurveAdm) topologyRevision(clusterId int) int {
+	if clusterId <= 0 {
+		return 0
+	}
+
+	histories, err := curveadm.Storage().GetLatestHistory(clusterId, comm.HISTORY_KIND_TOPOLOGY)
+	if err != nil {
+		log.Error("Get latest topology revision failed",
+			log.Field("ClusterId", clusterId),
+			log.Field("Error", err))
+		return 0
+	} else if len(histories) == 0 {
+		return 0
+	}
+	return histories[0].Revision
+}
+
 func (curveadm *CurveAdm) PreAudit(now time.Time, args []string) int64 {
 	if len(args) == 0 {
 		return -1
@@ -527,13 +634,46 @@ func (curveadm *CurveAdm) PreAudit(now time.Time, args []string) int64 {
 		return -1
 	}
 
-	cwd, _ := os.Getwd()
 	command := fmt.Sprintf("curveadm %s", strings.Join(args, " "))
-	id, err := curveadm.Storage().InsertAuditLog(
-		now, cwd, command, comm.AUDIT_STATUS_ABORT)
+	id := curveadm.StartAudit(now, command, args)
+	if id >= 0 {
+		log.SetCorrelationId(id) // only one operation in the process
+	}
+	return id
+}
+
+// StartAudit inserts an audit log for the command which will be executed
+// by the operator, the returned id should be passed to PostAudit.
+// The args is the argv which used to replay the command, nil if the
+// command can't be replayed.
+func (curveadm *CurveAdm) StartAudit(now time.Time, command string, args []string) int64 {
+	cwd, _ := os.Getwd()
+	operator := curveadm.operator
+	clusterId := curveadm.clusterId
+	id, err := curveadm.Storage().InsertAuditLog(storage.AuditLog{
+		ExecuteTime:    now,
+		WorkDirectory:  cwd,
+		Command:        command,
+		Args:           args,
+		Status:         comm.AUDIT_STATUS_ABORT,
+		User:           operator.User,
+		Client:         operator.Client,
+		Token:          operator.Token,
+		ClusterId:      clusterId,
+		RevisionBefore: curveadm.topologyRevision(clusterId),
+	})
 	if err != nil {
 		log.Error("Insert audit log failed",
 			log.Field("Error", err))
+	} else {
+		curveadm.auditId = id
+		curveadm.Storage().SetOperator(operator.User, id)
+		log.Info("Start operation",
+			log.CorrelationField(id),
+			log.Field("Command", command),
+			log.Field("WorkDirectory", cwd),
+			log.Field("User", operator.User),
+			log.Field("Client", operator.Client))
 	}
 
 	return id
@@ -566,7 +706,16 @@ func (curveadm *CurveAdm) PostAudit(id int64, ec error) {
 		}
 	}
 
-	err = curveadm.Storage().SetAuditLogStatus(id, status, errorCode)
+	auditLog := auditLogs[0]
+	revision := curveadm.topologyRevision(auditLog.ClusterId)
+	duration := time.Since(auditLog.ExecuteTime)
+	log.SwitchLevel(ec)("Finish operation",
+		log.CorrelationField(id),
+		log.Field("Status", status),
+		log.Field("ErrorCode", errorCode),
+		log.Field("Duration", duration.String()))
+
+	err = curveadm.Storage().SetAuditLogStatus(id, status, errorCode, revision, duration)
 	if err != nil {
 		log.Error("Set audit log status failed",
 			log.Field("Error", err))
@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+/*
+ * The secret (e.g. password) is never passed by command line argument,
+ * because the arguments are recorded in audit log and curveadm log.
+ * It is read from (in order):
+ *   1) the file specified by user
+ *   2) the environment variable
+ *   3) the prompt
+ */
+type Secret struct {
+	Name string // prompt name, e.g. "volume password"
+	File string
+	Env  string
+}
+
+func ReadSecret(secret Secret) (string, error) {
+	if len(secret.File) > 0 {
+		data, err := os.ReadFile(secret.File)
+		if err != nil {
+			return "", errno.ERR_READ_SECRET_FILE_FAILED.
+				F("%s: %s", utils.AbsPath(secret.File), err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	} else if v, ok := os.LookupEnv(secret.Env); ok && len(secret.Env) > 0 {
+		return v, nil
+	}
+	return tui.PromptPassword("Enter %s", secret.Name), nil
+}
@@ -25,25 +25,64 @@
 package command
 
 import (
+	"strings"
+	"time"
+
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/tui"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	AUDIT_EXAMPLE = `Examples:
+  $ curveadm audit                                   # Display the last 20 audit logs
+  $ curveadm audit --cluster my-cluster --user curve # Display audit logs of cluster 'my-cluster' which run by user 'curve'
+  $ curveadm audit --status fail,cancel --since 24h  # Display failed and canceled audit logs in the last 24 hours
+  $ curveadm audit --since 2023-10-01 --until 2023-10-08 --format csv -n 0 > audit.csv  # Export all audit logs in the week to CSV file`
+
+	FORMAT_TABLE = "table"
+	FORMAT_JSON  = "json"
+	FORMAT_CSV   = "csv"
+)
+
+var (
+	AUDIT_TIME_LAYOUTS = []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006-01-02",
+	}
+
+	AUDIT_STATUSES = map[string]int{
+		"abort":   comm.AUDIT_STATUS_ABORT,
+		"success": comm.AUDIT_STATUS_SUCCESS,
+		"fail":    comm.AUDIT_STATUS_FAIL,
+		"cancel":  comm.AUDIT_STATUS_CANCEL,
+	}
+)
+
 type auditOptions struct {
 	tail    int
 	verbose bool
+	cluster string
+	user    string
+	status  string
+	since   string
+	until   string
+	format  string
 }
 
 func NewAuditCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options auditOptions
 
 	cmd := &cobra.Command{
-		Use:   "audit [OPTIONS]",
-		Short: "Show audit log of operation",
-		Args:  cliutil.NoArgs,
+		Use:     "audit [OPTIONS]",
+		Short:   "Show audit log of operation",
+		Args:    cliutil.NoArgs,
+		Example: AUDIT_EXAMPLE,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAudit(curveadm, options)
 		},
@@ -53,12 +92,120 @@ func NewAuditCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.IntVarP(&options.tail, "tail", "n", 20, "Number of lines to show from the end of the logs (0 means all)")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for clusters")
+	flags.StringVar(&options.cluster, "cluster", "", "Only show audit logs of specified cluster")
+	flags.StringVar(&options.user, "user", "", "Only show audit logs which run by specified user")
+	flags.StringVar(&options.status, "status", "", "Only show audit logs with specified status (success/fail/cancel/abort)")
+	flags.StringVar(&options.since, "since", "", "Only show audit logs since timestamp (e.g. 2023-10-01 10:00:00) or relative (e.g. 2h)")
+	flags.StringVar(&options.until, "until", "", "Only show audit logs before timestamp (e.g. 2023-10-01 10:00:00) or relative (e.g. 2h)")
+	flags.StringVar(&options.format, "format", FORMAT_TABLE, "Output format (table/json/csv)")
 
+	cmd.AddCommand(
+		NewAuditShowCommand(curveadm),
+		NewAuditReplayCommand(curveadm),
+	)
 	return cmd
 }
 
+// relative duration (e.g. 2h) or timestamp in local time zone
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	} else if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range AUDIT_TIME_LAYOUTS {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errno.ERR_INVALID_AUDIT_TIME.
+		F("time: %s", value)
+}
+
+func parseAuditStatuses(value string) ([]int, error) {
+	statuses := []int{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if len(item) == 0 {
+			continue
+		}
+		status, ok := AUDIT_STATUSES[item]
+		if !ok {
+			return nil, errno.ERR_INVALID_AUDIT_STATUS.
+				F("status: %s", item)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func getClusterNames(curveadm *cli.CurveAdm) (map[int]string, error) {
+	clusters, err := curveadm.Storage().GetClusters("%")
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
+	}
+
+	names := map[int]string{}
+	for _, cluster := range clusters {
+		names[cluster.Id] = cluster.Name
+	}
+	return names, nil
+}
+
+func getAuditFilter(options auditOptions, clusters map[int]string) (storage.AuditFilter, error) {
+	filter := storage.AuditFilter{User: options.user}
+	if len(options.cluster) > 0 {
+		for id, name := range clusters {
+			if name == options.cluster {
+				filter.ClusterId = id
+			}
+		}
+		if filter.ClusterId == 0 {
+			return filter, errno.ERR_CLUSTER_NOT_FOUND.
+				F("cluster name: %s", options.cluster)
+		}
+	}
+
+	var err error
+	now := time.Now()
+	if filter.Statuses, err = parseAuditStatuses(options.status); err != nil {
+		return filter, err
+	} else if filter.Since, err = parseAuditTime(options.since, now); err != nil {
+		return filter, err
+	} else if filter.Until, err = parseAuditTime(options.until, now); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func formatAuditLogs(auditLogs []storage.AuditLog, clusters map[int]string,
+	options auditOptions) (string, error) {
+	switch options.format {
+	case FORMAT_TABLE:
+		return tui.FormatAuditLogs(auditLogs, clusters, options.verbose), nil
+	case FORMAT_JSON:
+		return tui.FormatAuditLogsJSON(auditLogs, clusters)
+	case FORMAT_CSV:
+		return tui.FormatAuditLogsCSV(auditLogs, clusters)
+	}
+	return "", errno.ERR_UNSUPPORTED_AUDIT_FORMAT.
+		F("format: %s", options.format)
+}
+
 func runAudit(curveadm *cli.CurveAdm, options auditOptions) error {
-	auditLogs, err := curveadm.Storage().GetAuditLogs()
+	// 1) parse filter
+	clusters, err := getClusterNames(curveadm)
+	if err != nil {
+		return err
+	}
+	filter, err := getAuditFilter(options, clusters)
+	if err != nil {
+		return err
+	}
+
+	// 2) get audit logs
+	auditLogs, err := curveadm.Storage().GetAuditLogs(filter)
 	if err != nil {
 		return errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
 	}
@@ -67,7 +214,12 @@ func runAudit(curveadm *cli.CurveAdm, options auditOptions) error {
 	if tail != 0 && tail > 0 && tail < len(auditLogs) {
 		auditLogs = auditLogs[len(auditLogs)-tail:]
 	}
-	output := tui.FormatAuditLogs(auditLogs, options.verbose)
-	curveadm.WriteOut(output)
+
+	// 3) display or export
+	output, err := formatAuditLogs(auditLogs, clusters, options)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOut("%s", output)
 	return nil
 }
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/spf13/cobra"
+)
+
+const (
+	AUDIT_REPLAY_EXAMPLE = `Examples:
+  $ curveadm audit replay 12  # Execute the command of audit log 12 again`
+)
+
+type auditReplayOptions struct {
+	id int64
+}
+
+func NewAuditReplayCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options auditReplayOptions
+
+	cmd := &cobra.Command{
+		Use:     "replay ID",
+		Short:   "Execute the command of operation again",
+		Args:    cliutil.ExactArgs(1),
+		Example: AUDIT_REPLAY_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseAuditId(args[0])
+			if err != nil {
+				return err
+			}
+			options.id = id
+			return runAuditReplay(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+// only the command which run by curveadm itself records its argv,
+// the audit log inserted by old version or http service can't be replayed
+func replayArgs(auditLog storage.AuditLog) ([]string, error) {
+	args := auditLog.Args
+	if len(args) == 0 || args[0] == "audit" {
+		return nil, errno.ERR_AUDIT_COMMAND_NOT_REPLAYABLE.
+			F("command: %s", auditLog.Command)
+	}
+	return args, nil
+}
+
+// the command acts on the current cluster, so it should be replayed
+// in the cluster which it ran in, e.g. deploy, upgrade
+func checkReplayCluster(auditLog storage.AuditLog, current int, clusters map[int]string) error {
+	if auditLog.ClusterId <= 0 || auditLog.ClusterId == current {
+		return nil
+	}
+
+	name, ok := clusters[auditLog.ClusterId]
+	if !ok {
+		name = fmt.Sprintf("%d (removed)", auditLog.ClusterId)
+	}
+	return errno.ERR_REPLAY_AUDIT_CLUSTER_MISMATCHED.
+		F("cluster: %s, current cluster: %s", name, clusters[current])
+}
+
+func runAuditReplay(curveadm *cli.CurveAdm, options auditReplayOptions) error {
+	// 1) get audit log
+	auditLog, err := getAuditLog(curveadm, options.id)
+	if err != nil {
+		return err
+	}
+	args, err := replayArgs(auditLog)
+	if err != nil {
+		return err
+	}
+	clusters, err := getClusterNames(curveadm)
+	if err != nil {
+		return err
+	}
+	err = checkReplayCluster(auditLog, curveadm.ClusterId(), clusters)
+	if err != nil {
+		return err
+	}
+
+	// 2) confirm by user
+	cluster := "-"
+	if auditLog.ClusterId > 0 {
+		cluster = clusters[auditLog.ClusterId]
+	}
+	prompt := tuicomm.PromptReplayAudit(auditLog.Id, auditLog.Command, cluster, auditLog.WorkDirectory)
+	if pass := tuicomm.ConfirmYes("%s", prompt); !pass {
+		curveadm.WriteOut(tuicomm.PromptCancelOpetation("replay audit"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 3) execute the command in the work directory which it ran in,
+	//    it will be recorded as a new audit log
+	binary, err := os.Executable()
+	if err != nil {
+		return errno.ERR_REPLAY_AUDIT_COMMAND_FAILED.E(err)
+	}
+	cmd := exec.Command(binary, args...)
+	if cliutil.PathExist(auditLog.WorkDirectory) {
+		cmd.Dir = auditLog.WorkDirectory
+	}
+	cmd.Stdin = curveadm.In()
+	cmd.Stdout = curveadm.Out()
+	cmd.Stderr = curveadm.Err()
+	log.Info("Replay audit command",
+		log.Field("AuditId", auditLog.Id),
+		log.Field("Command", auditLog.Command))
+	if err := cmd.Run(); err != nil {
+		return errno.ERR_REPLAY_AUDIT_COMMAND_FAILED.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	// argument which contains space is kept as it is
+	args, err := replayArgs(storage.AuditLog{
+		Command: "curveadm deploy -c my topology.yaml",
+		Args:    []string{"deploy", "-c", "my topology.yaml"},
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"deploy", "-c", "my topology.yaml"}, args)
+
+	for _, auditLog := range []storage.AuditLog{
+		{Command: "curveadm deploy"},                  // inserted by old version
+		{Command: `http DeployCluster {"name":"c1"}`}, // inserted by http service
+		{Command: "curveadm audit replay 1", Args: []string{"audit", "replay", "1"}},
+	} {
+		_, err := replayArgs(auditLog)
+		assert.True(errors.Is(err, errno.ERR_AUDIT_COMMAND_NOT_REPLAYABLE), auditLog.Command)
+	}
+}
+
+func TestCheckReplayCluster(t *testing.T) {
+	assert := assert.New(t)
+	clusters := map[int]string{1: "c1", 2: "c2"}
+
+	assert.Nil(checkReplayCluster(storage.AuditLog{ClusterId: 1}, 1, clusters))
+	assert.Nil(checkReplayCluster(storage.AuditLog{ClusterId: -1}, 2, clusters)) // no cluster checked out
+
+	err := checkReplayCluster(storage.AuditLog{ClusterId: 1}, 2, clusters)
+	assert.True(errors.Is(err, errno.ERR_REPLAY_AUDIT_CLUSTER_MISMATCHED))
+	assert.Contains(err.Error(), "cluster: c1, current cluster: c2")
+
+	err = checkReplayCluster(storage.AuditLog{ClusterId: 3}, -1, clusters)
+	assert.True(errors.Is(err, errno.ERR_REPLAY_AUDIT_CLUSTER_MISMATCHED))
+	assert.Contains(err.Error(), "cluster: 3 (removed)")
+}
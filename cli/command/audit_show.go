@@ -0,0 +1,157 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/spf13/cobra"
+)
+
+const (
+	AUDIT_SHOW_EXAMPLE = `Examples:
+  $ curveadm audit show 12          # Display audit log 12 and all log lines of the operation
+  $ curveadm audit show 12 --json   # Display log lines in json format`
+)
+
+type auditShowOptions struct {
+	id   int64
+	json bool
+}
+
+func NewAuditShowCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options auditShowOptions
+
+	cmd := &cobra.Command{
+		Use:     "show ID [OPTIONS]",
+		Short:   "Display full log of operation",
+		Args:    cliutil.ExactArgs(1),
+		Example: AUDIT_SHOW_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseAuditId(args[0])
+			if err != nil {
+				return err
+			}
+			options.id = id
+			return runAuditShow(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.json, "json", false, "Display log lines in json format")
+
+	return cmd
+}
+
+func parseAuditId(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return -1, errno.ERR_INVALID_AUDIT_ID.
+			F("id: %s", arg)
+	}
+	return id, nil
+}
+
+func getAuditLog(curveadm *cli.CurveAdm, id int64) (storage.AuditLog, error) {
+	auditLogs, err := curveadm.Storage().GetAuditLog(id)
+	if err != nil {
+		return storage.AuditLog{}, errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
+	} else if len(auditLogs) != 1 {
+		return storage.AuditLog{}, errno.ERR_AUDIT_LOG_NOT_FOUND.
+			F("id: %d", id)
+	}
+	return auditLogs[0], nil
+}
+
+// log files sorted by modified time, including the rotated ones:
+//
+//	curveadm-2023-10-23T10-00-00.000.log, ..., curveadm.log
+func readOperationLog(logDir string, id int64) ([]log.Record, error) {
+	files, err := log.ListLogFiles(logDir)
+	if err != nil {
+		return nil, errno.ERR_READ_FILE_FAILED.E(err)
+	}
+
+	records := []log.Record{}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		items, err := log.FilterRecords(f, id)
+		f.Close()
+		if err != nil {
+			return nil, errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		records = append(records, items...)
+	}
+	return records, nil
+}
+
+func displayOperationLog(curveadm *cli.CurveAdm, auditLog storage.AuditLog,
+	clusters map[int]string, records []log.Record, options auditShowOptions) {
+	if options.json {
+		encoder := json.NewEncoder(curveadm.Out())
+		for _, record := range records {
+			encoder.Encode(record)
+		}
+		return
+	}
+
+	curveadm.WriteOut("%s", tui.FormatAuditLogs([]storage.AuditLog{auditLog}, clusters, true))
+	curveadm.WriteOutln("")
+	for _, record := range records {
+		curveadm.WriteOutln("%s", record.String())
+	}
+}
+
+func runAuditShow(curveadm *cli.CurveAdm, options auditShowOptions) error {
+	// 1) get audit log
+	auditLog, err := getAuditLog(curveadm, options.id)
+	if err != nil {
+		return err
+	}
+	clusters, err := getClusterNames(curveadm)
+	if err != nil {
+		return err
+	}
+
+	// 2) read all log lines which correlated with the audit log
+	records, err := readOperationLog(curveadm.LogDir(), options.id)
+	if err != nil {
+		return err
+	}
+
+	// 3) display
+	displayOperationLog(curveadm, auditLog, clusters, records, options)
+	return nil
+}
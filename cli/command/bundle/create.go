@@ -0,0 +1,209 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CREATE_EXAMPLE = `Examples:
+  $ curveadm bundle create                                   # Create bundle for current cluster
+  $ curveadm bundle create --monitor monitor.yaml            # Create bundle with monitor images
+  $ curveadm bundle create --client client.yaml -o /backup   # Create bundle with client images into /backup`
+)
+
+type createOptions struct {
+	output  string
+	monitor string
+	website string
+	clients []string
+}
+
+func NewCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options createOptions
+
+	cmd := &cobra.Command{
+		Use:     "create [OPTIONS]",
+		Short:   "Create offline bundle which contains all images and curveadm binary",
+		Args:    cliutil.NoArgs,
+		Example: CREATE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", ".", "Specify directory to save bundle")
+	flags.StringVar(&options.monitor, "monitor", "", "Specify monitor configuration file (default: the deployed one)")
+	flags.StringVar(&options.website, "website", "", "Specify website configuration file")
+	flags.StringSliceVarP(&options.clients, "client", "c", []string{}, "Specify client configuration file")
+
+	return cmd
+}
+
+func getMonitorImages(curveadm *cli.CurveAdm, options createOptions) ([]string, error) {
+	data := curveadm.Monitor().Monitor
+	if len(options.monitor) == 0 && (len(data) == 0 || data == comm.CLEANED_MONITOR_CONF) {
+		return []string{}, nil
+	} else if len(options.monitor) > 0 {
+		data = ""
+	}
+
+	hosts, hostIps, dcs, err := monitor.ParseTopology(curveadm)
+	if err != nil {
+		return nil, err
+	}
+	mcs, err := configure.ParseMonitorConfig(curveadm, options.monitor, data, hosts, hostIps, dcs)
+	if err != nil {
+		return nil, err
+	}
+	images := []string{}
+	for _, mc := range mcs {
+		images = append(images, mc.GetImage())
+	}
+	return images, nil
+}
+
+func getImages(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options createOptions) ([]string, error) {
+	// 1) images of topology
+	images := task.TopologyImages(dcs)
+
+	// 2) images of monitor
+	mimages, err := getMonitorImages(curveadm, options)
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, mimages...)
+
+	// 3) images of website
+	if len(options.website) > 0 {
+		wcs, err := configure.ParseWebsiteConfig(options.website)
+		if err != nil {
+			return nil, err
+		}
+		for _, wc := range wcs {
+			images = append(images, wc.GetImage())
+		}
+	}
+
+	// 4) images of clients
+	for _, filename := range options.clients {
+		cc, err := configure.ParseClientConfig(filename)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, cc.GetContainerImage())
+	}
+	return task.UniqueImages(images), nil
+}
+
+func genBundleOptions(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options createOptions) (task.BundleOptions, error) {
+	images, err := getImages(curveadm, dcs, options)
+	if err != nil {
+		return task.BundleOptions{}, err
+	}
+	binary, err := os.Executable()
+	if err != nil {
+		return task.BundleOptions{}, errno.ERR_GET_CURVEADM_BINARY_PATH_FAILED.E(err)
+	}
+	output, err := filepath.Abs(options.output)
+	if err == nil {
+		err = os.MkdirAll(output, 0755)
+	}
+	if err != nil {
+		return task.BundleOptions{}, errno.ERR_CREATE_DIRECTORY_FAILED.E(err)
+	}
+
+	// curve-bundle-20231018194017
+	name := fmt.Sprintf("curve-bundle-%s", time.Now().Format("20060102150405"))
+	return task.BundleOptions{
+		Dir:     path.Join(curveadm.TempDir(), name),
+		Archive: path.Join(output, name+".tar.gz"),
+		Images:  images,
+		Binary:  binary,
+	}, nil
+}
+
+func runCreate(curveadm *cli.CurveAdm, options createOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	} else if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 2) generate bundle options
+	bundleOptions, err := genBundleOptions(curveadm, dcs, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) generate create bundle playbook
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.CREATE_BUNDLE,
+		Configs: dcs[:1],
+		Options: map[string]interface{}{
+			comm.KEY_BUNDLE_OPTIONS: bundleOptions,
+		},
+	})
+
+	// 4) run playbook
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 5) print bundle
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Bundle saved to '%s', it contains %d images:",
+		bundleOptions.Archive, len(bundleOptions.Images)))
+	for _, image := range bundleOptions.Images {
+		curveadm.WriteOutln("  %s", image)
+	}
+	curveadm.WriteOutln("Deploy with it in air-gapped environment: curveadm deploy --bundle %s", bundleOptions.Archive)
+	return nil
+}
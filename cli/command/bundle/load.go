@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bundle
+
+import (
+	"path/filepath"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+// NewLoadOptions returns options for loading images from offline bundle,
+// the caller should remove the options.Dir after used
+func NewLoadOptions(curveadm *cli.CurveAdm, bundle string, images []string) (task.BundleOptions, error) {
+	archive, err := filepath.Abs(bundle)
+	if err != nil || !utils.PathExist(archive) {
+		return task.BundleOptions{}, errno.ERR_BUNDLE_FILE_NOT_FOUND.
+			F("bundle: %s", bundle)
+	}
+	return task.BundleOptions{
+		Dir:     utils.RandFilename(curveadm.TempDir()),
+		Archive: archive,
+		Images:  task.UniqueImages(images),
+	}, nil
+}
+
+// AddLoadSteps adds steps which unpack bundle and load images into hosts,
+// it is used to replace pulling image, images are loaded once for each host
+func AddLoadSteps(curveadm *cli.CurveAdm,
+	pb *playbook.Playbook,
+	hostnames []string,
+	options task.BundleOptions) error {
+	hcs := []*hosts.HostConfig{}
+	seen := map[string]bool{}
+	for _, hostname := range hostnames {
+		if seen[hostname] {
+			continue
+		}
+		seen[hostname] = true
+		hc, err := curveadm.GetHost(hostname)
+		if err != nil {
+			return err
+		}
+		hcs = append(hcs, hc)
+	}
+	if len(hcs) == 0 {
+		return nil
+	}
+
+	stepOptions := map[string]interface{}{
+		comm.KEY_BUNDLE_OPTIONS: options,
+	}
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.UNPACK_BUNDLE,
+		Configs: hcs[:1],
+		Options: stepOptions,
+	})
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.LOAD_BUNDLE_IMAGES,
+		Configs: hcs,
+		Options: stepOptions,
+	})
+	return nil
+}
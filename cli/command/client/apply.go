@@ -0,0 +1,613 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tui "github.com/opencurve/curveadm/internal/tui/client"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	APPLY_EXAMPLE = `Examples:
+  $ curveadm client apply -f clients.yaml          # Map/Mount clients declared in manifest
+  $ curveadm client apply -f clients.yaml --prune  # Also unmap/umount clients which not declared in manifest`
+
+	APPLY_ACTION_MAP        = "map"
+	APPLY_ACTION_UNMAP      = "unmap"
+	APPLY_ACTION_MOUNT      = "mount"
+	APPLY_ACTION_UMOUNT     = "umount"
+	APPLY_ACTION_ADD_TARGET = "add-target"
+	APPLY_ACTION_NONE       = "-"
+)
+
+type (
+	applyOptions struct {
+		filename    string
+		prune       bool
+		concurrency uint
+	}
+
+	applyItem struct {
+		id           string
+		cc           *configure.ClientConfig
+		mapOptions   bs.MapOptions
+		mountOptions fs.MountOptions
+		targetOption bs.TargetOption
+		tui.ApplyItem
+	}
+)
+
+func checkApplyOptions(curveadm *cli.CurveAdm, options applyOptions) error {
+	if !utils.PathExist(options.filename) {
+		return errno.ERR_CLIENT_MANIFEST_FILE_NOT_EXIST.
+			F("file path: %s", utils.AbsPath(options.filename))
+	}
+	return nil
+}
+
+func NewApplyCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options applyOptions
+
+	cmd := &cobra.Command{
+		Use:     "apply [OPTIONS]",
+		Short:   "Apply clients declared in manifest",
+		Args:    utils.NoArgs,
+		Example: APPLY_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkApplyOptions(curveadm, options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "file", "f", "clients.yaml", "Specify client manifest file")
+	flags.BoolVar(&options.prune, "prune", false, "Unmap/Umount clients which not declared in manifest")
+	flags.UintVar(&options.concurrency, "concurrency", 10, "Specify the number of clients applied concurrently")
+
+	return cmd
+}
+
+func newItem(action, kind, host, name, result string) *applyItem {
+	return &applyItem{
+		ApplyItem: tui.ApplyItem{
+			Action: action,
+			Kind:   kind,
+			Host:   host,
+			Name:   name,
+			Result: result,
+		},
+	}
+}
+
+func getManifestConfig(manifest *configure.ClientManifest, conf string,
+	overrides map[string]interface{}, kind string) (*configure.ClientConfig, error) {
+	cc, err := manifest.GetClientConfig(conf, overrides)
+	if err != nil {
+		return nil, err
+	} else if cc.GetKind() != kind && kind == topology.KIND_CURVEBS {
+		return nil, errno.ERR_REQUIRE_CURVEBS_KIND_CLIENT_CONFIGURE_FILE.
+			F("conf: %s, kind: %s", conf, cc.GetKind())
+	} else if cc.GetKind() != kind {
+		return nil, errno.ERR_REQUIRE_CURVEFS_KIND_CLIENT_CONFIGURE_FILE.
+			F("conf: %s, kind: %s", conf, cc.GetKind())
+	}
+	return cc, nil
+}
+
+func planMaps(curveadm *cli.CurveAdm, manifest *configure.ClientManifest,
+	exist map[string]bool) ([]*applyItem, error) {
+	items := []*applyItem{}
+	for _, m := range manifest.Maps {
+		user, name, err := ParseImage(m.Volume)
+		if err != nil {
+			return nil, err
+		}
+		size, err := ParseSize(m.Size)
+		if err != nil {
+			return nil, err
+		} else if _, err := curveadm.GetHost(m.Host); err != nil {
+			return nil, err
+		}
+
+		id := curveadm.GetVolumeId(m.Host, user, name)
+		if exist[id] {
+			items = append(items, newItem(APPLY_ACTION_NONE, topology.KIND_CURVEBS,
+				m.Host, m.Volume, tui.APPLY_RESULT_UNCHANGED))
+			continue
+		}
+
+		cc, err := getManifestConfig(manifest, m.Conf, m.Config, topology.KIND_CURVEBS)
+		if err != nil {
+			return nil, err
+		}
+		item := newItem(APPLY_ACTION_MAP, topology.KIND_CURVEBS,
+			m.Host, m.Volume, tui.APPLY_RESULT_PENDING)
+		item.id = id
+		item.cc = cc
+		item.mapOptions = bs.MapOptions{
+			Host:        m.Host,
+			User:        user,
+			Volume:      name,
+			Size:        size,
+			Create:      m.Create,
+			NoExclusive: m.NoExclusive,
+			Poolset:     m.Poolset,
+			Persistent:  m.Persistent,
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func planMounts(curveadm *cli.CurveAdm, manifest *configure.ClientManifest,
+	exist map[string]bool) ([]*applyItem, error) {
+	items := []*applyItem{}
+	for _, m := range manifest.Mounts {
+		if !strings.HasPrefix(m.MountPoint, "/") {
+			return nil, errno.ERR_FS_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH.
+				F("mount point: %s", m.MountPoint)
+		} else if _, err := curveadm.GetHost(m.Host); err != nil {
+			return nil, err
+		}
+
+		id := curveadm.GetFilesystemId(m.Host, m.MountPoint)
+		if exist[id] {
+			items = append(items, newItem(APPLY_ACTION_NONE, topology.KIND_CURVEFS,
+				m.Host, m.MountPoint, tui.APPLY_RESULT_UNCHANGED))
+			continue
+		}
+
+		cc, err := getManifestConfig(manifest, m.Conf, m.Config, topology.KIND_CURVEFS)
+		if err != nil {
+			return nil, err
+		}
+		item := newItem(APPLY_ACTION_MOUNT, topology.KIND_CURVEFS,
+			m.Host, m.MountPoint, tui.APPLY_RESULT_PENDING)
+		item.id = id
+		item.cc = cc
+		item.mountOptions = fs.MountOptions{
+			Host:        m.Host,
+			MountFSName: m.FSName,
+			MountFSType: m.FSType,
+			MountPoint:  m.MountPoint,
+			Persistent:  m.Persistent,
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func planTargets(curveadm *cli.CurveAdm, manifest *configure.ClientManifest) ([]*applyItem, error) {
+	items := []*applyItem{}
+	for _, m := range manifest.Targets {
+		user, name, err := ParseImage(m.Volume)
+		if err != nil {
+			return nil, err
+		}
+		size, err := ParseSize(m.Size)
+		if err != nil {
+			return nil, err
+		}
+		blocksize, err := ParseBlockSize(m.Blocksize)
+		if err != nil {
+			return nil, err
+		} else if _, err := curveadm.GetHost(m.Host); err != nil {
+			return nil, err
+		}
+
+		cc, err := getManifestConfig(manifest, m.Conf, m.Config, topology.KIND_CURVEBS)
+		if err != nil {
+			return nil, err
+		}
+		item := newItem(APPLY_ACTION_ADD_TARGET, topology.KIND_CURVEBS,
+			m.Host, m.Volume, tui.APPLY_RESULT_PENDING)
+		item.cc = cc
+		item.targetOption = bs.TargetOption{
+			Host:       m.Host,
+			User:       user,
+			Volume:     name,
+			Size:       size,
+			Blocksize:  blocksize,
+			Create:     m.Create,
+			Persistent: m.Persistent,
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// planPrune returns the clients which recorded but not declared in manifest
+func planPrune(curveadm *cli.CurveAdm, declared map[string]bool) ([]*applyItem, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	items := []*applyItem{}
+	for _, client := range clients {
+		if declared[client.Id] {
+			continue
+		}
+
+		var item *applyItem
+		switch client.Kind {
+		case topology.KIND_CURVEBS:
+			auxInfo := &bs.AuxInfo{}
+			if err := json.Unmarshal([]byte(client.AuxInfo), auxInfo); err != nil {
+				return nil, errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+			}
+			item = newItem(APPLY_ACTION_UNMAP, client.Kind, client.Host,
+				auxInfo.User+":"+auxInfo.Volume, tui.APPLY_RESULT_PENDING)
+			item.mapOptions = bs.MapOptions{
+				Host:   client.Host,
+				User:   auxInfo.User,
+				Volume: auxInfo.Volume,
+			}
+		case topology.KIND_CURVEFS:
+			auxInfo := &fs.AuxInfo{}
+			if err := json.Unmarshal([]byte(client.AuxInfo), auxInfo); err != nil {
+				return nil, errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+			}
+			item = newItem(APPLY_ACTION_UMOUNT, client.Kind, client.Host,
+				auxInfo.MountPoint, tui.APPLY_RESULT_PENDING)
+			item.mountOptions = fs.MountOptions{
+				Host:       client.Host,
+				MountPoint: auxInfo.MountPoint,
+			}
+		default:
+			continue
+		}
+		item.id = client.Id
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func planApply(curveadm *cli.CurveAdm, options applyOptions) ([]*applyItem, error) {
+	// 1) parse manifest
+	manifest, err := configure.ParseClientManifest(options.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) compare with clients table
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+	exist := map[string]bool{}
+	for _, client := range clients {
+		exist[client.Id] = true
+	}
+
+	items := []*applyItem{}
+	maps, err := planMaps(curveadm, manifest, exist)
+	if err != nil {
+		return nil, err
+	}
+	mounts, err := planMounts(curveadm, manifest, exist)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := planTargets(curveadm, manifest)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, maps...)
+	items = append(items, mounts...)
+	items = append(items, targets...)
+	if !options.prune {
+		return items, nil
+	}
+
+	// 3) unmap/umount clients which not declared
+	declared := map[string]bool{}
+	for _, m := range manifest.Maps {
+		user, name, _ := ParseImage(m.Volume)
+		declared[curveadm.GetVolumeId(m.Host, user, name)] = true
+	}
+	for _, m := range manifest.Mounts {
+		declared[curveadm.GetFilesystemId(m.Host, m.MountPoint)] = true
+	}
+	prunes, err := planPrune(curveadm, declared)
+	if err != nil {
+		return nil, err
+	}
+	return append(items, prunes...), nil
+}
+
+func filterItems(items []*applyItem, action string) []*applyItem {
+	out := []*applyItem{}
+	for _, item := range items {
+		if item.Action == action {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func genApplyPlaybook(curveadm *cli.CurveAdm,
+	items []*applyItem,
+	options applyOptions) *playbook.Playbook {
+	execOptions := playbook.ExecOptions{
+		Concurrency:     options.concurrency,
+		SkipError:       true,
+		ContinueOnError: true, // the items of other actions are still applied
+	}
+	pb := playbook.NewPlaybook(curveadm)
+
+	// unmap
+	unmaps := []interface{}{}
+	for _, item := range filterItems(items, APPLY_ACTION_UNMAP) {
+		unmaps = append(unmaps, item.mapOptions)
+	}
+	if len(unmaps) > 0 {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:        playbook.UNMAP_IMAGE,
+			Configs:     unmaps,
+			ExecOptions: execOptions,
+		})
+	}
+
+	// umount
+	umounts := []interface{}{}
+	for _, item := range filterItems(items, APPLY_ACTION_UMOUNT) {
+		umounts = append(umounts, item.mountOptions)
+	}
+	if len(umounts) > 0 {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:        playbook.UMOUNT_FILESYSTEM,
+			Configs:     umounts,
+			ExecOptions: execOptions,
+		})
+	}
+
+	// map
+	ccs, creates := []*configure.ClientConfig{}, []*configure.ClientConfig{}
+	mapSet := bs.MapOptionsSet{}
+	for _, item := range filterItems(items, APPLY_ACTION_MAP) {
+		ccs = append(ccs, item.cc)
+		if item.mapOptions.Create {
+			creates = append(creates, item.cc)
+		}
+		mapSet[item.cc] = item.mapOptions
+	}
+	steps := map[int][]*configure.ClientConfig{
+		playbook.START_NEBD_SERVICE: ccs,
+		playbook.CREATE_VOLUME:      creates,
+		playbook.MAP_IMAGE:          ccs,
+	}
+	for _, step := range []int{playbook.START_NEBD_SERVICE, playbook.CREATE_VOLUME, playbook.MAP_IMAGE} {
+		if len(steps[step]) == 0 {
+			continue
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:        step,
+			Configs:     steps[step],
+			Options:     map[string]interface{}{comm.KEY_MAP_OPTIONS: mapSet},
+			ExecOptions: execOptions,
+		})
+	}
+
+	// mount
+	ccs = []*configure.ClientConfig{}
+	mountSet := fs.MountOptionsSet{}
+	for _, item := range filterItems(items, APPLY_ACTION_MOUNT) {
+		ccs = append(ccs, item.cc)
+		mountSet[item.cc] = item.mountOptions
+	}
+	if len(ccs) > 0 {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:        playbook.MOUNT_FILESYSTEM,
+			Configs:     ccs,
+			Options:     map[string]interface{}{comm.KEY_MOUNT_OPTIONS: mountSet},
+			ExecOptions: execOptions,
+		})
+	}
+	return pb
+}
+
+func listTargetStores(curveadm *cli.CurveAdm, item *applyItem) (map[string]bool, error) {
+	curveadm.MemStorage().Set(comm.KEY_ALL_TARGETS, nil)
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range []int{playbook.START_TARGET_DAEMON, playbook.LIST_TARGETS} {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: item.cc,
+			Options: map[string]interface{}{
+				comm.KEY_TARGET_OPTIONS: bs.TargetOption{Host: item.Host},
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: step == playbook.LIST_TARGETS,
+			},
+		})
+	}
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	stores := map[string]bool{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_TARGETS)
+	if v != nil {
+		for _, target := range v.(map[string]*step.Target) {
+			stores[target.Store] = true
+		}
+	}
+	return stores, nil
+}
+
+// NOTE: targets on the same host share one target daemon,
+// so we add them one by one
+func applyTargets(curveadm *cli.CurveAdm, items []*applyItem) error {
+	var lastErr error
+	hosts := map[string][]*applyItem{}
+	for _, item := range filterItems(items, APPLY_ACTION_ADD_TARGET) {
+		hosts[item.Host] = append(hosts[item.Host], item)
+	}
+	for _, items := range hosts {
+		stores, err := listTargetStores(curveadm, items[0])
+		if err != nil {
+			for _, item := range items {
+				item.Result = tui.APPLY_RESULT_FAILED
+			}
+			lastErr = err
+			continue
+		}
+
+		for _, item := range items {
+			options := item.targetOption
+			if stores[bs.FormatImage(options.User, options.Volume)] {
+				item.Result = tui.APPLY_RESULT_UNCHANGED
+				continue
+			}
+
+			curveadm.WriteOutln("")
+			pb := playbook.NewPlaybook(curveadm)
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    playbook.ADD_TARGET,
+				Configs: item.cc,
+				Options: map[string]interface{}{
+					comm.KEY_TARGET_OPTIONS: options,
+				},
+			})
+			if err := pb.Run(); err != nil {
+				item.Result = tui.APPLY_RESULT_FAILED
+				lastErr = err
+			} else {
+				item.Result = tui.APPLY_RESULT_OK
+			}
+		}
+	}
+	return lastErr
+}
+
+// checkApplyResult checks result of each map/mount/unmap/umount item
+// by comparing with clients table and containers on hosts
+func checkApplyResult(curveadm *cli.CurveAdm, items []*applyItem) error {
+	_, reconciles, err := scanClients(curveadm, reconcileOptions{})
+	if err != nil {
+		return err
+	}
+
+	states := map[string]string{}
+	for _, reconcile := range reconciles {
+		states[reconcile.Id] = reconcile.State
+	}
+	for _, item := range items {
+		if item.Result != tui.APPLY_RESULT_PENDING {
+			continue
+		}
+
+		state, ok := states[item.id]
+		switch item.Action {
+		case APPLY_ACTION_MAP, APPLY_ACTION_MOUNT:
+			ok = ok && state == comm.CLIENT_RECONCILE_OK
+		case APPLY_ACTION_UNMAP, APPLY_ACTION_UMOUNT:
+			ok = !ok
+		}
+		item.Result = utils.Choose(ok, tui.APPLY_RESULT_OK, tui.APPLY_RESULT_FAILED)
+	}
+	return nil
+}
+
+func displayApply(curveadm *cli.CurveAdm, items []*applyItem) {
+	out := []tui.ApplyItem{}
+	for _, item := range items {
+		out = append(out, item.ApplyItem)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatApply(out))
+}
+
+func runApply(curveadm *cli.CurveAdm, options applyOptions) error {
+	// 1) compute the difference between manifest and clients table
+	items, err := planApply(curveadm, options)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Host < items[j].Host
+	})
+
+	// 2) display plan and confirm by user
+	displayApply(curveadm, items)
+	pending := 0
+	for _, item := range items {
+		if item.Result == tui.APPLY_RESULT_PENDING {
+			pending++
+		}
+	}
+	if pending == 0 {
+		curveadm.WriteOutln(color.GreenString("Nothing to apply, all clients are up-to-date"))
+		return nil
+	} else if pass := tuicomm.ConfirmYes(tuicomm.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOutln(tuicomm.PromptCancelOpetation("apply clients"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 3) run map/mount/unmap/umount playbook concurrently
+	pb := genApplyPlaybook(curveadm, items, options)
+	runErr := pb.Run()
+
+	// 4) add targets
+	if err := applyTargets(curveadm, items); err != nil {
+		runErr = err
+	}
+
+	// 5) check and display result of each item
+	curveadm.WriteOutln("")
+	if err := checkApplyResult(curveadm, items); err != nil {
+		return err
+	}
+	displayApply(curveadm, items)
+	for _, item := range items {
+		if item.Result != tui.APPLY_RESULT_FAILED {
+			continue
+		} else if runErr != nil {
+			return errno.ERR_APPLY_CLIENT_MANIFEST_PARTIALLY.E(runErr)
+		}
+		return errno.ERR_APPLY_CLIENT_MANIFEST_PARTIALLY
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Apply clients success ^_^"))
+	return nil
+}
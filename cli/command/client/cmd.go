@@ -42,6 +42,8 @@ func NewClientCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewMountCommand(curveadm),
 		NewUmountCommand(curveadm),
 		NewStatusCommand(curveadm),
+		NewReconcileCommand(curveadm),
+		NewApplyCommand(curveadm),
 		NewEnterCommand(curveadm),
 		// NewInstallCommand(curveadm),
 		// NewUninstallCommand(curveadm),
@@ -23,18 +23,21 @@
 package client
 
 import (
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/bundle"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
 	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -44,7 +47,11 @@ const (
   $ curveadm map user:/volume --host machine1 --create                  # Map volume which created by automatic
   $ curveadm map user:/volume --host machine1 --size=10GiB --create     # Map volume which size is 10GiB and created by automatic
   $ curveadm map user:/volume --host machine1 --create --poolset ssd    # Map volume created by automatic in poolset 'ssd'
-  $ curveadm map user:/volume --host machine1 -c /path/to/client.yaml   # Map volume with specified configure file`
+  $ curveadm map user:/volume --host machine1 -c /path/to/client.yaml   # Map volume with specified configure file
+  $ curveadm map user:/volume --host machine1 --persistent              # Map volume which survives host reboot
+  $ curveadm map user:/volume --host machine1 --bundle /path/to/bundle  # Map volume with client image from offline bundle`
+
+	DEFAULT_VOLUME_SIZE = "10GiB"
 )
 
 var (
@@ -66,6 +73,9 @@ type mapOptions struct {
 	filename    string
 	noExclusive bool
 	poolset     string
+	persistent  bool
+	replace     bool
+	bundle      string
 }
 
 func ParseImage(image string) (user, name string, err error) {
@@ -159,15 +169,18 @@ func NewMapCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
 	flags.BoolVar(&options.create, "create", false, "Create volume iff not exist")
 	flags.BoolVar(&options.noExclusive, "no-exclusive", false, "Map volume non exclusive")
-	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
+	flags.StringVar(&options.size, "size", DEFAULT_VOLUME_SIZE, "Specify volume size")
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.poolset, "poolset", "", "Specify the poolset")
+	flags.BoolVar(&options.persistent, "persistent", false, "Re-map volume to the same device after host reboot")
+	flags.StringVar(&options.bundle, "bundle", "", "Load client image from offline bundle instead of pulling it")
 	return cmd
 }
 
 func genMapPlaybook(curveadm *cli.CurveAdm,
 	ccs []*configure.ClientConfig,
-	options mapOptions) (*playbook.Playbook, error) {
+	options mapOptions,
+	bundleOptions *task.BundleOptions) (*playbook.Playbook, error) {
 	user, name, _ := ParseImage(options.image)
 	size, _ := ParseSize(options.size)
 	steps := MAP_PLAYBOOK_STEPS
@@ -176,6 +189,13 @@ func genMapPlaybook(curveadm *cli.CurveAdm,
 		if step == playbook.CREATE_VOLUME && !options.create {
 			continue
 		}
+		// load client image before starting nebd service which uses it
+		if step == playbook.START_NEBD_SERVICE && bundleOptions != nil {
+			err := bundle.AddLoadSteps(curveadm, pb, []string{options.host}, *bundleOptions)
+			if err != nil {
+				return nil, err
+			}
+		}
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: ccs,
@@ -188,6 +208,8 @@ func genMapPlaybook(curveadm *cli.CurveAdm,
 					Create:      options.create,
 					NoExclusive: options.noExclusive,
 					Poolset:     options.poolset,
+					Persistent:  options.persistent,
+					Replace:     options.replace,
 				},
 				comm.KEY_CLIENT_HOST:              options.host, // for checker
 				comm.KEY_CHECK_KERNEL_MODULE_NAME: comm.KERNERL_MODULE_NBD,
@@ -209,7 +231,16 @@ func runMap(curveadm *cli.CurveAdm, options mapOptions) error {
 	}
 
 	// 2) generate map playbook
-	pb, err := genMapPlaybook(curveadm, []*configure.ClientConfig{cc}, options)
+	var bundleOptions *task.BundleOptions
+	if len(options.bundle) > 0 {
+		bo, err := bundle.NewLoadOptions(curveadm, options.bundle, []string{cc.GetContainerImage()})
+		if err != nil {
+			return err
+		}
+		bundleOptions = &bo
+		defer os.RemoveAll(bo.Dir)
+	}
+	pb, err := genMapPlaybook(curveadm, []*configure.ClientConfig{cc}, options, bundleOptions)
 	if err != nil {
 		return err
 	}
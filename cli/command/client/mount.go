@@ -40,7 +40,8 @@ import (
 const (
 	MOUNT_EXAMPLE = `Examples:
   $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml [--fstype s3]    # Mount a s3 CurveFS '/s3_001' to '/path/to/mount'
-  $ curveadm mount /volume_001 /path/to/mount --host machine -c client.yaml --fstype volume  # Mount a volume CurveFS '/volume_001' to '/path/to/mount'`
+  $ curveadm mount /volume_001 /path/to/mount --host machine -c client.yaml --fstype volume  # Mount a volume CurveFS '/volume_001' to '/path/to/mount'
+  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml --persistent     # Mount a s3 CurveFS which survives host reboot`
 )
 
 var (
@@ -59,6 +60,8 @@ type mountOptions struct {
 	mountPoint  string
 	filename    string
 	insecure    bool
+	persistent  bool
+	replace     bool
 }
 
 func checkMountOptions(curveadm *cli.CurveAdm, options mountOptions) error {
@@ -95,6 +98,7 @@ func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.mountFSType, "fstype", "s3", "Specify fs data backend")
 	flags.BoolVarP(&options.insecure, "insecure", "k", false, "Mount without precheck")
+	flags.BoolVar(&options.persistent, "persistent", false, "Re-mount filesystem after host reboot")
 
 	return cmd
 }
@@ -119,6 +123,8 @@ func genMountPlaybook(curveadm *cli.CurveAdm,
 					MountFSName: options.mountFSName,
 					MountFSType: options.mountFSType,
 					MountPoint:  utils.TrimSuffixRepeat(options.mountPoint, "/"),
+					Persistent:  options.persistent,
+					Replace:     options.replace,
 				},
 				comm.KEY_CLIENT_HOST:              options.host, // for checker
 				comm.KEY_CHECK_KERNEL_MODULE_NAME: comm.KERNERL_MODULE_FUSE,
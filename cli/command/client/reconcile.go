@@ -0,0 +1,330 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tui "github.com/opencurve/curveadm/internal/tui/client"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	RECONCILE_EXAMPLE = `Examples:
+  $ curveadm client reconcile                                 # Compare clients with containers on all hosts
+  $ curveadm client reconcile --host machine1                 # Compare clients with containers on host 'machine1'
+  $ curveadm client reconcile --drop-stale                    # Drop records whose container is losed
+  $ curveadm client reconcile --repair -c /path/to/client.yaml  # Restart stopped clients and re-map/re-mount losed clients`
+)
+
+var (
+	SCAN_CLIENT_PLAYBOOK_STEPS = []int{
+		playbook.SCAN_CLIENT_CONTAINERS,
+	}
+
+	START_CLIENT_PLAYBOOK_STEPS = []int{
+		playbook.START_CLIENT_CONTAINER,
+	}
+)
+
+type reconcileOptions struct {
+	host        string
+	repair      bool
+	dropStale   bool
+	filename    string
+	mountFSType string
+}
+
+func NewReconcileCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options reconcileOptions
+
+	cmd := &cobra.Command{
+		Use:     "reconcile [OPTIONS]",
+		Short:   "Reconcile client records with containers on hosts",
+		Args:    utils.NoArgs,
+		Example: RECONCILE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReconcile(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Specify target host")
+	flags.BoolVar(&options.repair, "repair", false, "Restart stopped clients and re-map/re-mount losed clients")
+	flags.BoolVar(&options.dropStale, "drop-stale", false, "Drop records whose container is losed")
+	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file for re-map/re-mount")
+	flags.StringVar(&options.mountFSType, "fstype", "s3", "Specify fs data backend for re-mount if it's not recorded")
+
+	return cmd
+}
+
+func getReconcileHosts(curveadm *cli.CurveAdm, options reconcileOptions) ([]*hosts.HostConfig, error) {
+	if len(options.host) > 0 {
+		hc, err := curveadm.GetHost(options.host)
+		if err != nil {
+			return nil, err
+		}
+		return []*hosts.HostConfig{hc}, nil
+	}
+	return hosts.ParseHosts(curveadm.Hosts())
+}
+
+func genScanClientPlaybook(curveadm *cli.CurveAdm,
+	hcs []*hosts.HostConfig) (*playbook.Playbook, error) {
+	steps := SCAN_CLIENT_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: hcs,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func genStartClientPlaybook(curveadm *cli.CurveAdm,
+	clients []storage.Client) (*playbook.Playbook, error) {
+	config := []interface{}{}
+	for _, client := range clients {
+		config = append(config, client)
+	}
+
+	steps := START_CLIENT_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: config,
+		})
+	}
+	return pb, nil
+}
+
+func scanClients(curveadm *cli.CurveAdm, options reconcileOptions) (
+	[]storage.Client, []task.ClientReconcile, error) {
+	// 1) get all clients
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	// 2) scan client containers on hosts
+	hcs, err := getReconcileHosts(curveadm, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	pb, err := genScanClientPlaybook(curveadm, hcs)
+	if err != nil {
+		return nil, nil, err
+	} else if err = pb.Run(); err != nil {
+		return nil, nil, err
+	}
+
+	// 3) only reconcile clients which located in scanned hosts,
+	//    the target and export records are managed by their own command
+	containers := []task.ClientContainer{}
+	scanned := map[string]bool{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_CLIENT_CONTAINERS)
+	if v != nil {
+		for host, items := range v.(map[string][]task.ClientContainer) {
+			scanned[host] = true
+			containers = append(containers, items...)
+		}
+	}
+	out := []storage.Client{}
+	for _, client := range clients {
+		if !scanned[client.Host] {
+			continue
+		} else if client.Kind == bs.KIND_CURVEBS_TARGET || client.Kind == bs.KIND_CURVEBS_EXPORT {
+			continue
+		}
+		out = append(out, client)
+	}
+	return out, task.ReconcileClients(out, containers), nil
+}
+
+func remapClient(curveadm *cli.CurveAdm, cc *configure.ClientConfig, client storage.Client) error {
+	auxInfo := &bs.AuxInfo{}
+	err := json.Unmarshal([]byte(client.AuxInfo), auxInfo)
+	if err != nil {
+		return errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+
+	size := DEFAULT_VOLUME_SIZE
+	if auxInfo.Size > 0 {
+		size = fmt.Sprintf("%dGiB", auxInfo.Size)
+	}
+	pb, err := genMapPlaybook(curveadm, []*configure.ClientConfig{cc}, mapOptions{
+		image:       auxInfo.User + ":" + auxInfo.Volume,
+		host:        client.Host,
+		size:        size,
+		noExclusive: auxInfo.NoExclusive,
+		poolset:     auxInfo.Poolset,
+		persistent:  auxInfo.Persistent,
+		replace:     true,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return pb.Run()
+}
+
+func remountClient(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	client storage.Client, options reconcileOptions) error {
+	auxInfo := &fs.AuxInfo{}
+	err := json.Unmarshal([]byte(client.AuxInfo), auxInfo)
+	if err != nil {
+		return errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+
+	fstype := auxInfo.FSType
+	if len(fstype) == 0 { // recorded by old curveadm
+		fstype = options.mountFSType
+	}
+	pb, err := genMountPlaybook(curveadm, []*configure.ClientConfig{cc}, mountOptions{
+		host:        client.Host,
+		mountFSName: auxInfo.FSName,
+		mountFSType: fstype,
+		mountPoint:  auxInfo.MountPoint,
+		persistent:  auxInfo.Persistent,
+		replace:     true,
+	})
+	if err != nil {
+		return err
+	}
+	return pb.Run()
+}
+
+func repairStaleClient(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	client storage.Client, options reconcileOptions) error {
+	// the stale record will be replaced after the new container created
+	switch client.Kind {
+	case topology.KIND_CURVEBS:
+		return remapClient(curveadm, cc, client)
+	case topology.KIND_CURVEFS:
+		return remountClient(curveadm, cc, client, options)
+	}
+	return errno.ERR_UNSUPPORT_CLIENT_KIND.F("kind: %s", client.Kind)
+}
+
+func runReconcile(curveadm *cli.CurveAdm, options reconcileOptions) error {
+	// 1) compare clients with containers
+	clients, items, err := scanClients(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) display reconcile result
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatReconcile(items))
+	if !options.repair && !options.dropStale {
+		return nil
+	}
+
+	m := map[string]storage.Client{}
+	for _, client := range clients {
+		m[client.Id] = client
+	}
+	stopped, stale := []storage.Client{}, []storage.Client{}
+	for _, item := range items {
+		switch item.State {
+		case comm.CLIENT_RECONCILE_STOPPED:
+			stopped = append(stopped, m[item.Id])
+		case comm.CLIENT_RECONCILE_STALE:
+			stale = append(stale, m[item.Id])
+		}
+	}
+	if !options.repair {
+		stopped = []storage.Client{}
+	}
+	if len(stopped) == 0 && len(stale) == 0 {
+		return nil
+	}
+
+	// 3) parse client configure for re-map/re-mount
+	var cc *configure.ClientConfig
+	if options.repair && len(stale) > 0 && utils.PathExist(options.filename) {
+		cc, err = configure.ParseClientConfig(options.filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4) confirm by user
+	if pass := tuicomm.ConfirmYes(tuicomm.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOutln(tuicomm.PromptCancelOpetation("reconcile client"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 5) restart stopped clients
+	if len(stopped) > 0 {
+		pb, err := genStartClientPlaybook(curveadm, stopped)
+		if err != nil {
+			return err
+		} else if err = pb.Run(); err != nil {
+			return err
+		}
+	}
+
+	// 6) re-map/re-mount or drop stale clients
+	for _, client := range stale {
+		if options.repair && cc != nil && cc.GetKind() == client.Kind {
+			err = repairStaleClient(curveadm, cc, client, options)
+		} else if options.dropStale {
+			err = curveadm.Storage().DeleteClient(client.Id)
+			if err != nil {
+				err = errno.ERR_DELETE_CLIENT_FAILED.E(err)
+			}
+		} else {
+			curveadm.WriteOutln(color.YellowString("Skip stale client %s (%s), "+
+				"please specify %s client configure file by '-c'", client.Id, client.Host, client.Kind))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Reconcile clients success ^_^"))
+	return nil
+}
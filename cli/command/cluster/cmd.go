@@ -43,9 +43,8 @@ func NewClusterCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewCheckoutCommand(curveadm),
 		NewListCommand(curveadm),
 		NewRemoveCommand(curveadm),
-		// TODO(P1): enable export and import
-		//NewExportCommand(curveadm),
-		//NewImportCommand(curveadm),
+		NewExportCommand(curveadm),
+		NewImportCommand(curveadm),
 	)
 	return cmd
 }
@@ -23,33 +23,38 @@
 package cluster
 
 import (
-	"fmt"
 	"os"
+	"time"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/storage/archive"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 	"github.com/spf13/cobra"
 )
 
 const (
-	CLUSTER_NAME        = 0x01
-	CLUSTER_DESCRIPTION = 0x02
-	CLUSTER_CREATETIME  = 0x03
-	CLUSTER_TOPOLOGY    = 0x04
-	SERVICE             = 0x10
+	// passphrase for encrypting/decrypting archive, prompt for it if not set
+	ENV_ARCHIVE_PASSPHRASE = "CURVEADM_ARCHIVE_PASSPHRASE"
 )
 
 var (
 	exportExample = `Examples:
-  $ curveadm cluster export my-cluster                     # Export cluster 'my-cluster' 
-  $ curveadm cluster export my-cluster -o /path/to/dbfile  # Export cluster 'my-cluster' to specified file`
+  $ curveadm cluster export my-cluster                                   # Export cluster 'my-cluster' to 'my-cluster.json'
+  $ curveadm cluster export my-cluster -o /path/to/archive               # Export cluster 'my-cluster' to specified file
+  $ curveadm cluster export my-cluster --encrypt                         # Export cluster 'my-cluster' with encryption
+  $ curveadm cluster export my-cluster --encrypt --with-secrets          # Export cluster 'my-cluster' with SSH private keys`
 )
 
 type exportOptions struct {
-	name    string
-	outfile string
+	name        string
+	outfile     string
+	encrypt     bool
+	withSecrets bool
 }
 
 func NewExportCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -68,78 +73,224 @@ func NewExportCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.StringVarP(&options.outfile, "output", "o", "curveadm.db", "Output to specified database file")
+	flags.StringVarP(&options.outfile, "output", "o", "", "Output to specified archive file (default \"CLUSTER.json\")")
+	flags.BoolVar(&options.encrypt, "encrypt", false, "Encrypt the archive with passphrase")
+	flags.BoolVar(&options.withSecrets, "with-secrets", false, "Export SSH private keys which referenced by hosts")
 
 	return cmd
 }
 
-func writeItem(file *os.File, id int, value string) error {
-	key := fmt.Sprintf("--- %04d %d\n", id, len(value)+1)
-	if _, err := file.WriteString(key); err != nil {
-		return err
-	} else if _, err := file.WriteString(value + "\n"); err != nil {
-		return err
+func readPassphrase(confirm bool) (string, error) {
+	passphrase := os.Getenv(ENV_ARCHIVE_PASSPHRASE)
+	if len(passphrase) == 0 {
+		passphrase = tui.PromptPassword("Enter passphrase")
+		if confirm && passphrase != tui.PromptPassword("Confirm passphrase") {
+			return "", errno.ERR_PASSPHRASE_MISMATCH
+		}
 	}
-	return nil
+
+	if len(passphrase) == 0 {
+		return "", errno.ERR_EMPTY_PASSPHRASE
+	}
+	return passphrase, nil
 }
 
-func newMonitorWrite(file *os.File) (func(int, string) bool, func() error) {
-	var err error
-	return func(id int, value string) bool {
-			if err != nil {
-				return false
-			}
-			err = writeItem(file, id, value)
-			return err == nil
-		},
-		func() error {
-			return err
+// clients are not bound to cluster, so we export all clients with the same kind
+func exportClients(curveadm *cli.CurveAdm, kind string) ([]archive.Client, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	out := []archive.Client{}
+	for _, client := range clients {
+		if client.Kind != kind {
+			continue
+		}
+		out = append(out, archive.Client{
+			Id:          client.Id,
+			Kind:        client.Kind,
+			Host:        client.Host,
+			ContainerId: client.ContainerId,
+			AuxInfo:     client.AuxInfo,
+		})
+	}
+	return out, nil
+}
+
+// only export disk records which belong to the hosts of cluster
+func exportDiskRecords(curveadm *cli.CurveAdm, hostSet map[string]bool) []archive.Disk {
+	disks := []archive.Disk{}
+	for _, disk := range curveadm.DiskRecords() {
+		if !hostSet[disk.Host] {
+			continue
 		}
+		disks = append(disks, archive.Disk{
+			Host:               disk.Host,
+			Device:             disk.Device,
+			Size:               disk.Size,
+			URI:                disk.URI,
+			MountPoint:         disk.MountPoint,
+			FormatPercent:      disk.FormatPercent,
+			ContainerImage:     disk.ContainerImage,
+			ChunkServerID:      disk.ChunkServerID,
+			ServiceMountDevice: disk.ServiceMountDevice,
+		})
+	}
+	return disks
 }
 
-func exportCluster(cluster storage.Cluster, services []storage.Service, filename string) error {
-	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+func exportSecrets(curveadm *cli.CurveAdm) (map[string]string, error) {
+	hcs, err := hosts.ParseHosts(curveadm.Hosts())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	mw, me := newMonitorWrite(file)
+	secrets := map[string]string{}
+	for _, hc := range hcs {
+		keyfile := hc.GetPrivateKeyFile()
+		if len(keyfile) == 0 || len(secrets[keyfile]) > 0 {
+			continue
+		}
+		data, err := utils.ReadFile(keyfile)
+		if err != nil {
+			return nil, errno.ERR_READ_PRIVATE_KEY_FILE_FAILED.E(err)
+		}
+		secrets[keyfile] = data
+	}
+	return secrets, nil
+}
 
-	// dump cluster
-	if succ := mw(CLUSTER_NAME, cluster.Name) &&
-		mw(CLUSTER_DESCRIPTION, cluster.Description) &&
-		mw(CLUSTER_CREATETIME, cluster.CreateTime.Format("2006-01-02 15:04:05")) &&
-		mw(CLUSTER_TOPOLOGY, cluster.Topology); !succ {
-		return me()
+func exportCluster(curveadm *cli.CurveAdm, cluster storage.Cluster,
+	options exportOptions) (*archive.Archive, error) {
+	// 1) cluster and services
+	s := curveadm.Storage()
+	services, err := s.GetServices(cluster.Id)
+	if err != nil {
+		log.Error("Get services failed",
+			log.Field("ClusterId", cluster.Id),
+			log.Field("Error", err))
+		return nil, errno.ERR_GET_ALL_SERVICES_CONTAINER_ID_FAILED.E(err)
 	}
 
-	// dump service
+	a := &archive.Archive{
+		CurveAdmVersion: cli.Version,
+		ExportTime:      time.Now(),
+		Cluster: archive.Cluster{
+			Name:        cluster.Name,
+			UUId:        cluster.UUId,
+			Description: cluster.Description,
+			CreateTime:  cluster.CreateTime,
+			Topology:    cluster.Topology,
+			Pool:        cluster.Pool,
+		},
+		Services:    []archive.Service{},
+		Hosts:       curveadm.Hosts(),
+		Disks:       curveadm.Disks(),
+		DiskRecords: []archive.Disk{},
+		Clients:     []archive.Client{},
+	}
 	for _, service := range services {
-		value := fmt.Sprintf("%s %s", service.Id, service.ContainerId)
-		if succ := mw(SERVICE, value); !succ {
-			return me()
+		a.Services = append(a.Services, archive.Service{
+			Id:          service.Id,
+			ContainerId: service.ContainerId,
+		})
+	}
+
+	// 2) pinned image digests
+	digests, err := s.GetImageDigests(cluster.Id)
+	if err != nil {
+		return nil, errno.ERR_GET_IMAGE_DIGESTS_FAILED.E(err)
+	}
+	for _, digest := range digests {
+		a.ImageDigests = append(a.ImageDigests, archive.ImageDigest{
+			ServiceId: digest.ServiceId,
+			Image:     digest.Image,
+			Digest:    digest.Digest,
+		})
+	}
+
+	// 3) monitor
+	monitor, err := s.GetMonitor(cluster.Id)
+	if err != nil {
+		return nil, errno.ERR_GET_MONITOR_FAILED.E(err)
+	}
+	a.Monitor = monitor.Monitor
+
+	// 4) disk records and clients, which depend on the topology
+	if len(cluster.Topology) > 0 {
+		dcs, err := curveadm.ParseTopologyData(cluster.Topology)
+		if err != nil {
+			return nil, err
+		}
+		hostSet := map[string]bool{}
+		for _, dc := range dcs {
+			hostSet[dc.GetHost()] = true
+		}
+		a.DiskRecords = exportDiskRecords(curveadm, hostSet)
+		a.Clients, err = exportClients(curveadm, dcs[0].GetKind())
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return nil
+	// 5) secrets
+	if options.withSecrets {
+		a.Secrets, err = exportSecrets(curveadm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
 }
 
 func runExport(curveadm *cli.CurveAdm, options exportOptions) error {
+	// 1) check options
+	if options.withSecrets && !options.encrypt {
+		return errno.ERR_EXPORT_SECRETS_REQUIRES_ENCRYPT
+	}
 	name := options.name
-	storage := curveadm.Storage()
-	clusters, err := storage.GetClusters(name)
+	if len(options.outfile) == 0 {
+		options.outfile = name + ".json"
+	}
+
+	// 2) get cluster
+	clusters, err := curveadm.Storage().GetClusters(name)
 	if err != nil {
-		log.Error("GetClusters", log.Field("error", err))
-		return err
+		log.Error("Get clusters failed",
+			log.Field("ClusterName", name),
+			log.Field("Error", err))
+		return errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
 	} else if len(clusters) == 0 {
-		return fmt.Errorf("cluster %s not exist", name)
-	} else if services, err := storage.GetServices(clusters[0].Id); err != nil {
-		log.Error("GetServices", log.Field("error", err))
-		return err
-	} else if err = exportCluster(clusters[0], services, options.outfile); err != nil {
+		return errno.ERR_CLUSTER_NOT_FOUND.
+			F("cluster name: %s", name)
+	}
+
+	// 3) collect all state of cluster
+	a, err := exportCluster(curveadm, clusters[0], options)
+	if err != nil {
 		return err
 	}
 
-	curveadm.WriteOut("Export cluster '%s' to '%s' success\n", name, options.outfile)
+	// 4) encode (and encrypt) archive
+	passphrase := ""
+	if options.encrypt {
+		passphrase, err = readPassphrase(true)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := archive.Marshal(a, passphrase)
+	if err != nil {
+		return errno.ERR_WRITE_CLUSTER_ARCHIVE_FAILED.E(err)
+	}
+
+	// 5) write archive, it may contains secrets, so only owner can read it
+	err = utils.WriteFile(options.outfile, string(data), 0600)
+	if err != nil {
+		return errno.ERR_WRITE_CLUSTER_ARCHIVE_FAILED.E(err)
+	}
+
+	curveadm.WriteOutln("Export cluster '%s' to '%s' success", name, options.outfile)
 	return nil
 }
@@ -26,49 +26,67 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/storage/archive"
 	"github.com/opencurve/curveadm/internal/utils"
-	"github.com/opencurve/curveadm/pkg/log/zaplog"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
 	"github.com/spf13/cobra"
 )
 
+// legacy export format: --- $ID $LENGTH\n$VALUE\n
 const (
+	CLUSTER_NAME        = 0x01
+	CLUSTER_DESCRIPTION = 0x02
+	CLUSTER_CREATETIME  = 0x03
+	CLUSTER_TOPOLOGY    = 0x04
+	SERVICE             = 0x10
+
 	MAX_VALUE_BYETS = 1024 * 1024 // 1MB
 )
 
 var (
 	importExample = `Examples:
-  $ curveadm cluster import my-cluster                     # Import a cluster named 'my-cluster' 
-  $ curveadm cluster import my-cluster -f /path/to/dbfile  # Import cluster 'my-cluster' with specified database file`
+  $ curveadm cluster import -f /path/to/archive                # Import cluster with the name in archive
+  $ curveadm cluster import my-cluster -f /path/to/archive     # Import cluster and rename it to 'my-cluster'
+  $ curveadm cluster import -f /path/to/archive --force        # Import cluster and overwrite the local hosts/disks`
 )
 
 type importOptions struct {
 	name   string
 	dbfile string
+	force  bool
 }
 
 func NewImportCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options importOptions
 
 	cmd := &cobra.Command{
-		Use:     "import CLUSTER [OPTIONS]",
+		Use:     "import [CLUSTER] [OPTIONS]",
 		Short:   "Import cluster",
-		Args:    utils.ExactArgs(1),
+		Args:    utils.RequiresMaxArgs(1),
 		Example: importExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.name = args[0]
+			if len(args) > 0 {
+				options.name = args[0]
+			}
 			return runImport(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
 	}
 
 	flags := cmd.Flags()
-	flags.StringVarP(&options.dbfile, "database", "f", "curveadm.db", "Specify the path of database file")
+	flags.StringVarP(&options.dbfile, "database", "f", "", "Specify the path of archive file which exported by 'cluster export'")
+	flags.BoolVar(&options.force, "force", false, "Overwrite the local hosts and disks if they are different from archive")
+	cmd.MarkFlagRequired("database")
 
 	return cmd
 }
@@ -118,12 +136,12 @@ func readItem(file *os.File) (int, string, error) {
 	return id, string(bytes[:nbytes-1]), nil
 }
 
-func readDatabase(filename string) (storage.Cluster, []storage.Service, error) {
-	cluster := storage.Cluster{}
-	services := []storage.Service{}
+// read the file which exported by legacy curveadm
+func readDatabase(filename string) (*archive.Archive, error) {
+	a := &archive.Archive{}
 	file, err := os.Open(filename)
 	if err != nil {
-		return cluster, services, err
+		return nil, err
 	}
 	defer file.Close()
 
@@ -132,71 +150,331 @@ func readDatabase(filename string) (storage.Cluster, []storage.Service, error) {
 		if err == io.EOF {
 			break
 		} else if err != nil {
-			return cluster, services, err
+			return nil, err
 		}
 
 		switch id {
+		case CLUSTER_NAME:
+			a.Cluster.Name = value
 		case CLUSTER_DESCRIPTION:
-			cluster.Description = value
+			a.Cluster.Description = value
 		case CLUSTER_TOPOLOGY:
-			cluster.Topology = value
+			a.Cluster.Topology = value
 		case SERVICE:
 			items := strings.Split(value, " ")
 			if len(items) != 2 {
-				return cluster, services, fmt.Errorf("invalid service, line: %s", value)
+				return nil, fmt.Errorf("invalid service, line: %s", value)
 			}
-			service := storage.Service{
+			a.Services = append(a.Services, archive.Service{
 				Id:          items[0],
 				ContainerId: items[1],
-			}
-			services = append(services, service)
+			})
 		}
 	}
 
-	return cluster, services, nil
+	return a, nil
 }
 
-func importCluster(storage *storage.Storage, name, dbfile string) error {
-	// read database file
-	cluster, services, err := readDatabase(dbfile)
+func readArchive(filename string) (*archive.Archive, error) {
+	if !utils.PathExist(filename) {
+		return nil, errno.ERR_READ_CLUSTER_ARCHIVE_FAILED.
+			F("%s: no such file", utils.AbsPath(filename))
+	}
+
+	data, err := os.ReadFile(filename)
 	if err != nil {
-		return err
+		return nil, errno.ERR_READ_CLUSTER_ARCHIVE_FAILED.E(err)
+	} else if !archive.IsArchive(data) {
+		a, err := readDatabase(filename)
+		if err != nil {
+			return nil, errno.ERR_INVALID_CLUSTER_ARCHIVE.E(err)
+		}
+		return a, nil
 	}
 
-	// insert cluster
-	err = storage.InsertCluster(name, cluster.Description, cluster.Topology)
+	encrypted, err := archive.IsEncrypted(data)
 	if err != nil {
-		return err
+		return nil, errno.ERR_INVALID_CLUSTER_ARCHIVE.E(err)
+	}
+	passphrase := ""
+	if encrypted {
+		passphrase, err = readPassphrase(false)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// insert service
-	clusters, err := storage.GetClusters(name)
+	a, err := archive.Unmarshal(data, passphrase)
+	if err == archive.ErrDecryptFailed {
+		return nil, errno.ERR_DECRYPT_CLUSTER_ARCHIVE_FAILED
+	} else if err != nil {
+		return nil, errno.ERR_INVALID_CLUSTER_ARCHIVE.E(err)
+	}
+	return a, nil
+}
+
+func checkConflict(curveadm *cli.CurveAdm, name string, a *archive.Archive) error {
+	clusters, err := curveadm.Storage().GetClusters("%")
 	if err != nil {
-		return err
+		return errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
 	}
-	clusterId := clusters[0].Id
-	for _, service := range services {
-		if err := storage.InsertService(clusterId, service.Id, service.ContainerId); err != nil {
-			return err
+
+	for _, cluster := range clusters {
+		if cluster.Name == name {
+			return errno.ERR_CLUSTER_ALREADY_EXIST.
+				F("cluster name: %s, please specify another name to rename it: "+
+					"curveadm cluster import NEW-NAME -f FILE", name)
+		} else if len(a.Cluster.UUId) > 0 && cluster.UUId == a.Cluster.UUId {
+			return errno.ERR_CLUSTER_UUID_ALREADY_EXIST.
+				F("cluster '%s' has the same uuid %s", cluster.Name, cluster.UUId)
+		}
+	}
+	return nil
+}
+
+// import hosts/disks iff the local one is empty, or it's forced
+func importConfig(curveadm *cli.CurveAdm, kind, local, data string,
+	force bool, set func(string) error) error {
+	if len(data) == 0 || local == data {
+		return nil
+	} else if len(local) > 0 && !force {
+		curveadm.WriteOutln("WARNING: local %s is different from archive, skip it "+
+			"(use --force to overwrite it)", kind)
+		return nil
+	}
+	return set(data)
+}
+
+// only the SSH private key files referenced by hosts of archive can be written,
+// the crafted archive may contain any other path, e.g. /etc/cron.d/xxx
+func checkSecrets(data string, secrets map[string]string) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	referenced := map[string]bool{}
+	if len(data) > 0 {
+		keyfiles, err := hosts.PrivateKeyFiles(data)
+		if err != nil {
+			return errno.ERR_INVALID_CLUSTER_ARCHIVE.E(err)
+		}
+		referenced = utils.Slice2Map(keyfiles)
+	}
+	for keyfile := range secrets {
+		if !referenced[keyfile] || !filepath.IsAbs(keyfile) {
+			return errno.ERR_INVALID_CLUSTER_ARCHIVE.
+				F("secret '%s' is not SSH private key file referenced by hosts", keyfile)
+		}
+	}
+	return nil
+}
+
+// write the SSH private key iff it doesn't exist, we never overwrite it
+func importSecrets(curveadm *cli.CurveAdm, secrets map[string]string) error {
+	for keyfile, data := range secrets {
+		if utils.PathExist(keyfile) {
+			if content, err := utils.ReadFile(keyfile); err != nil || content != data {
+				curveadm.WriteOutln("WARNING: SSH private key '%s' already exist "+
+					"and is different from archive, skip it", keyfile)
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(keyfile), 0700); err != nil {
+			return errno.ERR_WRITE_PRIVATE_KEY_FILE_FAILED.E(err)
+		} else if err := utils.WriteFile(keyfile, data, 0600); err != nil {
+			return errno.ERR_WRITE_PRIVATE_KEY_FILE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func importDiskRecords(s storage.Storage, disks []archive.Disk) error {
+	for _, disk := range disks {
+		records, err := s.GetDisk(comm.DISK_FILTER_DEVICE, disk.Host, disk.Device)
+		if err != nil {
+			return errno.ERR_GET_DISK_RECORDS_FAILED.E(err)
+		} else if len(records) > 0 {
+			continue // keep the local record
+		}
+
+		err = s.SetDisk(disk.Host, disk.Device, disk.MountPoint, disk.ContainerImage,
+			disk.FormatPercent, disk.ServiceMountDevice)
+		if err == nil && len(disk.URI) > 0 {
+			err = s.UpdateDiskURI(disk.URI, disk.Host, disk.Device)
+		}
+		if err == nil && len(disk.Size) > 0 {
+			err = s.UpdateDiskSize(disk.Size, disk.Host, disk.Device)
+		}
+		if err == nil && len(disk.ChunkServerID) > 0 {
+			err = s.UpdateDiskChunkServerID(disk.Host, disk.MountPoint, disk.ChunkServerID)
+		}
+		if err != nil {
+			return errno.ERR_UPDATE_DISK_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func importClients(curveadm *cli.CurveAdm, s storage.Storage, clients []archive.Client) error {
+	for _, client := range clients {
+		records, err := s.GetClient(client.Id)
+		if err != nil {
+			return errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+		} else if len(records) > 0 {
+			curveadm.WriteOutln("WARNING: client '%s' already exist, skip it", client.Id)
+			continue
+		}
+
+		err = s.InsertClient(client.Id, client.Kind, client.Host,
+			client.ContainerId, client.AuxInfo)
+		if err != nil {
+			return errno.ERR_INSERT_CLIENT_FAILED.E(err)
 		}
 	}
 	return nil
 }
 
+func importCluster(s storage.Storage, name string, a *archive.Archive) error {
+	if len(a.Cluster.UUId) == 0 { // legacy export file
+		err := s.InsertCluster(name, a.Cluster.Description, a.Cluster.Topology)
+		if err != nil {
+			return errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+		}
+		clusters, err := s.GetClusters(name)
+		if err != nil {
+			return errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+		}
+		for _, service := range a.Services {
+			err := s.InsertService(clusters[0].Id, service.Id, service.ContainerId)
+			if err != nil {
+				return errno.ERR_INSERT_SERVICE_CONTAINER_ID_FAILED.E(err)
+			}
+		}
+		return nil
+	}
+
+	cluster := storage.Cluster{
+		UUId:        a.Cluster.UUId,
+		Name:        name,
+		Description: a.Cluster.Description,
+		CreateTime:  a.Cluster.CreateTime,
+		Topology:    a.Cluster.Topology,
+		Pool:        a.Cluster.Pool,
+	}
+	services := []storage.Service{}
+	for _, service := range a.Services {
+		services = append(services, storage.Service{
+			Id:          service.Id,
+			ContainerId: service.ContainerId,
+		})
+	}
+	if err := s.ImportCluster(cluster, services); err != nil {
+		return errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+	}
+
+	if len(a.Monitor) > 0 {
+		clusters, err := s.GetClusters(name)
+		if err != nil {
+			return errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+		}
+		err = s.ReplaceMonitor(storage.Monitor{ClusterId: clusters[0].Id, Monitor: a.Monitor})
+		if err != nil {
+			return errno.ERR_REPLACE_MONITOR_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+// restore the pinned image digests, so the imported cluster pulls the
+// same images as the exported one
+func importImageDigests(s storage.Storage, name string, digests []archive.ImageDigest) error {
+	if len(digests) == 0 {
+		return nil
+	}
+
+	clusters, err := s.GetClusters(name)
+	if err != nil {
+		return errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+	}
+	pinned := []storage.ImageDigest{}
+	for _, digest := range digests {
+		pinned = append(pinned, storage.ImageDigest{
+			ServiceId: digest.ServiceId,
+			Image:     digest.Image,
+			Digest:    digest.Digest,
+		})
+	}
+	err = s.SetImageDigests(clusters[0].Id, pinned)
+	if err != nil {
+		return errno.ERR_SET_IMAGE_DIGESTS_FAILED.E(err)
+	}
+	return nil
+}
+
 func runImport(curveadm *cli.CurveAdm, options importOptions) error {
-	name := options.name
-	storage := curveadm.Storage()
-	clusters, err := storage.GetClusters(name)
+	// 1) read and validate archive
+	a, err := readArchive(options.dbfile)
 	if err != nil {
-		zaplog.Error("GetClusters", zaplog.Field("error", err))
 		return err
-	} else if len(clusters) != 0 {
-		return fmt.Errorf("cluster %s already exist", name)
-	} else if err := importCluster(storage, name, options.dbfile); err != nil {
-		storage.DeleteCluster(name)
+	}
+	name := options.name
+	if len(name) == 0 {
+		name = a.Cluster.Name
+	}
+	if len(name) == 0 {
+		return errno.ERR_INVALID_CLUSTER_ARCHIVE.
+			S("cluster name is missing in archive, please specify it")
+	}
+
+	// 2) check wether cluster name or uuid conflict
+	if err := checkConflict(curveadm, name, a); err != nil {
+		return err
+	}
+
+	// 3) check secrets before importing anything
+	if err := checkSecrets(a.Hosts, a.Secrets); err != nil {
+		return err
+	}
+
+	// 4) import cluster, services, image digests, monitor, disk records,
+	//    clients, hosts and disks in one transaction
+	err = curveadm.Storage().Transaction(func(s storage.Storage) error {
+		if err := importCluster(s, name, a); err != nil {
+			return err
+		} else if err := importImageDigests(s, name, a.ImageDigests); err != nil {
+			return err
+		} else if err := importDiskRecords(s, a.DiskRecords); err != nil {
+			return err
+		} else if err := importClients(curveadm, s, a.Clients); err != nil {
+			return err
+		}
+
+		err := importConfig(curveadm, "hosts", curveadm.Hosts(), a.Hosts, options.force, s.SetHosts)
+		if err != nil {
+			return errno.ERR_UPDATE_HOSTS_FAILED.E(err)
+		}
+		err = importConfig(curveadm, "disks", curveadm.Disks(), a.Disks, options.force, s.SetDisks)
+		if err != nil {
+			return errno.ERR_UPDATE_DISKS_FAILED.E(err)
+		}
+		return nil
+	})
+	if _, ok := err.(*errno.ErrorCode); err != nil && !ok {
+		err = errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+	}
+
+	// 5) write the secrets which shared by all clusters after committed
+	if err == nil {
+		err = importSecrets(curveadm, a.Secrets)
+	}
+	if err != nil {
+		log.Error("Import cluster failed",
+			log.Field("ClusterName", name),
+			log.Field("Error", err))
 		return err
 	}
 
-	curveadm.WriteOut("Cluster '%s' imported\n", name)
+	curveadm.WriteOutln("Cluster '%s' imported", name)
 	return nil
 }
@@ -0,0 +1,54 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSecrets(t *testing.T) {
+	assert := assert.New(t)
+	data := `hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+    private_key_file: /home/curve/.ssh/id_rsa
+`
+
+	assert.Nil(checkSecrets(data, nil))
+	assert.Nil(checkSecrets(data, map[string]string{"/home/curve/.ssh/id_rsa": "PRIVATE KEY"}))
+
+	// the path which isn't referenced by hosts
+	for _, secrets := range []map[string]string{
+		{"/etc/cron.d/curveadm": "* * * * * root id"},
+		{"/home/curve/.ssh/id_rsa": "PRIVATE KEY", "/root/.ssh/authorized_keys": "ssh-rsa"},
+	} {
+		err := checkSecrets(data, secrets)
+		assert.True(errors.Is(err, errno.ERR_INVALID_CLUSTER_ARCHIVE))
+	}
+	err := checkSecrets("", map[string]string{"/home/curve/.ssh/id_rsa": "PRIVATE KEY"})
+	assert.True(errors.Is(err, errno.ERR_INVALID_CLUSTER_ARCHIVE))
+}
@@ -28,16 +28,21 @@ import (
 	"fmt"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/bundle"
 	"github.com/opencurve/curveadm/cli/command/client"
 	"github.com/opencurve/curveadm/cli/command/cluster"
 	"github.com/opencurve/curveadm/cli/command/config"
+	"github.com/opencurve/curveadm/cli/command/cores"
 	"github.com/opencurve/curveadm/cli/command/disks"
+	"github.com/opencurve/curveadm/cli/command/export"
+	"github.com/opencurve/curveadm/cli/command/fs"
 	"github.com/opencurve/curveadm/cli/command/hosts"
 	"github.com/opencurve/curveadm/cli/command/http"
 	"github.com/opencurve/curveadm/cli/command/monitor"
 	"github.com/opencurve/curveadm/cli/command/pfs"
 	"github.com/opencurve/curveadm/cli/command/playground"
 	"github.com/opencurve/curveadm/cli/command/target"
+	"github.com/opencurve/curveadm/cli/command/volume"
 	"github.com/opencurve/curveadm/cli/command/website"
 	"github.com/opencurve/curveadm/internal/errno"
 	tools "github.com/opencurve/curveadm/internal/tools/upgrade"
@@ -61,13 +66,18 @@ type rootOptions struct {
 
 func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 	cmd.AddCommand(
+		bundle.NewBundleCommand(curveadm),         // curveadm bundle ...
 		client.NewClientCommand(curveadm),         // curveadm client
 		cluster.NewClusterCommand(curveadm),       // curveadm cluster ...
 		config.NewConfigCommand(curveadm),         // curveadm config ...
+		cores.NewCoresCommand(curveadm),           // curveadm cores ...
 		hosts.NewHostsCommand(curveadm),           // curveadm hosts ...
 		disks.NewDisksCommand(curveadm),           // curveadm disks ...
+		fs.NewFSCommand(curveadm),                 // curveadm fs ...
 		playground.NewPlaygroundCommand(curveadm), // curveadm playground ...
 		target.NewTargetCommand(curveadm),         // curveadm target ...
+		export.NewExportCommand(curveadm),         // curveadm export ...
+		volume.NewVolumeCommand(curveadm),         // curveadm volume ...
 		pfs.NewPFSCommand(curveadm),               // curveadm pfs ...
 		monitor.NewMonitorCommand(curveadm),       // curveadm monitor ...
 		http.NewHttpCommand(curveadm),             // curveadm http
@@ -80,6 +90,8 @@ func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 		NewEnterCommand(curveadm),      // curveadm enter
 		NewExecCommand(curveadm),       // curveadm exec
 		NewFormatCommand(curveadm),     // curveadm format
+		NewImagesCommand(curveadm),     // curveadm images
+		NewLogsCommand(curveadm),       // curveadm logs
 		NewMigrateCommand(curveadm),    // curveadm migrate
 		NewPrecheckCommand(curveadm),   // curveadm precheck
 		NewReloadCommand(curveadm),     // curveadm reload
@@ -88,6 +100,7 @@ func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 		NewStartCommand(curveadm),      // curveadm start
 		NewStatusCommand(curveadm),     // curveadm status
 		NewStopCommand(curveadm),       // curveadm stop
+		NewTraceCommand(curveadm),      // curveadm trace
 		NewSupportCommand(curveadm),    // curveadm support
 		NewUpgradeCommand(curveadm),    // curveadm upgrade
 		// commonly used shorthands
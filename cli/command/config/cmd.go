@@ -42,6 +42,8 @@ func NewConfigCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewShowCommand(curveadm),
 		NewDiffCommand(curveadm),
 		NewCommitCommand(curveadm),
+		NewHistoryCommand(curveadm),
+		NewRollbackCommand(curveadm),
 	)
 	return cmd
 }
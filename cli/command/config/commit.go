@@ -32,6 +32,7 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -39,19 +40,25 @@ import (
 
 const (
 	COMMIT_EXAMPLE = `Examples:
-  $ curveadm config commit /path/to/topology.yaml  # Commit cluster topology`
+  $ curveadm config commit /path/to/topology.yaml            # Commit cluster topology
+  $ curveadm config commit /path/to/topology.yaml --no-pin   # Commit cluster topology without pinning image digests`
 )
 
 var (
 	CHECK_TOPOLOGY_PLAYBOOK_STEPS = []int{
 		playbook.CHECK_TOPOLOGY,
 	}
+
+	RESOLVE_IMAGE_DIGEST_PLAYBOOK_STEPS = []int{
+		playbook.RESOLVE_IMAGE_DIGEST,
+	}
 )
 
 type commitOptions struct {
 	filename string
 	slient   bool
 	force    bool
+	noPin    bool
 }
 
 func NewCommitCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -72,6 +79,7 @@ func NewCommitCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.slient, "slient", "s", false, "Slient output for config commit")
 	flags.BoolVarP(&options.force, "force", "f", false, "Commit cluster topology by force")
+	flags.BoolVar(&options.noPin, "no-pin", false, "Do not resolve and pin the digest of images")
 
 	return cmd
 }
@@ -127,6 +135,94 @@ func genCheckTopologyPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// resolve digest only once for each image
+func uniqueImageDeployConfigs(dcs []*topology.DeployConfig) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	seen := map[string]bool{}
+	for _, dc := range dcs {
+		image := dc.GetContainerImage()
+		if !seen[image] {
+			seen[image] = true
+			out = append(out, dc)
+		}
+	}
+	return out
+}
+
+func genResolveDigestPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig) (*playbook.Playbook, error) {
+	steps := RESOLVE_IMAGE_DIGEST_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: uniqueImageDeployConfigs(dcs),
+		})
+	}
+	return pb, nil
+}
+
+/*
+ * resolve the mutable image tag (e.g. opencurvedocker/curvebs:v1.2) to digest
+ * and pin it for each service, the later pulls will pull image by pinned digest.
+ */
+func resolveImageDigests(curveadm *cli.CurveAdm, data string,
+	options commitOptions) ([]storage.ImageDigest, error) {
+	digests := []storage.ImageDigest{}
+	if options.noPin {
+		return digests, nil
+	}
+
+	dcs, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return nil, err
+	} else if len(dcs) == 0 {
+		return digests, nil
+	}
+
+	pb, err := genResolveDigestPlaybook(curveadm, dcs)
+	if err != nil {
+		return nil, err
+	}
+	err = pb.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := map[string]string{}
+	if v := curveadm.MemStorage().Get(comm.KEY_RESOLVED_IMAGE_DIGESTS); v != nil {
+		resolved = v.(map[string]string)
+	}
+	for _, dc := range dcs {
+		image := dc.GetContainerImage()
+		if len(resolved[image]) == 0 {
+			continue
+		}
+		digests = append(digests, storage.ImageDigest{
+			ServiceId: curveadm.GetServiceId(dc.GetId()),
+			Image:     image,
+			Digest:    resolved[image],
+		})
+	}
+	return digests, nil
+}
+
+// update cluster topology and pinned image digests in one transaction,
+// so the pinned digests always match the topology
+func updateTopology(curveadm *cli.CurveAdm, data string, digests []storage.ImageDigest) error {
+	return curveadm.Storage().Transaction(func(tx storage.Storage) error {
+		err := tx.SetClusterTopology(curveadm.ClusterId(), data)
+		if err != nil {
+			return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+		}
+		err = tx.SetImageDigests(curveadm.ClusterId(), digests)
+		if err != nil {
+			return errno.ERR_SET_IMAGE_DIGESTS_FAILED.E(err)
+		}
+		return nil
+	})
+}
+
 func readTopology(curveadm *cli.CurveAdm, options commitOptions) (string, error) {
 	filename := options.filename
 	if len(filename) == 0 {
@@ -206,13 +302,19 @@ func runCommit(curveadm *cli.CurveAdm, options commitOptions) error {
 		return errno.ERR_CANCEL_OPERATION
 	}
 
-	// 5) update cluster topology in database
-	err = curveadm.Storage().SetClusterTopology(curveadm.ClusterId(), data)
+	// 5) resolve image digests
+	digests, err := resolveImageDigests(curveadm, data, options)
 	if err != nil {
-		return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+		return err
+	}
+
+	// 6) update cluster topology and pinned image digests in database
+	err = updateTopology(curveadm, data, digests)
+	if err != nil {
+		return err
 	}
 
-	// 6) print success prompt
+	// 7) print success prompt
 	curveadm.WriteOutln("Cluster '%s' topology updated", curveadm.ClusterName())
 	return err
 }
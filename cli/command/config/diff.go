@@ -26,6 +26,7 @@ package config
 
 import (
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -33,43 +34,105 @@ import (
 
 const (
 	DIFF_EXAMPLE = `Examples:
-  $ curveadm config diff /path/to/topology.yaml  # Display difference for topology`
+  $ curveadm config diff /path/to/topology.yaml         # Display difference for topology
+  $ curveadm config diff --rev 1                        # Display difference between revision 1 and current topology
+  $ curveadm config diff --rev 1 --rev 2                # Display difference between revision 1 and revision 2
+  $ curveadm config diff --rev 1 /path/to/topology.yaml # Display difference between revision 1 and topology
+  $ curveadm config diff --kind hosts --rev 1 --rev 2   # Display difference between revision 1 and revision 2 of hosts`
 )
 
 type diffOptions struct {
-	filename string
+	filename  string
+	kind      string
+	revisions []int
 }
 
 func NewDiffCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options diffOptions
 
 	cmd := &cobra.Command{
-		Use:     "diff TOPOLOGY",
+		Use:     "diff [TOPOLOGY] [OPTIONS]",
 		Short:   "Display difference for topology",
-		Args:    utils.ExactArgs(1),
+		Args:    utils.RequiresMaxArgs(1),
 		Example: DIFF_EXAMPLE,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.filename = args[0]
+			if len(args) > 0 {
+				options.filename = args[0]
+			}
 			return runDiff(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&options.kind, "kind", comm.HISTORY_KIND_TOPOLOGY, "Specify history kind (topology/pool/hosts/disks/monitor)")
+	flags.IntSliceVar(&options.revisions, "rev", []int{}, "Specify the history revision")
+
 	return cmd
 }
 
+func readTopologyFile(filename string) (string, error) {
+	if !utils.PathExist(filename) {
+		return "", errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
+			F("%s: no such file", utils.AbsPath(filename))
+	}
+	data, err := utils.ReadFile(filename)
+	if err != nil {
+		return "", errno.ERR_READ_TOPOLOGY_FILE_FAILED.E(err)
+	}
+	return data, nil
+}
+
+func currentData(curveadm *cli.CurveAdm, kind string) string {
+	switch kind {
+	case comm.HISTORY_KIND_POOL:
+		return curveadm.ClusterPoolData()
+	case comm.HISTORY_KIND_HOSTS:
+		return curveadm.Hosts()
+	case comm.HISTORY_KIND_DISKS:
+		return curveadm.Disks()
+	case comm.HISTORY_KIND_MONITOR:
+		return curveadm.Monitor().Monitor
+	}
+	return curveadm.ClusterTopologyData()
+}
+
+/*
+ * data1 -> data2:
+ *   TOPOLOGY:              current -> file
+ *   --rev A:               revision A -> current
+ *   --rev A TOPOLOGY:      revision A -> file
+ *   --rev A --rev B:       revision A -> revision B
+ */
 func runDiff(curveadm *cli.CurveAdm, options diffOptions) error {
-	// 1) data1: current cluster topology data
-	data1 := curveadm.ClusterTopologyData()
+	var data1, data2 string
+	var err error
+	revisions := options.revisions
+	if len(revisions) > 2 || (len(revisions) == 2 && len(options.filename) > 0) {
+		return errno.ERR_REQUIRES_AT_MOST_TWO_REVISIONS
+	} else if !SUPPORT_HISTORY_KINDS[options.kind] {
+		return errno.ERR_UNSUPPORT_HISTORY_KIND.F("kind: %s", options.kind)
+	} else if len(revisions) == 0 && len(options.filename) == 0 {
+		return errno.ERR_REQUIRES_TOPOLOGY_OR_REVISION
+	}
+
+	// 1) data1: current data or the first revision
+	if len(revisions) == 0 {
+		data1 = currentData(curveadm, options.kind)
+	} else if data1, err = getRevisionData(curveadm, options.kind, revisions[0]); err != nil {
+		return err
+	}
 
-	// 2) data2: topology in file
-	if !utils.PathExist(options.filename) {
-		return errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
-			F("%s: no such file", utils.AbsPath(options.filename))
+	// 2) data2: data in file, the second revision or current data
+	if len(options.filename) > 0 {
+		data2, err = readTopologyFile(options.filename)
+	} else if len(revisions) == 2 {
+		data2, err = getRevisionData(curveadm, options.kind, revisions[1])
+	} else {
+		data2 = currentData(curveadm, options.kind)
 	}
-	data2, err := utils.ReadFile(options.filename)
 	if err != nil {
-		return errno.ERR_READ_TOPOLOGY_FILE_FAILED.E(err)
+		return err
 	}
 
 	// 3) print difference
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package config
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/tui"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	HISTORY_EXAMPLE = `Examples:
+  $ curveadm config history               # Display history revisions of cluster topology
+  $ curveadm config history --kind hosts  # Display history revisions of hosts`
+)
+
+var (
+	SUPPORT_HISTORY_KINDS = map[string]bool{
+		comm.HISTORY_KIND_TOPOLOGY: true,
+		comm.HISTORY_KIND_POOL:     true,
+		comm.HISTORY_KIND_HOSTS:    true,
+		comm.HISTORY_KIND_DISKS:    true,
+		comm.HISTORY_KIND_MONITOR:  true,
+	}
+)
+
+type historyOptions struct {
+	kind string
+}
+
+func NewHistoryCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options historyOptions
+
+	cmd := &cobra.Command{
+		Use:     "history [OPTIONS]",
+		Short:   "Display history revisions of cluster topology",
+		Args:    utils.NoArgs,
+		Example: HISTORY_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.kind, "kind", comm.HISTORY_KIND_TOPOLOGY, "Specify history kind (topology/pool/hosts/disks/monitor)")
+
+	return cmd
+}
+
+// hosts and disks are shared by all clusters
+func historyClusterId(curveadm *cli.CurveAdm, kind string) (int, error) {
+	if !SUPPORT_HISTORY_KINDS[kind] {
+		return -1, errno.ERR_UNSUPPORT_HISTORY_KIND.F("kind: %s", kind)
+	} else if kind == comm.HISTORY_KIND_HOSTS || kind == comm.HISTORY_KIND_DISKS {
+		return comm.HISTORY_GLOBAL_CLUSTER_ID, nil
+	} else if curveadm.ClusterId() == -1 {
+		return -1, errno.ERR_NO_CLUSTER_SPECIFIED
+	}
+	return curveadm.ClusterId(), nil
+}
+
+func getRevisionData(curveadm *cli.CurveAdm, kind string, revision int) (string, error) {
+	clusterId, err := historyClusterId(curveadm, kind)
+	if err != nil {
+		return "", err
+	}
+
+	histories, err := curveadm.Storage().GetHistory(clusterId, kind, revision)
+	if err != nil {
+		return "", errno.ERR_GET_HISTORY_FAILED.E(err)
+	} else if len(histories) == 0 {
+		return "", errno.ERR_HISTORY_REVISION_NOT_FOUND.
+			F("%s revision: %d", kind, revision)
+	}
+	return histories[0].Data, nil
+}
+
+func runHistory(curveadm *cli.CurveAdm, options historyOptions) error {
+	clusterId, err := historyClusterId(curveadm, options.kind)
+	if err != nil {
+		return err
+	}
+
+	histories, err := curveadm.Storage().GetHistories(clusterId, options.kind)
+	if err != nil {
+		return errno.ERR_GET_HISTORY_FAILED.E(err)
+	}
+
+	output := tui.FormatHistories(histories)
+	curveadm.WriteOut(output)
+	return nil
+}
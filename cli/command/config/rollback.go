@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package config
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ROLLBACK_EXAMPLE = `Examples:
+  $ curveadm config rollback --rev 3            # Rollback cluster topology to revision 3
+  $ curveadm config rollback --rev 3 --no-pin   # Rollback cluster topology without pinning image digests`
+)
+
+type rollbackOptions struct {
+	revision int
+	slient   bool
+	force    bool
+	noPin    bool
+}
+
+func NewRollbackCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options rollbackOptions
+
+	cmd := &cobra.Command{
+		Use:     "rollback [OPTIONS]",
+		Short:   "Rollback cluster topology to specified revision",
+		Args:    utils.NoArgs,
+		Example: ROLLBACK_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.revision, "rev", 0, "Specify the revision of cluster topology")
+	flags.BoolVarP(&options.slient, "slient", "s", false, "Slient output for config rollback")
+	flags.BoolVarP(&options.force, "force", "f", false, "Rollback cluster topology by force")
+	flags.BoolVar(&options.noPin, "no-pin", false, "Do not resolve and pin the digest of images")
+	cmd.MarkFlagRequired("rev")
+
+	return cmd
+}
+
+func runRollback(curveadm *cli.CurveAdm, options rollbackOptions) error {
+	// 1) parse cluster topology
+	_, err := curveadm.ParseTopology()
+	if err != nil && !skipError(err) {
+		return err
+	}
+
+	// 2) read topology from history
+	data, err := getRevisionData(curveadm, comm.HISTORY_KIND_TOPOLOGY, options.revision)
+	if err != nil {
+		return err
+	} else if !options.slient {
+		diff := utils.Diff(curveadm.ClusterTopologyData(), data)
+		curveadm.WriteOutln("%s", diff)
+	}
+
+	// 3) check topology, it's same as commit
+	err = checkTopology(curveadm, data, commitOptions{force: options.force})
+	if err != nil {
+		return err
+	}
+
+	// 4) confirm by user
+	if pass := tui.ConfirmYes("Do you want to continue?"); !pass {
+		curveadm.WriteOutln(tui.PromptCancelOpetation("rollback topology"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 5) re-pin image digests for the images of rolled back topology
+	digests, err := resolveImageDigests(curveadm, data, commitOptions{noPin: options.noPin})
+	if err != nil {
+		return err
+	}
+
+	// 6) update cluster topology and pinned image digests in database,
+	//    which generates a new revision
+	err = updateTopology(curveadm, data, digests)
+	if err != nil {
+		return err
+	}
+
+	// 7) print success prompt
+	curveadm.WriteOutln("Cluster '%s' topology rolled back to revision %d",
+		curveadm.ClusterName(), options.revision)
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package cores
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ANALYZE_EXAMPLE = `Examples:
+  $ curveadm cores analyze 3f6a2d1c9e4b   # Print backtrace of all threads for specified core dump`
+)
+
+type analyzeOptions struct {
+	id string
+}
+
+func NewAnalyzeCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options analyzeOptions
+
+	cmd := &cobra.Command{
+		Use:     "analyze ID",
+		Short:   "Print backtrace of core dump by gdb in service image",
+		Args:    cliutil.ExactArgs(1),
+		Example: ANALYZE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.id = args[0]
+			return runAnalyze(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func getCoreConfig(core task.CoreFile) (*topology.DeployConfig, error) {
+	if core.Config == nil {
+		return nil, errno.ERR_UNKNOWN_SERVICE_OF_CORE_FILE.
+			F("host=%s core=%s", core.Host, core.Path)
+	}
+	return core.Config, nil
+}
+
+func runAnalyze(curveadm *cli.CurveAdm, options analyzeOptions) error {
+	// 1) find core file by id
+	core, err := findCore(curveadm, options.id)
+	if err != nil {
+		return err
+	}
+	dc, err := getCoreConfig(core)
+	if err != nil {
+		return err
+	}
+
+	// 2) generate analyze core playbook
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.ANALYZE_CORE,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_CORES_OPTIONS: task.CoresOptions{Core: core},
+		},
+	})
+
+	// 3) run playbook
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) print backtrace
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", curveadm.MemStorage().Get(comm.KEY_CORE_BACKTRACE).(string))
+	curveadm.WriteOutln("")
+	return nil
+}
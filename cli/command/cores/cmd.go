@@ -0,0 +1,45 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package cores
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewCoresCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cores",
+		Short: "Manage core dumps of services",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewListCommand(curveadm),
+		NewAnalyzeCommand(curveadm),
+		NewFetchCommand(curveadm),
+	)
+	return cmd
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package cores
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FETCH_EXAMPLE = `Examples:
+  $ curveadm cores fetch 3f6a2d1c9e4b              # Fetch core dump with its binary and debug symbols to current directory
+  $ curveadm cores fetch 3f6a2d1c9e4b -o /tmp/core # Fetch core dump to /tmp/core`
+)
+
+type fetchOptions struct {
+	id     string
+	output string
+}
+
+func NewFetchCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options fetchOptions
+
+	cmd := &cobra.Command{
+		Use:     "fetch ID [OPTIONS]",
+		Short:   "Download core dump with its binary, shared libraries and debug symbols",
+		Args:    cliutil.ExactArgs(1),
+		Example: FETCH_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.id = args[0]
+			return runFetch(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", ".", "Specify directory to save core dump")
+
+	return cmd
+}
+
+func runFetch(curveadm *cli.CurveAdm, options fetchOptions) error {
+	// 1) prepare output directory
+	output, err := filepath.Abs(options.output)
+	if err == nil {
+		err = os.MkdirAll(output, 0755)
+	}
+	if err != nil {
+		return errno.ERR_CREATE_DIRECTORY_FAILED.E(err)
+	}
+
+	// 2) find core file by id
+	core, err := findCore(curveadm, options.id)
+	if err != nil {
+		return err
+	}
+	dc, err := getCoreConfig(core)
+	if err != nil {
+		return err
+	}
+
+	// 3) generate fetch core playbook
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.FETCH_CORE,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_CORES_OPTIONS: task.CoresOptions{Core: core, Output: output},
+		},
+	})
+
+	// 4) run playbook
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 5) print where core saved
+	binary, _ := task.CoreBinary(core)
+	dir := path.Join(output, fmt.Sprintf("core-%s", core.Id))
+	curveadm.WriteOutln(color.GreenString("Core dump saved to %s", dir))
+	curveadm.WriteOutln("Run 'gdb -ex \"set sysroot %s\" %s %s' to debug it",
+		path.Join(dir, "sysroot"), path.Join(dir, path.Base(binary)), path.Join(dir, path.Base(core.Path)))
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package cores
+
+import (
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LIST_EXAMPLE = `Examples:
+  $ curveadm cores ls                      # List core dumps of all services
+  $ curveadm cores ls --since 24h          # List core dumps generated in last 24 hours
+  $ curveadm cores ls --host host1         # List core dumps on host1
+  $ curveadm cores ls --role chunkserver   # List core dumps of chunkserver services`
+)
+
+type listOptions struct {
+	since string
+	host  string
+	role  string
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List core dumps in core directory of all hosts",
+		Args:    cliutil.NoArgs,
+		Example: LIST_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.since, "since", "", "Only list core dumps since relative time (e.g. 30m, 24h)")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+
+	return cmd
+}
+
+func parseSince(since string) (time.Duration, error) {
+	if len(since) == 0 {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(since)
+	if err != nil || duration <= 0 {
+		return 0, errno.ERR_INVALID_LOGS_SINCE.
+			F("since: %s", since)
+	}
+	return duration, nil
+}
+
+func genListPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options task.CoresOptions,
+	silent bool) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.LIST_CORES,
+		Configs: task.CoreCollectors(dcs),
+		Options: map[string]interface{}{
+			comm.KEY_CORES_OPTIONS:      options,
+			comm.KEY_ALL_DEPLOY_CONFIGS: dcs,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: silent,
+			SkipError:     true,
+		},
+	})
+	return pb
+}
+
+// filter core files by service role, the host is filtered by core collectors
+func getCores(curveadm *cli.CurveAdm, role string) []task.CoreFile {
+	cores := []task.CoreFile{}
+	value := curveadm.MemStorage().Get(comm.KEY_ALL_CORE_FILES)
+	if value == nil {
+		return cores
+	}
+	for _, core := range value.(map[string]task.CoreFile) {
+		if role == "*" || core.Role == role {
+			cores = append(cores, core)
+		}
+	}
+	return cores
+}
+
+// findCore locates the core file by id, which is listed by `curveadm cores ls`
+func findCore(curveadm *cli.CurveAdm, id string) (task.CoreFile, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return task.CoreFile{}, err
+	}
+
+	pb := genListPlaybook(curveadm, dcs, task.CoresOptions{}, true)
+	err = pb.Run()
+	for _, core := range getCores(curveadm, "*") {
+		if core.Id == id {
+			return core, nil
+		}
+	}
+	if err != nil {
+		return task.CoreFile{}, err
+	}
+	return task.CoreFile{}, errno.ERR_CORE_FILE_NOT_FOUND.
+		F("id: %s", id)
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   "*",
+		Role: "*",
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 2) generate list cores playbook
+	since, err := parseSince(options.since)
+	if err != nil {
+		return err
+	}
+	pb := genListPlaybook(curveadm, dcs, task.CoresOptions{Since: since}, false)
+
+	// 3) run playbook
+	err = pb.Run()
+
+	// 4) display core files
+	output := tui.FormatCores(getCores(curveadm, options.role))
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", output)
+	return err
+}
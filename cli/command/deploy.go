@@ -24,14 +24,17 @@ package command
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/bundle"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -40,6 +43,7 @@ import (
 const (
 	CLEAN_PRECHECK_ENVIRONMENT = playbook.CLEAN_PRECHECK_ENVIRONMENT
 	PULL_IMAGE                 = playbook.PULL_IMAGE
+	VERIFY_IMAGE_DIGEST        = playbook.VERIFY_IMAGE_DIGEST
 	CREATE_CONTAINER           = playbook.CREATE_CONTAINER
 	SYNC_CONFIG                = playbook.SYNC_CONFIG
 	START_ETCD                 = playbook.START_ETCD
@@ -63,6 +67,7 @@ var (
 	CURVEBS_DEPLOY_STEPS = []int{
 		CLEAN_PRECHECK_ENVIRONMENT,
 		PULL_IMAGE,
+		VERIFY_IMAGE_DIGEST,
 		CREATE_CONTAINER,
 		SYNC_CONFIG,
 		START_ETCD,
@@ -78,6 +83,7 @@ var (
 	CURVEFS_DEPLOY_STEPS = []int{
 		CLEAN_PRECHECK_ENVIRONMENT,
 		PULL_IMAGE,
+		VERIFY_IMAGE_DIGEST,
 		CREATE_CONTAINER,
 		SYNC_CONFIG,
 		START_ETCD,
@@ -104,6 +110,7 @@ var (
 		CREATE_LOGICAL_POOL:  1,
 		BALANCE_LEADER:       1,
 		ENABLE_ETCD_AUTH:     1,
+		VERIFY_IMAGE_DIGEST:  1,
 	}
 
 	CAN_SKIP_ROLES = []string{
@@ -117,6 +124,8 @@ type deployOptions struct {
 	poolset         string
 	poolsetDiskType string
 	debug           bool
+	bundle          string
+	retries         int
 }
 
 func checkDeployOptions(options deployOptions) error {
@@ -152,6 +161,8 @@ func NewDeployCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.poolset, "poolset", "default", "poolset name")
 	flags.StringVar(&options.poolsetDiskType, "poolset-disktype", "ssd", "Specify the disk type of physical pool")
 	flags.BoolVar(&options.debug, "debug", false, "Debug deploy progress")
+	flags.StringVar(&options.bundle, "bundle", "", "Distribute images from offline bundle instead of pulling them")
+	flags.IntVar(&options.retries, "retries", -1, "Specify retry times of idempotent steps (e.g. pull image), -1 means the default of each step")
 	return cmd
 }
 
@@ -180,6 +191,15 @@ func skipDeploySteps(dcs []*topology.DeployConfig, deploySteps []int, options de
 	return steps
 }
 
+// deployHosts returns hosts of services, images are loaded into them from bundle
+func deployHosts(dcs []*topology.DeployConfig) []string {
+	hosts := []string{}
+	for _, dc := range dcs {
+		hosts = append(hosts, dc.GetHost())
+	}
+	return hosts
+}
+
 func precheckBeforeDeploy(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
 	options deployOptions) error {
@@ -211,9 +231,18 @@ func precheckBeforeDeploy(curveadm *cli.CurveAdm,
 	return nil
 }
 
+// override the retries declared by steps, e.g. --retries 0 disables the retry
+func deployPolicy(options deployOptions) playbook.PolicyOverride {
+	if options.retries < 0 {
+		return playbook.PolicyOverride{}
+	}
+	return playbook.PolicyOverride{Retries: &options.retries}
+}
+
 func genDeployPlaybook(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
-	options deployOptions) (*playbook.Playbook, error) {
+	options deployOptions,
+	bundleOptions *task.BundleOptions) (*playbook.Playbook, error) {
 	kind := dcs[0].GetKind()
 	steps := CURVEFS_DEPLOY_STEPS
 	if kind == topology.KIND_CURVEBS {
@@ -222,9 +251,18 @@ func genDeployPlaybook(curveadm *cli.CurveAdm,
 	steps = skipDeploySteps(dcs, steps, options)
 	poolset := options.poolset
 	diskType := options.poolsetDiskType
+	policy := deployPolicy(options)
 
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
+		if step == PULL_IMAGE && bundleOptions != nil {
+			err := bundle.AddLoadSteps(curveadm, pb, deployHosts(dcs), *bundleOptions)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		// configs
 		config := dcs
 		if len(DEPLOY_FILTER_ROLE[step]) > 0 {
@@ -251,6 +289,9 @@ func genDeployPlaybook(curveadm *cli.CurveAdm,
 			Type:    step,
 			Configs: config,
 			Options: options,
+			ExecOptions: playbook.ExecOptions{
+				Policy: policy,
+			},
 		})
 	}
 	return pb, nil
@@ -330,7 +371,16 @@ func runDeploy(curveadm *cli.CurveAdm, options deployOptions) error {
 	}
 
 	// 5) generate deploy playbook
-	pb, err := genDeployPlaybook(curveadm, dcs, options)
+	var bundleOptions *task.BundleOptions
+	if len(options.bundle) > 0 {
+		bo, err := bundle.NewLoadOptions(curveadm, options.bundle, task.TopologyImages(dcs))
+		if err != nil {
+			return err
+		}
+		bundleOptions = &bo
+		defer os.RemoveAll(bo.Dir)
+	}
+	pb, err := genDeployPlaybook(curveadm, dcs, options, bundleOptions)
 	if err != nil {
 		return err
 	}
@@ -40,6 +40,8 @@ func NewDisksCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewCommitCommand(curveadm),
 		NewShowCommand(curveadm),
 		NewListCommand(curveadm),
+		NewDiscoverCommand(curveadm),
+		NewReplaceCommand(curveadm),
 	)
 	return cmd
 }
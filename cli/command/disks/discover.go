@@ -0,0 +1,268 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package disks
+
+import (
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	hostscmd "github.com/opencurve/curveadm/cli/command/hosts"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/disks"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/tui"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DISCOVER_EXAMPLE = `Examples:
+  $ curveadm disks discover                                       # Discover disks on all hosts and commit them
+  $ curveadm disks discover --host host1,host2                    # Discover disks on specified hosts
+  $ curveadm disks discover --labels chunkserver                   # Discover disks on hosts which have label 'chunkserver'
+  $ curveadm disks discover --type ssd --min-size 1TiB            # Discover SSD disks which are larger than 1TiB
+  $ curveadm disks discover --model 'SAMSUNG.*' --serial '^S64'   # Discover disks which model and serial match the rules
+  $ curveadm disks discover -o disks.yaml                         # Only generate disks to file, commit it by yourself`
+)
+
+var (
+	DISCOVER_DISKS_PLAYBOOK_STEPS = []int{
+		playbook.DISCOVER_DISKS,
+	}
+)
+
+type discoverOptions struct {
+	hosts              []string
+	labels             []string
+	minSize            string
+	maxSize            string
+	diskType           string
+	model              string
+	serial             string
+	mountPrefix        string
+	formatPercent      int
+	containerImage     string
+	serviceMountDevice bool
+	outfile            string
+	slient             bool
+}
+
+func NewDiscoverCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options discoverOptions
+	cmd := &cobra.Command{
+		Use:     "discover [OPTIONS]",
+		Short:   "Discover disks on hosts and generate disks",
+		Args:    utils.NoArgs,
+		Example: DISCOVER_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiscover(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&options.hosts, "host", []string{}, "Specify the hosts")
+	flags.StringSliceVarP(&options.labels, "labels", "l", []string{}, "Specify the host labels")
+	flags.StringVar(&options.minSize, "min-size", "", "Only discover disks which size is larger than or equal to it")
+	flags.StringVar(&options.maxSize, "max-size", "", "Only discover disks which size is less than or equal to it")
+	flags.StringVar(&options.diskType, "type", "", "Only discover disks with specified type (ssd/hdd)")
+	flags.StringVar(&options.model, "model", "", "Only discover disks which model match the regular expression")
+	flags.StringVar(&options.serial, "serial", "", "Only discover disks which serial match the regular expression")
+	flags.StringVar(&options.mountPrefix, "mount-prefix", "/data/chunkserver", "Specify the mount point prefix for new disks")
+	flags.IntVar(&options.formatPercent, "format-percent", disks.DEFAULT_FORMAT_PERCENT, "Specify the format percent for new disks")
+	flags.StringVar(&options.containerImage, "container-image", "", "Specify the format container image for new disks")
+	flags.BoolVar(&options.serviceMountDevice, "service-mount-device", false, "Mount device by chunkserver service instead of /etc/fstab")
+	flags.StringVarP(&options.outfile, "output", "o", "", "Output disks to specified file instead of committing it")
+	flags.BoolVarP(&options.slient, "slient", "s", false, "Slient output for disks discover")
+
+	return cmd
+}
+
+func filterHosts(curveadm *cli.CurveAdm, options discoverOptions) ([]*hosts.HostConfig, error) {
+	data := curveadm.Hosts()
+	if len(data) == 0 {
+		return nil, errno.ERR_EMPTY_HOSTS
+	}
+	hcs, err := hostscmd.Filter(data, options.labels)
+	if err != nil {
+		return nil, err
+	} else if len(options.hosts) == 0 {
+		return hcs, nil
+	}
+
+	out := []*hosts.HostConfig{}
+	m := utils.Slice2Map(options.hosts)
+	for _, hc := range hcs {
+		if m[hc.GetHost()] {
+			out = append(out, hc)
+			delete(m, hc.GetHost())
+		}
+	}
+	for host := range m {
+		return nil, errno.ERR_HOST_NOT_FOUND.F("host: %s", host)
+	}
+	return out, nil
+}
+
+func genDiscoverPlaybook(curveadm *cli.CurveAdm,
+	hcs []*hosts.HostConfig) (*playbook.Playbook, error) {
+	steps := DISCOVER_DISKS_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: hcs,
+			ExecOptions: playbook.ExecOptions{
+				Concurrency:  100,
+				SilentSubBar: true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+// mark the committed disks and the disks which not match the rule
+func collectBlockDevices(curveadm *cli.CurveAdm, hcs []*hosts.HostConfig,
+	rule *disks.DiscoverRule) []*disks.BlockDevice {
+	m := map[string][]*disks.BlockDevice{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_BLOCK_DEVICES)
+	if v != nil {
+		m = v.(map[string][]*disks.BlockDevice)
+	}
+
+	committed := map[string]bool{}
+	for _, dr := range curveadm.DiskRecords() {
+		committed[dr.Host+HOST_DEVICE_SEP+dr.Device] = true
+	}
+
+	devices := []*disks.BlockDevice{}
+	for _, hc := range hcs {
+		for _, bd := range m[hc.GetHost()] {
+			if bd.Status != disks.DISCOVER_STATUS_AVAILABLE {
+				// skip
+			} else if committed[bd.Host+HOST_DEVICE_SEP+bd.Device] {
+				bd.Status = disks.DISCOVER_STATUS_COMMITTED
+			} else if ok, reason := rule.Match(bd); !ok {
+				bd.Status, bd.Reason = disks.DISCOVER_STATUS_FILTERED, reason
+			}
+			devices = append(devices, bd)
+		}
+	}
+	return devices
+}
+
+// record the size and stable URI of new disks
+func updateDiskRecords(curveadm *cli.CurveAdm, devices []*disks.BlockDevice) error {
+	for _, bd := range devices {
+		if bd.Status != disks.DISCOVER_STATUS_AVAILABLE {
+			continue
+		}
+
+		size := strconv.FormatUint(bd.Size, 10)
+		err := curveadm.Storage().UpdateDiskSize(size, bd.Host, bd.Device)
+		if err != nil {
+			return errno.ERR_UPDATE_DISK_FAILED.E(err)
+		}
+		err = curveadm.Storage().UpdateDiskURI(bd.GetURI(), bd.Host, bd.Device)
+		if err != nil {
+			return errno.ERR_UPDATE_DISK_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func runDiscover(curveadm *cli.CurveAdm, options discoverOptions) error {
+	// 1) parse rule and filter hosts
+	rule, err := disks.NewDiscoverRule(options.minSize, options.maxSize,
+		options.diskType, options.model, options.serial)
+	if err != nil {
+		return err
+	}
+	hcs, err := filterHosts(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) generate playbook
+	pb, err := genDiscoverPlaybook(curveadm, hcs)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playbook
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) display discovered disks
+	devices := collectBlockDevices(curveadm, hcs, rule)
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatBlockDevices(devices))
+
+	// 5) generate disks
+	oldData := curveadm.Disks()
+	data, err := disks.GenerateDisks(oldData, devices, disks.GenerateOption{
+		MountPrefix:        options.mountPrefix,
+		FormatPercent:      options.formatPercent,
+		ContainerImage:     options.containerImage,
+		ServiceMountDevice: options.serviceMountDevice,
+	})
+	if err != nil {
+		return err
+	} else if data == oldData {
+		curveadm.WriteOutln(color.YellowString("No new disks discovered"))
+		return nil
+	} else if len(options.outfile) > 0 {
+		if err := utils.WriteFile(options.outfile, data, 0644); err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+		curveadm.WriteOutln(color.GreenString("Disks generated to '%s', "+
+			"you can commit it by 'curveadm disks commit %s'", options.outfile, options.outfile))
+		return nil
+	}
+
+	// 6) confirm by user
+	if !options.slient {
+		curveadm.WriteOutln(utils.Diff(oldData, data))
+		pass := tuicomm.ConfirmYes("Do you want to continue?")
+		if !pass {
+			curveadm.WriteOut(tuicomm.PromptCancelOpetation("commit disks"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 7) commit disks and record size and URI for new disks
+	if err := Commit(curveadm, data); err != nil {
+		return err
+	} else if err := updateDiskRecords(curveadm, devices); err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln(color.GreenString("Disks updated"))
+	return nil
+}
@@ -0,0 +1,423 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package disks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/disks"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	REPLACE_EXAMPLE = `Examples:
+  $ curveadm disks replace --host host1 --device /dev/sdb --new-device /dev/sdf  # Replace disk /dev/sdb with /dev/sdf on host1
+  $ curveadm disks replace --host host1 --device /dev/sdb                        # Replace disk with a new one in the same slot
+  $ curveadm disks replace --host host1 --device /dev/sdb --restart              # Discard the progress and replace from the beginning`
+
+	DEFAULT_RETIRE_TIMEOUT = 24 * time.Hour
+	DEFAULT_FORMAT_TIMEOUT = 24 * time.Hour
+)
+
+// replace steps, the progress will be saved after each of them finished
+const (
+	REPLACE_STEP_RETIRE_CHUNKSERVER int = iota
+	REPLACE_STEP_CLEAN_CHUNKSERVER
+	REPLACE_STEP_UMOUNT_DISK
+	REPLACE_STEP_UPDATE_DISK
+	REPLACE_STEP_FORMAT_DISK
+	REPLACE_STEP_START_CHUNKSERVER
+	REPLACE_STEP_VERIFY_CHUNKSERVER
+	REPLACE_STEP_DONE
+)
+
+var (
+	REPLACE_STEP_NAMES = []string{
+		"retire chunkserver and wait copysets recovered",
+		"stop and clean chunkserver container",
+		"umount failed disk",
+		"update disk records",
+		"format chunkfile pool on new disk",
+		"recreate and start chunkserver",
+		"verify chunkserver registered",
+	}
+
+	REPLACE_PLAYBOOK_STEPS = map[int][]int{
+		REPLACE_STEP_RETIRE_CHUNKSERVER: {playbook.RETIRE_CHUNKSERVER},
+		REPLACE_STEP_CLEAN_CHUNKSERVER:  {playbook.STOP_SERVICE, playbook.CLEAN_SERVICE},
+		REPLACE_STEP_UMOUNT_DISK:        {playbook.UMOUNT_DISK},
+		REPLACE_STEP_FORMAT_DISK:        {playbook.FORMAT_CHUNKFILE_POOL, playbook.WAIT_FORMAT_DONE},
+		REPLACE_STEP_START_CHUNKSERVER: {
+			playbook.PULL_IMAGE,
+			playbook.CREATE_CONTAINER,
+			playbook.SYNC_CONFIG,
+			playbook.START_CHUNKSERVER,
+		},
+		REPLACE_STEP_VERIFY_CHUNKSERVER: {playbook.WAIT_CHUNKSERVER_REGISTERED},
+	}
+)
+
+type (
+	replaceOptions struct {
+		host          string
+		device        string
+		newDevice     string
+		retireTimeout time.Duration
+		formatTimeout time.Duration
+		restart       bool
+	}
+
+	// replaceProgress is saved into data directory for resuming replacement
+	replaceProgress struct {
+		Cluster            string `json:"cluster"`
+		Host               string `json:"host"`
+		Device             string `json:"device"`
+		NewDevice          string `json:"new_device"`
+		MountPoint         string `json:"mount_point"`
+		ContainerImage     string `json:"container_image"`
+		FormatPercent      int    `json:"format_percent"`
+		ServiceMountDevice int    `json:"service_mount_device"`
+		Step               int    `json:"step"`
+	}
+)
+
+func NewReplaceCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options replaceOptions
+	cmd := &cobra.Command{
+		Use:     "replace [OPTIONS]",
+		Short:   "Replace failed disk of chunkserver",
+		Args:    utils.NoArgs,
+		Example: REPLACE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReplace(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Specify the host of failed disk")
+	flags.StringVar(&options.device, "device", "", "Specify the device of failed disk")
+	flags.StringVar(&options.newDevice, "new-device", "", "Specify the device of new disk (default same as --device)")
+	flags.DurationVar(&options.retireTimeout, "retire-timeout", DEFAULT_RETIRE_TIMEOUT, "Specify the timeout for waiting copysets recovered")
+	flags.DurationVar(&options.formatTimeout, "format-timeout", DEFAULT_FORMAT_TIMEOUT, "Specify the timeout for waiting chunkfile pool formatted")
+	flags.BoolVar(&options.restart, "restart", false, "Discard the saved progress and replace from the beginning")
+	cmd.MarkFlagRequired("host")
+	cmd.MarkFlagRequired("device")
+
+	return cmd
+}
+
+func progressPath(curveadm *cli.CurveAdm, host, device string) string {
+	name := fmt.Sprintf("replace-%s.json",
+		utils.MD5Sum(curveadm.ClusterName()+HOST_DEVICE_SEP+host+HOST_DEVICE_SEP+device))
+	return filepath.Join(curveadm.DataDir(), name)
+}
+
+func loadProgress(curveadm *cli.CurveAdm, options replaceOptions) (*replaceProgress, error) {
+	path := progressPath(curveadm, options.host, options.device)
+	if options.restart || !utils.PathExist(path) {
+		return nil, nil
+	}
+
+	data, err := utils.ReadFile(path)
+	if err != nil {
+		return nil, errno.ERR_READ_REPLACE_PROGRESS_FAILED.E(err)
+	}
+	progress := &replaceProgress{}
+	if err := json.Unmarshal([]byte(data), progress); err != nil {
+		return nil, errno.ERR_READ_REPLACE_PROGRESS_FAILED.E(err)
+	} else if progress.NewDevice != options.newDevice {
+		return nil, errno.ERR_READ_REPLACE_PROGRESS_FAILED.
+			F("disk %s:%s is replacing with %s, please specify the same new device or use --restart",
+				progress.Host, progress.Device, progress.NewDevice)
+	}
+	return progress, nil
+}
+
+func saveProgress(curveadm *cli.CurveAdm, progress *replaceProgress) error {
+	path := progressPath(curveadm, progress.Host, progress.Device)
+	if progress.Step == REPLACE_STEP_DONE {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errno.ERR_WRITE_REPLACE_PROGRESS_FAILED.E(err)
+		}
+		return nil
+	}
+
+	bytes, err := json.Marshal(progress)
+	if err != nil {
+		return errno.ERR_WRITE_REPLACE_PROGRESS_FAILED.E(err)
+	} else if err := utils.WriteFile(path, string(bytes), 0644); err != nil {
+		return errno.ERR_WRITE_REPLACE_PROGRESS_FAILED.E(err)
+	}
+	return nil
+}
+
+func newProgress(curveadm *cli.CurveAdm, options replaceOptions) (*replaceProgress, error) {
+	for _, dr := range curveadm.DiskRecords() {
+		if dr.Host == options.host && dr.Device == options.newDevice &&
+			options.newDevice != options.device {
+			return nil, errno.ERR_REPLACE_DISK_ALREADY_EXIST.
+				F("host=%s device=%s", dr.Host, dr.Device)
+		}
+	}
+
+	for _, dr := range curveadm.DiskRecords() {
+		if dr.Host != options.host || dr.Device != options.device {
+			continue
+		} else if dr.ChunkServerID == comm.DISK_DEFAULT_NULL_CHUNKSERVER_ID {
+			return nil, errno.ERR_DISK_NOT_BINDING_SERVICE.
+				F("host=%s device=%s", dr.Host, dr.Device)
+		}
+		return &replaceProgress{
+			Cluster:            curveadm.ClusterName(),
+			Host:               dr.Host,
+			Device:             dr.Device,
+			NewDevice:          options.newDevice,
+			MountPoint:         dr.MountPoint,
+			ContainerImage:     dr.ContainerImage,
+			FormatPercent:      dr.FormatPercent,
+			ServiceMountDevice: dr.ServiceMountDevice,
+			Step:               REPLACE_STEP_RETIRE_CHUNKSERVER,
+		}, nil
+	}
+	return nil, errno.ERR_REPLACE_DISK_NOT_FOUND.
+		F("host=%s device=%s", options.host, options.device)
+}
+
+// the chunkserver which data directory is the mount point of disk
+func getReplaceServices(curveadm *cli.CurveAdm,
+	progress *replaceProgress) (*topology.DeployConfig, *topology.DeployConfig, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var chunkserver *topology.DeployConfig
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_CHUNKSERVER) {
+		if dc.GetHost() == progress.Host && dc.GetDataDir() == progress.MountPoint {
+			chunkserver = dc
+			break
+		}
+	}
+	mds := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)
+	if chunkserver == nil || len(mds) == 0 {
+		return nil, nil, errno.ERR_DISK_NOT_BINDING_SERVICE.
+			F("host=%s device=%s mount=%s", progress.Host, progress.Device, progress.MountPoint)
+	}
+	return chunkserver, mds[0], nil
+}
+
+func newFormatConfig(progress *replaceProgress, device string) (*configure.FormatConfig, error) {
+	containerImage := configure.DEFAULT_CONTAINER_IMAGE
+	if len(progress.ContainerImage) > 0 &&
+		progress.ContainerImage != comm.DISK_DEFAULT_NULL_CONTAINER_IMAGE {
+		containerImage = progress.ContainerImage
+	}
+	disk := fmt.Sprintf("%s:%s:%d", device, progress.MountPoint, progress.FormatPercent)
+	fc, err := configure.NewFormatConfig(containerImage, progress.Host, disk)
+	if err != nil {
+		return nil, err
+	}
+	fc.FromDiskRecord = true
+	fc.ServiceMountDevice = progress.ServiceMountDevice != 0
+	return fc, nil
+}
+
+func genReplacePlaybook(curveadm *cli.CurveAdm, progress *replaceProgress,
+	options replaceOptions, step int) (*playbook.Playbook, error) {
+	chunkserver, mds, err := getReplaceServices(curveadm, progress)
+	if err != nil {
+		return nil, err
+	}
+	oldFc, err := newFormatConfig(progress, progress.Device)
+	if err != nil {
+		return nil, err
+	}
+	newFc, err := newFormatConfig(progress, progress.NewDevice)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := int(options.retireTimeout.Seconds())
+	formatTimeout := int(options.formatTimeout.Seconds())
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range REPLACE_PLAYBOOK_STEPS[step] {
+		// configs
+		var configs interface{} = []*topology.DeployConfig{chunkserver}
+		switch step {
+		case playbook.RETIRE_CHUNKSERVER,
+			playbook.WAIT_CHUNKSERVER_REGISTERED:
+			configs = []*topology.DeployConfig{mds}
+		case playbook.UMOUNT_DISK:
+			configs = []*configure.FormatConfig{oldFc}
+		case playbook.FORMAT_CHUNKFILE_POOL,
+			playbook.WAIT_FORMAT_DONE:
+			configs = []*configure.FormatConfig{newFc}
+		}
+
+		// options
+		options := map[string]interface{}{
+			comm.KEY_REPLACE_CHUNKSERVER:    chunkserver,
+			comm.KEY_REPLACE_RETIRE_TIMEOUT: timeout,
+			comm.KEY_REPLACE_FORMAT_TIMEOUT: formatTimeout,
+		}
+		switch step {
+		case playbook.CLEAN_SERVICE:
+			options[comm.KEY_CLEAN_ITEMS] = []string{comm.CLEAN_ITEM_CONTAINER}
+			options[comm.KEY_CLEAN_BY_RECYCLE] = true
+		case playbook.FORMAT_CHUNKFILE_POOL:
+			options[comm.DEBUG_MODE] = false
+			options[comm.FORMAT_INCREMENTAL] = false
+		}
+
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: configs,
+			Options: options,
+		})
+	}
+	return pb, nil
+}
+
+// replace the disk in disks and disk records, the new disk record
+// will be bound to the chunkserver when its container recreated
+func updateReplacedDisk(curveadm *cli.CurveAdm, progress *replaceProgress) error {
+	s := curveadm.Storage()
+	diskRecords, err := s.GetDisk(comm.DISK_FILTER_MOUNT, progress.Host, progress.MountPoint)
+	if err != nil {
+		return errno.ERR_GET_DISK_RECORDS_FAILED.E(err)
+	}
+	for _, dr := range diskRecords {
+		if err := s.UpdateDiskChunkServerID(dr.Host, dr.MountPoint,
+			comm.DISK_DEFAULT_NULL_CHUNKSERVER_ID); err != nil {
+			return errno.ERR_UPDATE_DISK_FAILED.E(err)
+		}
+	}
+	if progress.NewDevice == progress.Device {
+		return nil
+	}
+
+	data := curveadm.Disks()
+	if len(data) > 0 {
+		data, err = disks.ReplaceDisk(data, progress.Host, progress.Device, progress.NewDevice)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.SetDisk(progress.Host, progress.NewDevice, progress.MountPoint,
+		progress.ContainerImage, progress.FormatPercent, progress.ServiceMountDevice); err != nil {
+		return errno.ERR_UPDATE_DISK_FAILED.E(err)
+	} else if err := s.DeleteDisk(progress.Host, progress.Device); err != nil {
+		return errno.ERR_UPDATE_DISK_FAILED.E(err)
+	} else if len(data) == 0 {
+		return nil
+	} else if err := s.SetDisks(data); err != nil {
+		return errno.ERR_UPDATE_DISKS_FAILED.E(err)
+	}
+	return nil
+}
+
+func displayReplaceTitle(curveadm *cli.CurveAdm, progress *replaceProgress) {
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.YellowString("NOTICE: cluster '%s' is about to replace disk:", curveadm.ClusterName()))
+	curveadm.WriteOutln(color.YellowString("  - Replace disk: %s:%s -> %s:%s",
+		progress.Host, progress.Device, progress.Host, progress.NewDevice))
+	curveadm.WriteOutln(color.YellowString("  - Mount point: %s", progress.MountPoint))
+	for step := progress.Step; step < REPLACE_STEP_DONE; step++ {
+		curveadm.WriteOutln(color.YellowString("  - Step %d: %s", step+1, REPLACE_STEP_NAMES[step]))
+	}
+	if progress.Step > REPLACE_STEP_RETIRE_CHUNKSERVER {
+		curveadm.WriteOutln(color.YellowString("  - Resume from step %d, use --restart to replace from the beginning",
+			progress.Step+1))
+	}
+}
+
+func runReplace(curveadm *cli.CurveAdm, options replaceOptions) error {
+	if len(options.newDevice) == 0 {
+		options.newDevice = options.device
+	}
+
+	// 1) load saved progress or create a new one
+	progress, err := loadProgress(curveadm, options)
+	if err != nil {
+		return err
+	} else if progress == nil {
+		progress, err = newProgress(curveadm, options)
+		if err != nil {
+			return err
+		}
+	}
+	if _, _, err := getReplaceServices(curveadm, progress); err != nil {
+		return err
+	}
+
+	// 2) confirm by user
+	displayReplaceTitle(curveadm, progress)
+	if pass := tuicomm.ConfirmYes(tuicomm.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOutln(tuicomm.PromptCancelOpetation("replace disk"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 3) run replace steps one by one and save progress after each of them
+	for progress.Step < REPLACE_STEP_DONE {
+		step := progress.Step
+		if step == REPLACE_STEP_UPDATE_DISK {
+			err = updateReplacedDisk(curveadm, progress)
+		} else {
+			var pb *playbook.Playbook
+			pb, err = genReplacePlaybook(curveadm, progress, options, step)
+			if err == nil {
+				err = pb.Run()
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		progress.Step++
+		if err := saveProgress(curveadm, progress); err != nil {
+			return err
+		}
+		curveadm.WriteOutln("")
+	}
+
+	// 4) print success prompt
+	id := curveadm.MemStorage().Get(comm.KEY_REPLACE_NEW_CHUNKSERVER_ID)
+	curveadm.WriteOutln(color.GreenString("Disk %s:%s successfully replaced with %s, chunkserver registered with id %v ^_^.",
+		progress.Host, progress.Device, progress.NewDevice, id))
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package export
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ADD_PLAYBOOK_STEPS = []int{
+		playbook.ADD_EXPORT,
+	}
+
+	SUPPORT_EXPORT_PROTOCOLS = map[string]bool{
+		bs.EXPORT_PROTOCOL_NVMEOF: true,
+		bs.EXPORT_PROTOCOL_VHOST:  true,
+	}
+
+	HOST_NQN_REGEX = regexp.MustCompile(`^nqn\.[0-9]{4}-[0-9]{2}\.[^\s:,]+:[^\s,/]+$`)
+)
+
+type addOptions struct {
+	image         string
+	host          string
+	protocol      string
+	create        bool
+	size          string
+	filename      string
+	listenAddress string
+	port          int
+	allowHosts    []string
+}
+
+// only the host NQNs specified can connect to NVMe-oF subsystem
+func checkAllowHosts(options addOptions) error {
+	if options.protocol != bs.EXPORT_PROTOCOL_NVMEOF {
+		return nil
+	} else if len(options.allowHosts) == 0 {
+		return errno.ERR_NVMEOF_EXPORT_REQUIRES_ALLOW_HOST
+	}
+	for _, nqn := range options.allowHosts {
+		if !HOST_NQN_REGEX.MatchString(nqn) {
+			return errno.ERR_INVALID_EXPORT_ALLOW_HOST.
+				F("host: %s", nqn)
+		}
+	}
+	return nil
+}
+
+func checkAddOptions(curveadm *cli.CurveAdm, options addOptions) error {
+	if _, _, err := client.ParseImage(options.image); err != nil {
+		return err
+	} else if _, err = client.ParseSize(options.size); err != nil {
+		return err
+	} else if !SUPPORT_EXPORT_PROTOCOLS[options.protocol] {
+		return errno.ERR_UNSUPPORT_EXPORT_PROTOCOL.
+			F("protocol: %s", options.protocol)
+	} else if len(options.listenAddress) > 0 && net.ParseIP(options.listenAddress) == nil {
+		return errno.ERR_INVALID_EXPORT_LISTEN_ADDRESS.
+			F("address: %s", options.listenAddress)
+	} else if err := checkAllowHosts(options); err != nil {
+		return err
+	} else if !cliutil.PathExist(options.filename) {
+		return errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
+			F("file path: %s", cliutil.AbsPath(options.filename))
+	}
+	return nil
+}
+
+func NewAddCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options addOptions
+
+	cmd := &cobra.Command{
+		Use:   "add USER:VOLUME [OPTIONS]",
+		Short: "Export a volume by NVMe-oF or vhost-user-blk",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return checkAddOptions(curveadm, options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return runAdd(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "localhost", "Specify export host")
+	flags.StringVar(&options.protocol, "protocol", bs.EXPORT_PROTOCOL_NVMEOF, "Specify export protocol (nvmeof|vhost)")
+	flags.BoolVar(&options.create, "create", false, "Create volume iff not exist")
+	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
+	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
+	flags.StringVar(&options.listenAddress, "listen-address", "", "Specify NVMe-oF listen address (default the address of host)")
+	flags.IntVar(&options.port, "port", bs.DEFAULT_NVMEOF_LISTEN_PORT, "Specify NVMe-oF listen port")
+	flags.StringSliceVar(&options.allowHosts, "allow-host", []string{}, "Specify host NQN which allowed to connect NVMe-oF subsystem")
+
+	return cmd
+}
+
+func genAddPlaybook(curveadm *cli.CurveAdm,
+	ccs []*configure.ClientConfig,
+	options bs.ExportOptions) (*playbook.Playbook, error) {
+	steps := ADD_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: ccs,
+			Options: map[string]interface{}{
+				comm.KEY_EXPORT_OPTIONS: options,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func runAdd(curveadm *cli.CurveAdm, options addOptions) error {
+	// 1) parse client configure
+	cc, err := configure.ParseClientConfig(options.filename)
+	if err != nil {
+		return err
+	} else if cc.GetKind() != topology.KIND_CURVEBS {
+		return errno.ERR_REQUIRE_CURVEBS_KIND_CLIENT_CONFIGURE_FILE.
+			F("kind: %s", cc.GetKind())
+	}
+
+	// 2) listen on the address of host by default
+	hc, err := curveadm.GetHost(options.host)
+	if err != nil {
+		return err
+	}
+	address := options.listenAddress
+	if len(address) == 0 {
+		address = hc.GetHostname()
+	}
+	user, name, _ := client.ParseImage(options.image)
+	size, _ := client.ParseSize(options.size)
+	exportOptions := bs.ExportOptions{
+		Host:       options.host,
+		User:       user,
+		Volume:     name,
+		Protocol:   options.protocol,
+		Create:     options.create,
+		Size:       size,
+		Address:    address,
+		Port:       options.port,
+		AllowHosts: options.allowHosts,
+	}
+
+	// 3) generate export playbook
+	pb, err := genAddPlaybook(curveadm, []*configure.ClientConfig{cc}, exportOptions)
+	if err != nil {
+		return err
+	}
+
+	// 4) run playground
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 5) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Export volume (%s) on %s success ^_^"),
+		options.image, options.host)
+	curveadm.WriteOutln(fmt.Sprintf("%s endpoint: %s", options.protocol, exportOptions.Endpoint()))
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package export
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	DELETE_PLAYBOOK_STEPS = []int{
+		playbook.DELETE_EXPORT,
+	}
+)
+
+type deleteOptions struct {
+	image string
+	host  string
+}
+
+func NewDeleteCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options deleteOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm USER:VOLUME [OPTIONS]",
+		Aliases: []string{"delete"},
+		Short:   "Delete an export of volume",
+		Args:    cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, _, err := client.ParseImage(args[0])
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return runDelete(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "localhost", "Specify export host")
+
+	return cmd
+}
+
+func genDeletePlaybook(curveadm *cli.CurveAdm, options deleteOptions) (*playbook.Playbook, error) {
+	user, name, _ := client.ParseImage(options.image)
+	steps := DELETE_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: nil,
+			Options: map[string]interface{}{
+				comm.KEY_EXPORT_OPTIONS: bs.ExportOptions{
+					Host:   options.host,
+					User:   user,
+					Volume: name,
+				},
+			},
+		})
+	}
+	return pb, nil
+}
+
+func runDelete(curveadm *cli.CurveAdm, options deleteOptions) error {
+	// 1) generate delete playbook
+	pb, err := genDeletePlaybook(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) run playground
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Delete export (%s) on %s success ^_^"),
+		options.image, options.host)
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package export
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/client"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	LIST_PLAYBOOK_STEPS = []int{
+		playbook.GET_CLIENT_STATUS,
+	}
+)
+
+type listOptions struct {
+	host string
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List exports and their status",
+		Args:    cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Specify export host (default all hosts)")
+
+	return cmd
+}
+
+func getExports(curveadm *cli.CurveAdm, options listOptions) ([]storage.Client, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	exports := []storage.Client{}
+	for _, client := range clients {
+		if client.Kind != bs.KIND_CURVEBS_EXPORT {
+			continue
+		} else if len(options.host) > 0 && client.Host != options.host {
+			continue
+		}
+		exports = append(exports, client)
+	}
+	return exports, nil
+}
+
+func genListPlaybook(curveadm *cli.CurveAdm, exports []storage.Client) (*playbook.Playbook, error) {
+	config := []interface{}{}
+	for _, export := range exports {
+		config = append(config, export)
+	}
+
+	steps := LIST_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: config,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func displayExports(curveadm *cli.CurveAdm, exports []storage.Client) {
+	statuses := []task.ClientStatus{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_CLIENT_STATUS)
+	if v != nil {
+		for _, status := range v.(map[string]task.ClientStatus) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	output := tui.FormatExports(statuses)
+	if len(exports) > 0 {
+		curveadm.WriteOutln("")
+	}
+	curveadm.WriteOut(output)
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) get all exports
+	exports, err := getExports(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) generate get status playbook
+	pb, err := genListPlaybook(curveadm, exports)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playground
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	// 4) display exports
+	displayExports(curveadm, exports)
+	return nil
+}
@@ -24,6 +24,8 @@ package command
 
 import (
 	"fmt"
+
+	"github.com/dustin/go-humanize"
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
@@ -44,7 +46,8 @@ const (
   $ curveadm format --status -f /path/to/format.yaml     # Display formatting status
   $ curveadm format --stop   -f /path/to/format.yaml     # Stop formatting progress
   $ curveadm format --debug  -f /path/to/format.yaml     # Format chunkfile with debug mode
-  $ curveadm format --clean  -f /path/to/format.yaml     # clean the container left by debug mode`
+  $ curveadm format --clean  -f /path/to/format.yaml     # clean the container left by debug mode
+  $ curveadm format --concurrency 10 --host-concurrency 2 --bandwidth 200MiB  # Format with limited concurrency and bandwidth`
 )
 
 var (
@@ -71,12 +74,15 @@ var (
 )
 
 type formatOptions struct {
-	filename   string
-	showStatus bool
-	stopFormat bool
-	debug      bool
-	clean      bool
-	increment  bool
+	filename        string
+	showStatus      bool
+	stopFormat      bool
+	debug           bool
+	clean           bool
+	increment       bool
+	concurrency     uint
+	hostConcurrency uint
+	bandwidth       string
 }
 
 func checkFormatOptions(options formatOptions) error {
@@ -97,6 +103,13 @@ func checkFormatOptions(options formatOptions) error {
 		}
 	}
 
+	if len(options.bandwidth) > 0 {
+		if _, err := humanize.ParseBytes(options.bandwidth); err != nil {
+			return errno.ERR_INVALID_FORMAT_BANDWIDTH.
+				F("bandwidth: %s", options.bandwidth)
+		}
+	}
+
 	return nil
 }
 
@@ -124,6 +137,9 @@ func NewFormatCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.BoolVar(&options.debug, "debug", false, "Debug formatting progress")
 	flags.BoolVar(&options.clean, "clean", false, "Clean the Container")
 	flags.BoolVar(&options.increment, "increment", false, "Incremental formatting")
+	flags.UintVar(&options.concurrency, "concurrency", 0, "Specify the maximum number of disks formatting at the same time, wait formatting done if specified")
+	flags.UintVar(&options.hostConcurrency, "host-concurrency", 0, "Specify the maximum number of disks formatting at the same time in one host")
+	flags.StringVar(&options.bandwidth, "bandwidth", "", "Specify the write bandwidth limit of each formatting (e.g. 200MiB)")
 
 	return cmd
 }
@@ -140,6 +156,9 @@ func genFormatPlaybook(curveadm *cli.CurveAdm,
 	debug := options.debug
 	clean := options.clean
 	increment := options.increment
+	concurrency := options.concurrency
+	hostConcurrency := options.hostConcurrency
+	bandwidth, _ := humanize.ParseBytes(options.bandwidth)
 
 	steps := FORMAT_PLAYBOOK_STEPS
 	if showStatus {
@@ -161,6 +180,9 @@ func genFormatPlaybook(curveadm *cli.CurveAdm,
 		options := map[string]interface{}{}
 		if step == playbook.FORMAT_CHUNKFILE_POOL {
 			options[comm.DEBUG_MODE] = debug
+			options[comm.FORMAT_CONCURRENCY] = int(concurrency)
+			options[comm.FORMAT_HOST_CONCURRENCY] = int(hostConcurrency)
+			options[comm.FORMAT_BANDWIDTH] = int(bandwidth)
 		}
 		options[comm.FORMAT_INCREMENTAL] = increment
 		if step == playbook.STOP_SERVICE {
@@ -185,6 +207,7 @@ func genFormatPlaybook(curveadm *cli.CurveAdm,
 				Type:    step,
 				Configs: fcs,
 				ExecOptions: playbook.ExecOptions{
+					Concurrency:  concurrency,
 					SilentSubBar: showStatus,
 				},
 				Options: options,
@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewFSCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fs",
+		Short: "Manage filesystems of CurveFS",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewListCommand(curveadm),
+		NewCreateCommand(curveadm),
+		NewQuotaCommand(curveadm),
+		NewUsageCommand(curveadm),
+		NewDeleteCommand(curveadm),
+	)
+	return cmd
+}
+
+// all filesystem operations are executed in the first mds service
+func manageFilesystem(curveadm *cli.CurveAdm, options fs.FilesystemOptions) error {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	} else if len(dcs) == 0 || dcs[0].GetKind() != topology.KIND_CURVEFS {
+		return errno.ERR_FS_REQUIRE_CURVEFS_CLUSTER
+	}
+
+	dcs = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.MANAGE_FILESYSTEM,
+		Configs: dcs[0],
+		Options: map[string]interface{}{
+			comm.KEY_FILESYSTEM_OPTIONS: options,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar: true,
+		},
+	})
+	return pb.Run()
+}
+
+func listFilesystems(curveadm *cli.CurveAdm) ([]fs.Filesystem, error) {
+	err := manageFilesystem(curveadm, fs.FilesystemOptions{
+		Action: fs.FILESYSTEM_ACTION_LIST,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filesystems := []fs.Filesystem{}
+	value := curveadm.MemStorage().Get(comm.KEY_ALL_FILESYSTEMS)
+	if value != nil {
+		filesystems = value.([]fs.Filesystem)
+	}
+	return filesystems, nil
+}
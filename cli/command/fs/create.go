@@ -0,0 +1,159 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CREATE_EXAMPLE = `Examples:
+  $ curveadm fs create test --s3.ak AK --s3.sk-file sk.txt --s3.endpoint http://127.0.0.1:9000 --s3.bucket_name curvefs  # Create s3 filesystem 'test'
+  $ curveadm fs create test --capacity 1TiB --block-size 4MiB ...                                                           # Create filesystem with explicit capacity and block size`
+)
+
+const (
+	ENV_S3_SECRET_KEY = "CURVEADM_S3_SECRET_KEY"
+)
+
+var (
+	SUPPORT_FILESYSTEM_TYPES = map[string]bool{
+		fs.FILESYSTEM_TYPE_S3:     true,
+		fs.FILESYSTEM_TYPE_VOLUME: true,
+		fs.FILESYSTEM_TYPE_HYBRID: true,
+	}
+)
+
+type createOptions struct {
+	name         string
+	fstype       string
+	capacity     string
+	blockSize    string
+	s3AccessKey  string
+	s3SkFile     string
+	s3Address    string
+	s3BucketName string
+}
+
+func NewCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options createOptions
+
+	cmd := &cobra.Command{
+		Use:     "create NAME [OPTIONS]",
+		Short:   "Create filesystem",
+		Args:    cliutil.ExactArgs(1),
+		Example: CREATE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			_, err := parseCreateOptions(options)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.fstype, "fstype", fs.FILESYSTEM_TYPE_S3, "Specify filesystem data backend (s3/volume/hybrid)")
+	flags.StringVar(&options.capacity, "capacity", "", "Specify filesystem capacity, like 100GiB (default unlimited)")
+	flags.StringVar(&options.blockSize, "block-size", "1MiB", "Specify filesystem block size")
+	flags.StringVar(&options.s3AccessKey, "s3.ak", "", "Specify S3 access key")
+	flags.StringVar(&options.s3SkFile, "s3.sk-file", "", "Specify the file which contains S3 secret key")
+	flags.StringVar(&options.s3Address, "s3.endpoint", "", "Specify S3 address")
+	flags.StringVar(&options.s3BucketName, "s3.bucket_name", "", "Specify S3 bucket name")
+
+	return cmd
+}
+
+func parseCreateOptions(options createOptions) (fs.FilesystemOptions, error) {
+	fsOptions := fs.FilesystemOptions{
+		Action:       fs.FILESYSTEM_ACTION_CREATE,
+		Name:         options.name,
+		Type:         options.fstype,
+		S3AccessKey:  options.s3AccessKey,
+		S3Address:    options.s3Address,
+		S3BucketName: options.s3BucketName,
+	}
+
+	if !SUPPORT_FILESYSTEM_TYPES[options.fstype] {
+		return fsOptions, errno.ERR_UNSUPPORT_FILESYSTEM_TYPE.
+			F("fstype: %s", options.fstype)
+	}
+
+	blockSize, err := humanize.ParseBytes(options.blockSize)
+	if err != nil || blockSize == 0 {
+		return fsOptions, errno.ERR_INVALID_FILESYSTEM_BLOCK_SIZE.
+			F("block size: %s", options.blockSize)
+	}
+	fsOptions.BlockSize = blockSize
+
+	if len(options.capacity) > 0 {
+		capacity, err := humanize.ParseBytes(options.capacity)
+		if err != nil || capacity == 0 {
+			return fsOptions, errno.ERR_INVALID_FILESYSTEM_CAPACITY.
+				F("capacity: %s", options.capacity)
+		}
+		fsOptions.Capacity = capacity
+	}
+
+	if options.fstype == fs.FILESYSTEM_TYPE_S3 &&
+		(len(options.s3AccessKey) == 0 ||
+			len(options.s3Address) == 0 || len(options.s3BucketName) == 0) {
+		return fsOptions, errno.ERR_FILESYSTEM_REQUIRES_S3_INFO
+	}
+	return fsOptions, nil
+}
+
+func runCreate(curveadm *cli.CurveAdm, options createOptions) error {
+	fsOptions, err := parseCreateOptions(options)
+	if err != nil {
+		return err
+	}
+
+	// the secret key is read from file, environment or prompt
+	if options.fstype == fs.FILESYSTEM_TYPE_S3 {
+		fsOptions.S3SecretKey, err = cli.ReadSecret(cli.Secret{
+			Name: "S3 secret key",
+			File: options.s3SkFile,
+			Env:  ENV_S3_SECRET_KEY,
+		})
+		if err != nil {
+			return err
+		} else if len(fsOptions.S3SecretKey) == 0 {
+			return errno.ERR_FILESYSTEM_REQUIRES_S3_INFO
+		}
+	}
+
+	if err = manageFilesystem(curveadm, fsOptions); err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Created filesystem '%s'", options.name)
+	return nil
+}
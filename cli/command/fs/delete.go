@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	name string
+	yes  bool
+}
+
+func NewDeleteCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options deleteOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm NAME [OPTIONS]",
+		Aliases: []string{"delete"},
+		Short:   "Delete unused filesystem",
+		Args:    cliutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runDelete(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Skip confirmation")
+
+	return cmd
+}
+
+func checkFilesystemUnused(curveadm *cli.CurveAdm, name string) error {
+	filesystems, err := listFilesystems(curveadm)
+	if err != nil {
+		return err
+	}
+
+	for _, fs := range filesystems {
+		if fs.Name != name {
+			continue
+		} else if fs.MountNum > 0 || len(fs.MountPoints) > 0 {
+			return errno.ERR_FILESYSTEM_IS_MOUNTED.
+				F("filesystem: %s, mount points: %v", name, fs.MountPoints)
+		}
+		return nil
+	}
+	return errno.ERR_FILESYSTEM_NOT_FOUND.F("filesystem: %s", name)
+}
+
+func runDelete(curveadm *cli.CurveAdm, options deleteOptions) error {
+	// 1) filesystem must exist and no client mount it
+	err := checkFilesystemUnused(curveadm, options.name)
+	if err != nil {
+		return err
+	}
+
+	// 2) confirm by user
+	if !options.yes {
+		if pass := tui.ConfirmYes(tui.PromptDeleteFilesystem(options.name)); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("delete filesystem"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 3) delete filesystem
+	err = manageFilesystem(curveadm, fs.FilesystemOptions{
+		Action: fs.FILESYSTEM_ACTION_DELETE,
+		Name:   options.name,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Deleted filesystem '%s'", options.name)
+	return nil
+}
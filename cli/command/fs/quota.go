@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	QUOTA_EXAMPLE = `Examples:
+  $ curveadm fs quota test --capacity 100                   # Limit filesystem 'test' to 100GiB
+  $ curveadm fs quota test --capacity 100 --inodes 1000000  # Limit filesystem 'test' to 100GiB and 1000000 inodes`
+)
+
+type quotaOptions struct {
+	name     string
+	capacity uint64
+	inodes   uint64
+}
+
+func NewQuotaCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options quotaOptions
+
+	cmd := &cobra.Command{
+		Use:     "quota NAME [OPTIONS]",
+		Short:   "Set filesystem quota",
+		Args:    cliutil.ExactArgs(1),
+		Example: QUOTA_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runQuota(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.Uint64Var(&options.capacity, "capacity", 0, "Specify capacity quota in GiB (0 means unlimited)")
+	flags.Uint64Var(&options.inodes, "inodes", 0, "Specify inodes quota (0 means unlimited)")
+
+	return cmd
+}
+
+func runQuota(curveadm *cli.CurveAdm, options quotaOptions) error {
+	err := manageFilesystem(curveadm, fs.FilesystemOptions{
+		Action:        fs.FILESYSTEM_ACTION_QUOTA,
+		Name:          options.name,
+		QuotaCapacity: options.capacity,
+		QuotaInodes:   options.inodes,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Set quota for filesystem '%s'", options.name)
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewUsageCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage NAME",
+		Short: "Show filesystem usage and quota",
+		Args:  cliutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUsage(curveadm, args[0])
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func runUsage(curveadm *cli.CurveAdm, name string) error {
+	err := manageFilesystem(curveadm, fs.FilesystemOptions{
+		Action: fs.FILESYSTEM_ACTION_USAGE,
+		Name:   name,
+	})
+	if err != nil {
+		return err
+	}
+
+	usage := curveadm.MemStorage().Get(comm.KEY_FILESYSTEM_USAGE).(*fs.FilesystemUsage)
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatFilesystemUsage(usage))
+	return nil
+}
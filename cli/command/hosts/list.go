@@ -128,7 +128,7 @@ func intersectOne(hc *hosts.HostConfig, intersect map[string]bool) bool {
 	return len(exist) == len(intersect)
 }
 
-func filter(data string, labels []string) ([]*hosts.HostConfig, error) {
+func Filter(data string, labels []string) ([]*hosts.HostConfig, error) {
 	hcs, err := hosts.ParseHosts(data)
 	if err != nil {
 		return nil, err
@@ -157,7 +157,7 @@ func runList(curveadm *cli.CurveAdm, options listOptions) error {
 	var err error
 	data := curveadm.Hosts()
 	if len(data) > 0 {
-		hcs, err = filter(data, options.labels) // filter hosts
+		hcs, err = Filter(data, options.labels) // filter hosts
 		if err != nil {
 			return err
 		}
@@ -33,7 +33,7 @@ hosts:
 
 func run(t *testing.T, data string, labels []string, out []string) {
 	assert := assert.New(t)
-	hcs, err := filter(data, labels)
+	hcs, err := Filter(data, labels)
 	assert.Nil(err)
 	assert.Equal(len(hcs), len(out))
 	for i, hc := range hcs {
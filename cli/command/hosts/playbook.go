@@ -150,7 +150,7 @@ func runPlaybook(curveadm *cli.CurveAdm, options playbookOptions) error {
 	var err error
 	hosts := curveadm.Hosts()
 	if len(hosts) > 0 {
-		hcs, err = filter(hosts, options.labels) // filter hosts
+		hcs, err = Filter(hosts, options.labels) // filter hosts
 		if err != nil {
 			return err
 		}
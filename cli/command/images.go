@@ -0,0 +1,141 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/service"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	IMAGES_EXAMPLE = `Examples:
+  $ curveadm images                      # Display image and digest of all services
+  $ curveadm images --role chunkserver   # Display image and digest of chunkserver services
+  $ curveadm images -v                   # Display full digests`
+)
+
+var (
+	GET_IMAGES_PLAYBOOK_STEPS = []int{
+		playbook.GET_SERVICE_IMAGE,
+	}
+)
+
+type imagesOptions struct {
+	id      string
+	role    string
+	host    string
+	verbose bool
+}
+
+func NewImagesCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options imagesOptions
+
+	cmd := &cobra.Command{
+		Use:     "images [OPTIONS]",
+		Short:   "Display image and digest which service containers are running",
+		Args:    cliutil.NoArgs,
+		Example: IMAGES_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImages(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Display full digests")
+
+	return cmd
+}
+
+func genImagesPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options imagesOptions) (*playbook.Playbook, error) {
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	steps := GET_IMAGES_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: true,
+				SkipError:    true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func displayImages(curveadm *cli.CurveAdm, options imagesOptions) {
+	images := []task.ServiceImage{}
+	value := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_IMAGES)
+	if value != nil {
+		m := value.(map[string]task.ServiceImage)
+		for _, image := range m {
+			images = append(images, image)
+		}
+	}
+
+	output := tui.FormatServiceImages(images, options.verbose)
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", output)
+}
+
+func runImages(curveadm *cli.CurveAdm, options imagesOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) generate get images playbook
+	pb, err := genImagesPlaybook(curveadm, dcs, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playground
+	err = pb.Run()
+
+	// 4) display service images
+	displayImages(curveadm, options)
+	return err
+}
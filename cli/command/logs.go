@@ -0,0 +1,217 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LOGS_EXAMPLE = `Examples:
+  $ curveadm logs --role chunkserver                       # Show the last 100 lines of all chunkserver logs
+  $ curveadm logs --host host1 --since 10m --grep ERROR    # Show error lines in last 10 minutes on host1
+  $ curveadm logs --role mds -f                            # Follow the logs of all mds
+  $ curveadm logs --since 1h --output /tmp/logs            # Save the last 1 hour logs to local directory`
+)
+
+var (
+	LOGS_PLAYBOOK_STEPS = []int{
+		playbook.STREAM_SERVICE_LOGS,
+	}
+)
+
+type logsOptions struct {
+	id     string
+	role   string
+	host   string
+	since  string
+	tail   int
+	follow bool
+	grep   string
+	output string
+}
+
+func NewLogsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options logsOptions
+
+	cmd := &cobra.Command{
+		Use:     "logs [OPTIONS]",
+		Short:   "Show and search service logs",
+		Args:    cliutil.NoArgs,
+		Example: LOGS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.follow && len(options.output) > 0 {
+				return errno.ERR_FOLLOW_CONFLICT_WITH_OUTPUT
+			}
+			// show all lines in time window unless tail specified
+			if len(options.since) > 0 && !cmd.Flags().Changed("tail") {
+				options.tail = 0
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.since, "since", "", "Show logs since relative time (e.g. 30s, 10m, 2h)")
+	flags.IntVarP(&options.tail, "tail", "n", 100, "Number of lines to show from the end of each log (0 means all)")
+	flags.BoolVarP(&options.follow, "follow", "f", false, "Follow log output")
+	flags.StringVar(&options.grep, "grep", "", "Only show lines which match the regular expression")
+	flags.StringVarP(&options.output, "output", "o", "", "Save logs to local directory instead of output them")
+
+	return cmd
+}
+
+func parseLogsOptions(options logsOptions) (task.LogsOptions, error) {
+	logsOptions := task.LogsOptions{
+		Tail:   options.tail,
+		Follow: options.follow,
+	}
+	if len(options.since) > 0 {
+		since, err := time.ParseDuration(options.since)
+		if err != nil || since <= 0 {
+			return logsOptions, errno.ERR_INVALID_LOGS_SINCE.
+				F("since: %s", options.since)
+		}
+		logsOptions.Since = since
+	}
+	if len(options.grep) > 0 {
+		grep, err := regexp.Compile(options.grep)
+		if err != nil {
+			return logsOptions, errno.ERR_BUILD_REGEX_FAILED.E(err)
+		}
+		logsOptions.Grep = grep
+	}
+	return logsOptions, nil
+}
+
+func genLogsPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	logsOptions task.LogsOptions,
+	merger *task.LogMerger) *playbook.Playbook {
+	steps := LOGS_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+			Options: map[string]interface{}{
+				comm.KEY_LOGS_OPTIONS: logsOptions,
+				comm.KEY_LOGS_MERGER:  merger,
+			},
+			ExecOptions: playbook.ExecOptions{
+				Concurrency:   uint(len(dcs)), // all services are followed at the same time
+				SilentSubBar:  true,
+				SilentMainBar: true,
+				SkipError:     true,
+			},
+		})
+	}
+	return pb
+}
+
+// save logs of each service into one file: <output>/<host>_<role>_<id>.log
+func saveLogs(curveadm *cli.CurveAdm, lines []task.LogLine, output string) error {
+	files := map[string][]string{}
+	prefixes := []string{}
+	for _, line := range lines {
+		prefix := line.Prefix()
+		if _, ok := files[prefix]; !ok {
+			prefixes = append(prefixes, prefix)
+		}
+		files[prefix] = append(files[prefix], line.Text)
+	}
+
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return errno.ERR_SAVE_SERVICE_LOGS_FAILED.E(err)
+	}
+	for _, prefix := range prefixes {
+		filename := path.Join(output, strings.ReplaceAll(prefix, "/", "_")+".log")
+		data := strings.Join(files[prefix], "\n") + "\n"
+		if err := cliutil.WriteFile(filename, data, 0644); err != nil {
+			return errno.ERR_SAVE_SERVICE_LOGS_FAILED.E(err)
+		}
+		curveadm.WriteOutln("Saved %d lines to '%s'", len(files[prefix]), filename)
+	}
+	if len(prefixes) == 0 {
+		curveadm.WriteOutln("No logs matched")
+	}
+	return nil
+}
+
+func runLogs(curveadm *cli.CurveAdm, options logsOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) filter service
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) generate logs playbook
+	logsOptions, err := parseLogsOptions(options)
+	if err != nil {
+		return err
+	}
+	merger := task.NewLogMerger(curveadm.Out(), options.follow)
+	pb := genLogsPlaybook(curveadm, dcs, logsOptions, merger)
+
+	// 4) run playbook, the lines are merged by timestamp
+	merger.Start()
+	err = pb.Run()
+	if len(options.output) > 0 {
+		if err := saveLogs(curveadm, merger.Lines(), options.output); err != nil {
+			return err
+		}
+	} else {
+		merger.Stop()
+	}
+	return err
+}
@@ -40,6 +40,7 @@ var (
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE, // only container
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_ETCD,
@@ -51,6 +52,7 @@ var (
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE, // only container
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_MDS,
@@ -62,6 +64,7 @@ var (
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE, // only container
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SNAPSHOTCLONE,
@@ -74,6 +77,7 @@ var (
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE, // only container
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.CREATE_PHYSICAL_POOL,
@@ -87,6 +91,7 @@ var (
 		playbook.STOP_SERVICE, // only container
 		playbook.CLEAN_SERVICE,
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_METASERVER,
@@ -199,6 +204,8 @@ func genMigratePlaybook(curveadm *cli.CurveAdm,
 		case CREATE_PHYSICAL_POOL,
 			CREATE_LOGICAL_POOL:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)[:1]
+		case playbook.VERIFY_IMAGE_DIGEST:
+			config = config[:1]
 		}
 
 		// options
@@ -30,9 +30,11 @@ import (
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	pg "github.com/opencurve/curveadm/internal/task/task/playground"
 	"github.com/opencurve/curveadm/internal/task/task/playground/script"
 	"github.com/opencurve/curveadm/internal/utils"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
@@ -44,12 +46,19 @@ const (
 	KIND_CURVEFS = topology.KIND_CURVEFS
 
 	FORMAT_PLAYGROUND_NAME = "playground-%s-%d" // playground-curvebs-1656035415
+	FORMAT_SPARE_NODE_NAME = "playground-spare%d"
+
+	RUN_EXAMPLE = `Examples:
+  $ curveadm playground run --kind curvebs                      # Run a single container CurveBS playground
+  $ curveadm playground run --kind curvebs --multi-node         # Run a 3 nodes CurveBS playground
+  $ curveadm playground run --kind curvefs --spares 1           # Run a 3 nodes CurveFS playground with 1 spare node
+  $ curveadm playground run --kind curvebs -f topology.yaml     # Run playground with specified topology`
 )
 
 var (
 	supportKind = map[string]bool{
 		KIND_CURVEBS: true,
-		//KIND_CURVEFS: true, // FIXME: support curvefs
+		KIND_CURVEFS: true,
 	}
 
 	RUN_PLAYGROUND_PLAYBOOK_STEPS = []int{
@@ -57,6 +66,10 @@ var (
 		playbook.INIT_PLAYGROUND,
 		playbook.START_PLAYGROUND,
 	}
+
+	RUN_PLAYGROUND_NODES_PLAYBOOK_STEPS = []int{
+		playbook.CREATE_PLAYGROUND_NODES,
+	}
 )
 
 type runOptions struct {
@@ -64,6 +77,18 @@ type runOptions struct {
 	kind           string
 	mountPoint     string
 	containerImage string
+	filename       string
+	multiNode      bool
+	spares         int
+	nodeImage      string
+}
+
+// the single container playground only support curvebs
+func (options runOptions) isMultiNode() bool {
+	return options.multiNode ||
+		options.kind == KIND_CURVEFS ||
+		len(options.filename) > 0 ||
+		options.spares > 0
 }
 
 func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
@@ -74,7 +99,10 @@ func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
 			F("kind=%s", kind)
 	}
 
-	if kind == KIND_CURVEBS {
+	if options.spares < 0 {
+		return errno.ERR_INVALID_PLAYGROUND_SPARE_NODES.
+			F("spares=%d", options.spares)
+	} else if kind == KIND_CURVEBS || options.isMultiNode() {
 		return nil
 	}
 
@@ -99,11 +127,15 @@ func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		Aliases: []string{"create"},
 		Short:   "Run playground",
 		Args:    cliutil.NoArgs,
+		Example: RUN_EXAMPLE,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return checkRunOptions(curveadm, options)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.name = fmt.Sprintf(FORMAT_PLAYGROUND_NAME, options.kind, time.Now().Unix())
+			if options.isMultiNode() {
+				return runMultiNode(curveadm, options)
+			}
 			return runRun(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
@@ -113,6 +145,10 @@ func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVarP(&options.kind, "kind", "k", "curvefs", "Specify the type of playground (curvebs/curvefs)")
 	flags.StringVar(&options.mountPoint, "mountpoint", "p", "Specify the mountpoint for CurveFS playground")
 	flags.StringVarP(&options.containerImage, "container_image", "i", "opencurvedocker/curvebs:playground", "Specify the playground container image")
+	flags.StringVarP(&options.filename, "topology", "f", "", "Specify the path of topology file for multi-node playground")
+	flags.BoolVar(&options.multiNode, "multi-node", false, "Run playground with multiple nodes, each node acts as a host")
+	flags.IntVar(&options.spares, "spares", 0, "Specify the number of spare nodes for scale-out or migrate")
+	flags.StringVar(&options.nodeImage, "node-image", configure.DEFAULT_PLAYGROUND_NODE_IMAGE, "Specify the container image of playground node")
 
 	return cmd
 }
@@ -178,3 +214,118 @@ func runRun(curveadm *cli.CurveAdm, options runOptions) error {
 		options.name))
 	return nil
 }
+
+func readPlaygroundTopology(options runOptions) (string, error) {
+	filename := options.filename
+	if len(filename) == 0 {
+		if options.kind == KIND_CURVEBS {
+			return script.CLUSTER_CURVEBS, nil
+		}
+		return script.CLUSTER_CURVEFS, nil
+	} else if !utils.PathExist(filename) {
+		return "", errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
+			F("%s: no such file", utils.AbsPath(filename))
+	}
+
+	data, err := utils.ReadFile(filename)
+	if err != nil {
+		return "", errno.ERR_READ_TOPOLOGY_FILE_FAILED.E(err)
+	}
+	return data, nil
+}
+
+func getPlaygroundNodes(curveadm *cli.CurveAdm, data string, options runOptions) ([]string, error) {
+	kind, nodes, err := topology.ParseTopologyHosts(data)
+	if err != nil {
+		return nil, err
+	} else if kind != options.kind {
+		return nil, errno.ERR_PLAYGROUND_TOPOLOGY_KIND_MISMATCH.
+			F("playground kind: %s, topology kind: %s", options.kind, kind)
+	}
+	for i := 1; i <= options.spares; i++ {
+		nodes = append(nodes, fmt.Sprintf(FORMAT_SPARE_NODE_NAME, i))
+	}
+
+	// the node will be added into hosts, so it can't conflict with existed host
+	hcs, err := hosts.ParseHosts(curveadm.Hosts())
+	if err != nil {
+		return nil, err
+	}
+	for _, hc := range hcs {
+		for _, node := range nodes {
+			if hc.GetHost() == node {
+				return nil, errno.ERR_PLAYGROUND_NODE_ALREADY_EXIST.
+					F("host: %s", node)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+func genRunNodesPlaybook(curveadm *cli.CurveAdm,
+	nodes []string,
+	options runOptions) (*playbook.Playbook, error) {
+	steps := RUN_PLAYGROUND_NODES_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type: step,
+			Configs: &configure.PlaygroundConfig{
+				Kind:           options.kind,
+				Name:           options.name,
+				Nodes:          nodes,
+				NodeImage:      options.nodeImage,
+				PrivateKeyFile: pg.GetPrivateKeyFile(curveadm, options.name),
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func runMultiNode(curveadm *cli.CurveAdm, options runOptions) error {
+	// 1) read topology and get nodes
+	data, err := readPlaygroundTopology(options)
+	if err != nil {
+		return err
+	}
+	nodes, err := getPlaygroundNodes(curveadm, data, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) print prompt
+	curveadm.WriteOutln(color.GreenString("Start to run playground '%s' with %d nodes\n"),
+		options.name, len(nodes))
+
+	// 3) create nodes and add them into hosts
+	pb, err := genRunNodesPlaybook(curveadm, nodes, options)
+	if err != nil {
+		return err
+	}
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) add cluster with topology and checkout it
+	storage := curveadm.Storage()
+	err = storage.InsertCluster(options.name, "playground", data)
+	if err != nil {
+		return errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+	}
+	err = storage.CheckoutCluster(options.name)
+	if err != nil {
+		return errno.ERR_CHECKOUT_CLUSTER_FAILED.E(err)
+	}
+
+	// 5) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Playground '%s' nodes successfully created ^_^"), options.name)
+	curveadm.WriteOutln("Cluster '%s' has been checked out, run '%s' to deploy it,",
+		options.name, color.BlueString("curveadm deploy -k"))
+	curveadm.WriteOutln("then test failover, scale-out or migrate with the spare nodes.")
+	return nil
+}
@@ -23,14 +23,17 @@
 package command
 
 import (
+	"os"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/bundle"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
@@ -41,6 +44,7 @@ var (
 	// etcd
 	SCALE_OUT_ETCD_STEPS = []int{
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SERVICE,
@@ -50,6 +54,7 @@ var (
 	// mds
 	SCALE_OUT_MDS_STEPS = []int{
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SERVICE,
@@ -59,6 +64,7 @@ var (
 	// snapshotclone (curvebs)
 	SCALE_OUT_SNAPSHOTCLONE_STEPS = []int{
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SERVICE,
@@ -69,6 +75,7 @@ var (
 	SCALE_OUT_CHUNKSERVER_STEPS = []int{
 		playbook.BACKUP_ETCD_DATA,
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.CREATE_PHYSICAL_POOL,
@@ -80,6 +87,7 @@ var (
 	SCALE_OUT_METASERVER_STEPS = []int{
 		playbook.BACKUP_ETCD_DATA,
 		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SERVICE,
@@ -118,6 +126,7 @@ type scaleOutOptions struct {
 	filename        string
 	poolset         string
 	poolsetDiskType string
+	bundle          string
 }
 
 func NewScaleOutCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -140,6 +149,7 @@ func NewScaleOutCommand(curveadm *cli.CurveAdm) *cobra.Command {
 
 	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset")
 	flags.StringVar(&options.poolsetDiskType, "poolset-disktype", "ssd", "Specify the disk type of physical pool")
+	flags.StringVar(&options.bundle, "bundle", "", "Distribute images from offline bundle instead of pulling them")
 
 	return cmd
 }
@@ -311,7 +321,8 @@ func precheckBeforeScaleOut(curveadm *cli.CurveAdm, options scaleOutOptions, dat
 }
 
 func genScaleOutPlaybook(curveadm *cli.CurveAdm,
-	dcs []*topology.DeployConfig, data, poolset, poolsetDiskType string) (*playbook.Playbook, error) {
+	dcs []*topology.DeployConfig, data, poolset, poolsetDiskType string,
+	bundleOptions *task.BundleOptions) (*playbook.Playbook, error) {
 	diffs, _ := diffTopology(curveadm, data)
 	dcs2scaleOut := diffs[topology.DIFF_ADD]
 	role := dcs2scaleOut[0].GetRole()
@@ -319,6 +330,14 @@ func genScaleOutPlaybook(curveadm *cli.CurveAdm,
 
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
+		if step == playbook.PULL_IMAGE && bundleOptions != nil {
+			err := bundle.AddLoadSteps(curveadm, pb, deployHosts(dcs2scaleOut), *bundleOptions)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		// configs
 		config := dcs2scaleOut
 		switch step {
@@ -327,6 +346,8 @@ func genScaleOutPlaybook(curveadm *cli.CurveAdm,
 		case CREATE_PHYSICAL_POOL,
 			CREATE_LOGICAL_POOL:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)[:1]
+		case playbook.VERIFY_IMAGE_DIGEST:
+			config = config[:1]
 		}
 
 		// options
@@ -406,7 +427,18 @@ func runScaleOut(curveadm *cli.CurveAdm, options scaleOutOptions) error {
 	}
 
 	// 7) generate scale-out playbook
-	pb, err := genScaleOutPlaybook(curveadm, dcs, data, options.poolset, options.poolsetDiskType)
+	var bundleOptions *task.BundleOptions
+	if len(options.bundle) > 0 {
+		diffs, _ := diffTopology(curveadm, data)
+		images := task.TopologyImages(diffs[topology.DIFF_ADD])
+		bo, err := bundle.NewLoadOptions(curveadm, options.bundle, images)
+		if err != nil {
+			return err
+		}
+		bundleOptions = &bo
+		defer os.RemoveAll(bo.Dir)
+	}
+	pb, err := genScaleOutPlaybook(curveadm, dcs, data, options.poolset, options.poolsetDiskType, bundleOptions)
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,12 @@
 package command
 
 import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -30,6 +36,7 @@ import (
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
 	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
@@ -37,29 +44,53 @@ import (
 )
 
 const (
-	SUPPORT_UPLOAD_URL_FORMAT = "http://curveadm.aspirer.wang:19301/upload?path=%s"
+	SUPPORT_EXAMPLE = `Examples:
+  $ curveadm support                                        # Collect support bundle into current directory
+  $ curveadm support --role chunkserver --since 2h          # Only collect chunkserver logs in last 2 hours
+  $ curveadm support --include-cores -o /tmp                # Collect core files and save bundle to /tmp
+  $ curveadm support --upload-url http://10.0.0.1/upload    # Upload bundle to your own http server
+  $ curveadm support --s3-endpoint http://10.0.0.1:9000 --s3-bucket support \
+      --s3-access-key AK --s3-secret-key-file sk.txt        # Upload bundle to your own S3 bucket`
+
+	ENV_SUPPORT_S3_SECRET_KEY = "CURVEADM_S3_SECRET_KEY"
 )
 
 var (
 	SUPPORT_PLAYBOOK_STEPS = []int{
 		playbook.INIT_SUPPORT,
-		//playbook.COLLECT_REPORT,
 		playbook.COLLECT_CURVEADM,
 		playbook.COLLECT_SERVICE,
 	}
 )
 
 type supportOptions struct {
-	ids []string
+	ids            []string
+	role           string
+	host           string
+	since          string
+	includeCores   bool
+	includeConfigs bool
+	output         string
+	encrypt        bool
+	uploadURL      string
+	s3             task.SupportS3Options
+	s3SkFile       string
 }
 
 func NewSupportCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options supportOptions
 
 	cmd := &cobra.Command{
-		Use:   "support [OPTIONS]",
-		Short: "Get support from Curve team",
-		Args:  cliutil.NoArgs,
+		Use:     "support [OPTIONS]",
+		Short:   "Collect logs, configures and cores into a support bundle",
+		Args:    cliutil.NoArgs,
+		Example: SUPPORT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := readSupportS3SecretKey(&options); err != nil {
+				return err
+			}
+			return checkSupportOptions(options)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSupport(curveadm, options)
 		},
@@ -68,10 +99,55 @@ func NewSupportCommand(curveadm *cli.CurveAdm) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringSliceVarP(&options.ids, "client", "c", []string{}, "Specify client id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.since, "since", "", "Only collect logs modified since relative time (e.g. 30m, 2h)")
+	flags.BoolVar(&options.includeCores, "include-cores", false, "Collect core files")
+	flags.BoolVar(&options.includeConfigs, "include-configs", true, "Collect configure files (secrets are redacted)")
+	flags.StringVarP(&options.output, "output", "o", ".", "Specify directory to save support bundle")
+	flags.BoolVar(&options.encrypt, "encrypt", false, "Encrypt support bundle with a random secret key")
+	flags.StringVar(&options.uploadURL, "upload-url", "", "Upload support bundle to the specified url by HTTP POST")
+	flags.StringVar(&options.s3.Endpoint, "s3-endpoint", "", "Upload support bundle to the specified S3 endpoint")
+	flags.StringVar(&options.s3.Bucket, "s3-bucket", "", "Specify S3 bucket")
+	flags.StringVar(&options.s3.Region, "s3-region", task.DEFAULT_S3_REGION, "Specify S3 region")
+	flags.StringVar(&options.s3.AccessKey, "s3-access-key", "", "Specify S3 access key")
+	flags.StringVar(&options.s3SkFile, "s3-secret-key-file", "", "Specify the file which contains S3 secret key")
 
 	return cmd
 }
 
+// the secret key is read from file, environment or prompt
+func readSupportS3SecretKey(options *supportOptions) error {
+	if len(options.s3.Endpoint) == 0 {
+		return nil
+	}
+	secretKey, err := cli.ReadSecret(cli.Secret{
+		Name: "S3 secret key",
+		File: options.s3SkFile,
+		Env:  ENV_SUPPORT_S3_SECRET_KEY,
+	})
+	options.s3.SecretKey = secretKey
+	return err
+}
+
+func checkSupportOptions(options supportOptions) error {
+	s3 := options.s3
+	if len(options.since) > 0 {
+		since, err := time.ParseDuration(options.since)
+		if err != nil || since <= 0 {
+			return errno.ERR_INVALID_LOGS_SINCE.
+				F("since: %s", options.since)
+		}
+	}
+	if len(s3.Endpoint) > 0 && len(options.uploadURL) > 0 {
+		return errno.ERR_UPLOAD_URL_CONFLICT_WITH_S3
+	} else if len(s3.Endpoint) > 0 &&
+		(len(s3.Bucket) == 0 || len(s3.AccessKey) == 0 || len(s3.SecretKey) == 0) {
+		return errno.ERR_INCOMPLETE_S3_OPTIONS
+	}
+	return nil
+}
+
 func getClients(curveadm *cli.CurveAdm,
 	options supportOptions) ([]storage.Client, error) {
 	out := []storage.Client{}
@@ -88,6 +164,54 @@ func getClients(curveadm *cli.CurveAdm,
 	return out, nil
 }
 
+// the core directory may be shared by services in the same host,
+// we only let the first service collect cores for each directory
+func getCoreCollectors(dcs []*topology.DeployConfig) map[string]bool {
+	collectors := map[string]bool{}
+	seen := map[string]bool{}
+	for _, dc := range dcs {
+		key := fmt.Sprintf("%s:%s", dc.GetHost(), dc.GetCoreDir())
+		if len(dc.GetCoreDir()) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		collectors[dc.GetId()] = true
+	}
+	return collectors
+}
+
+func genSupportOptions(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options supportOptions) (task.SupportOptions, error) {
+	// curve-support-my-cluster-20231018194017
+	name := fmt.Sprintf("curve-support-%s-%s",
+		curveadm.ClusterName(), time.Now().Format("20060102150405"))
+	output, err := filepath.Abs(options.output)
+	if err == nil {
+		err = os.MkdirAll(output, 0755)
+	}
+	if err != nil {
+		return task.SupportOptions{}, errno.ERR_CREATE_DIRECTORY_FAILED.E(err)
+	}
+	supportOptions := task.SupportOptions{
+		BundleDir:      path.Join(curveadm.TempDir(), name),
+		Archive:        path.Join(output, name+".tar.gz"),
+		IncludeCores:   options.includeCores,
+		IncludeConfigs: options.includeConfigs,
+		CoreCollectors: getCoreCollectors(dcs),
+		UploadURL:      options.uploadURL,
+		S3:             options.s3,
+	}
+	if len(options.since) > 0 {
+		supportOptions.Since, _ = time.ParseDuration(options.since)
+	}
+	if options.encrypt {
+		supportOptions.Secret = utils.RandString(32)
+		supportOptions.Archive += ".encrypted"
+	}
+	return supportOptions, nil
+}
+
 func genSupportPlaybook(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
 	options supportOptions) (*playbook.Playbook, error) {
@@ -105,14 +229,13 @@ func genSupportPlaybook(curveadm *cli.CurveAdm,
 	if len(clients) > 0 {
 		steps = append(steps, playbook.COLLECT_CLIENT)
 	}
+	steps = append(steps, playbook.PACK_SUPPORT_BUNDLE)
 	for _, step := range steps {
 		config := dcs
 		switch step {
-		case playbook.INIT_SUPPORT:
-			config = config[:1]
-		case playbook.COLLECT_REPORT:
-			config = config[:1]
-		case playbook.COLLECT_CURVEADM:
+		case playbook.INIT_SUPPORT,
+			playbook.COLLECT_CURVEADM,
+			playbook.PACK_SUPPORT_BUNDLE:
 			config = config[:1]
 		}
 
@@ -131,6 +254,20 @@ func genSupportPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+func displaySupportBundle(curveadm *cli.CurveAdm, supportOptions task.SupportOptions) {
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Support bundle saved to '%s'", supportOptions.Archive))
+	if len(supportOptions.UploadURL) > 0 {
+		curveadm.WriteOutln(color.GreenString("Support bundle uploaded to '%s'", supportOptions.UploadURL))
+	} else if len(supportOptions.S3.Endpoint) > 0 {
+		curveadm.WriteOutln(color.GreenString("Support bundle uploaded to S3 bucket '%s'", supportOptions.S3.Bucket))
+	}
+	if len(supportOptions.Secret) > 0 {
+		curveadm.WriteOut(color.GreenString("secret key: "))
+		curveadm.WriteOutln(color.YellowString(supportOptions.Secret))
+	}
+}
+
 func runSupport(curveadm *cli.CurveAdm, options supportOptions) error {
 	// 1) parse cluster topology
 	dcs, err := curveadm.ParseTopology()
@@ -138,33 +275,42 @@ func runSupport(curveadm *cli.CurveAdm, options supportOptions) error {
 		return err
 	}
 
-	// 2) generate secret
-	secret := utils.RandString(32)
-	curveadm.MemStorage().Set(comm.KEY_SECRET, secret)
+	// 2) filter service
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   "*",
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) generate support options
+	supportOptions, err := genSupportOptions(curveadm, dcs, options)
+	if err != nil {
+		return err
+	}
+	curveadm.MemStorage().Set(comm.KEY_SUPPORT_OPTIONS, supportOptions)
 	curveadm.MemStorage().Set(comm.KEY_ALL_CLIENT_IDS, options.ids)
-	curveadm.MemStorage().Set(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT, SUPPORT_UPLOAD_URL_FORMAT)
 
-	// 3) generate support playbook
+	// 4) generate support playbook
 	pb, err := genSupportPlaybook(curveadm, dcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 4) confirm by user
+	// 5) confirm by user
 	if pass := tui.ConfirmYes(tui.PromptCollectService()); !pass {
 		return nil
 	}
 
-	// 5) run playbook
+	// 6) run playbook
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 6) print secret
-	curveadm.WriteOutln("")
-	curveadm.WriteOutln(color.GreenString("Upload success, please tell the below secret key to curve team :)"))
-	curveadm.WriteOut(color.GreenString("secret key: "))
-	curveadm.WriteOutln(color.YellowString(secret))
+	// 7) print support bundle
+	displaySupportBundle(curveadm, supportOptions)
 	return nil
 }
@@ -23,6 +23,10 @@
 package target
 
 import (
+	"net"
+	"regexp"
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/cli/command/client"
@@ -44,17 +48,99 @@ var (
 	}
 )
 
+const (
+	MIN_CHAP_PASSWORD_LENGTH = 12
+
+	ENV_CHAP_PASSWORD        = "CURVEADM_CHAP_PASSWORD"
+	ENV_CHAP_MUTUAL_PASSWORD = "CURVEADM_CHAP_MUTUAL_PASSWORD"
+)
+
+var (
+	INITIATOR_NAME_REGEX = regexp.MustCompile(`^iqn\.[0-9]{4}-[0-9]{2}\.[^\s:]+(:[^\s]+)?$`)
+)
+
 type addOptions struct {
-	image     string
-	host      string
-	size      string
-	create    bool
-	filename  string
-	blocksize string
+	image                  string
+	host                   string
+	size                   string
+	create                 bool
+	filename               string
+	blocksize              string
+	persistent             bool
+	chapUser               string
+	chapPassword           string
+	chapPasswordFile       string
+	chapMutualUser         string
+	chapMutualPassword     string
+	chapMutualPasswordFile string
+	initiatorAddresses     []string
+	initiatorNames         []string
+}
+
+func checkCHAP(user, password string) error {
+	if len(user) == 0 && len(password) == 0 {
+		return nil
+	} else if len(user) == 0 || len(password) == 0 {
+		return errno.ERR_CHAP_REQUIRES_USER_AND_PASSWORD
+	} else if strings.ContainsAny(user+password, " \t\n'\"") {
+		return errno.ERR_INVALID_CHAP_CREDENTIALS
+	} else if len(password) < MIN_CHAP_PASSWORD_LENGTH {
+		return errno.ERR_CHAP_PASSWORD_TOO_SHORT.
+			F("user: %s", user)
+	}
+	return nil
+}
+
+func readCHAPPassword(user, name, file, env string) (string, error) {
+	if len(user) == 0 && len(file) == 0 {
+		return "", nil
+	}
+	return cli.ReadSecret(cli.Secret{Name: name, File: file, Env: env})
+}
+
+// the CHAP passwords are read from file, environment or prompt
+func readCHAPPasswords(options *addOptions) error {
+	var err error
+	options.chapPassword, err = readCHAPPassword(options.chapUser,
+		"CHAP password", options.chapPasswordFile, ENV_CHAP_PASSWORD)
+	if err != nil {
+		return err
+	}
+	options.chapMutualPassword, err = readCHAPPassword(options.chapMutualUser,
+		"mutual CHAP password", options.chapMutualPasswordFile, ENV_CHAP_MUTUAL_PASSWORD)
+	return err
+}
+
+func checkTargetAuth(options addOptions) error {
+	if err := checkCHAP(options.chapUser, options.chapPassword); err != nil {
+		return err
+	} else if err := checkCHAP(options.chapMutualUser, options.chapMutualPassword); err != nil {
+		return err
+	} else if len(options.chapMutualUser) > 0 && len(options.chapUser) == 0 {
+		return errno.ERR_MUTUAL_CHAP_REQUIRES_INCOMING_CHAP
+	}
+
+	for _, address := range options.initiatorAddresses {
+		if net.ParseIP(address) != nil {
+			continue
+		} else if _, _, err := net.ParseCIDR(address); err != nil {
+			return errno.ERR_INVALID_INITIATOR_ADDRESS.
+				F("address: %s", address)
+		}
+	}
+	for _, name := range options.initiatorNames {
+		if !INITIATOR_NAME_REGEX.MatchString(name) {
+			return errno.ERR_INVALID_INITIATOR_NAME.
+				F("name: %s", name)
+		}
+	}
+	return nil
 }
 
 func checkAddOptions(curveadm *cli.CurveAdm, options addOptions) error {
-	if _, _, err := client.ParseImage(options.image); err != nil {
+	if err := checkTargetAuth(options); err != nil {
+		return err
+	} else if _, _, err := client.ParseImage(options.image); err != nil {
 		return err
 	} else if _, err = client.ParseSize(options.size); err != nil {
 		return err
@@ -76,6 +162,9 @@ func NewAddCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		Args:  cliutil.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			options.image = args[0]
+			if err := readCHAPPasswords(&options); err != nil {
+				return err
+			}
 			return checkAddOptions(curveadm, options)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -91,6 +180,13 @@ func NewAddCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.blocksize, "blocksize", "4096B", "Specify volume blocksize")
+	flags.BoolVar(&options.persistent, "persistent", false, "Restore target after host reboot")
+	flags.StringVar(&options.chapUser, "chap-user", "", "Specify CHAP user which initiator login with")
+	flags.StringVar(&options.chapPasswordFile, "chap-password-file", "", "Specify the file which contains CHAP password which initiator login with")
+	flags.StringVar(&options.chapMutualUser, "chap-mutual-user", "", "Specify mutual CHAP user which target authenticate with")
+	flags.StringVar(&options.chapMutualPasswordFile, "chap-mutual-password-file", "", "Specify the file which contains mutual CHAP password which target authenticate with")
+	flags.StringSliceVar(&options.initiatorAddresses, "initiator-address", []string{}, "Specify initiator IP or CIDR which allowed to login (default all)")
+	flags.StringSliceVar(&options.initiatorNames, "initiator-name", []string{}, "Specify initiator IQN which allowed to login (default all)")
 	return cmd
 }
 
@@ -108,12 +204,21 @@ func genAddPlaybook(curveadm *cli.CurveAdm,
 			Configs: ccs,
 			Options: map[string]interface{}{
 				comm.KEY_TARGET_OPTIONS: bs.TargetOption{
-					Host:      options.host,
-					User:      user,
-					Volume:    name,
-					Size:      size,
-					Blocksize: blocksize,
-					Create:    options.create,
+					Host:       options.host,
+					User:       user,
+					Volume:     name,
+					Size:       size,
+					Blocksize:  blocksize,
+					Create:     options.create,
+					Persistent: options.persistent,
+					TargetAuth: bs.TargetAuth{
+						IncomingUser:       options.chapUser,
+						IncomingPassword:   options.chapPassword,
+						OutgoingUser:       options.chapMutualUser,
+						OutgoingPassword:   options.chapMutualPassword,
+						InitiatorAddresses: options.initiatorAddresses,
+						InitiatorNames:     options.initiatorNames,
+					},
 				},
 			},
 		})
@@ -0,0 +1,200 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	TRACE_EXAMPLE = `Examples:
+  $ curveadm trace --id 6ff561598c6f                               # Sample on-CPU stacks of service for 30 seconds by perf
+  $ curveadm trace --id 6ff561598c6f --tool offcpu --duration 1m   # Sample off-CPU stacks of service for 1 minute
+  $ curveadm trace --id 5d5b3e1b0a1c --tool bt                     # Trace fuse operations of curvefs client by bpftrace
+  $ curveadm trace --id 6ff561598c6f --image perf:latest -o /tmp   # Run tracing tools in specified image and save result to /tmp`
+)
+
+type traceOptions struct {
+	id       string
+	tool     string
+	duration time.Duration
+	image    string
+	output   string
+}
+
+func NewTraceCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options traceOptions
+
+	cmd := &cobra.Command{
+		Use:     "trace [OPTIONS]",
+		Short:   "Trace service or client process and collect flame graph",
+		Args:    cliutil.NoArgs,
+		Example: TRACE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrace(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "", "Specify service id or client id")
+	flags.StringVar(&options.tool, "tool", task.TRACE_TOOL_PERF, "Specify trace tool (bt/perf/offcpu)")
+	flags.DurationVar(&options.duration, "duration", 30*time.Second, "Specify trace duration")
+	flags.StringVar(&options.image, "image", "", "Specify image which tracing tools installed in (default: the image of traced container)")
+	flags.StringVarP(&options.output, "output", "o", ".", "Specify directory to save trace result")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func checkTraceOptions(options traceOptions) error {
+	if !cliutil.Slice2Map(task.TRACE_TOOLS)[options.tool] {
+		return errno.ERR_UNSUPPORTED_TRACE_TOOL.
+			F("tool: %s", options.tool)
+	} else if options.duration < time.Second {
+		return errno.ERR_INVALID_TRACE_DURATION.
+			F("duration: %s", options.duration)
+	}
+	return nil
+}
+
+// locate the traced process by service id first, then by client id
+func locateTraceTarget(curveadm *cli.CurveAdm, id string) (task.TraceOptions, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return task.TraceOptions{}, err
+	}
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   id,
+		Role: "*",
+		Host: "*",
+	})
+	if len(dcs) > 0 {
+		dc := dcs[0]
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		containerId, err := curveadm.GetContainerId(serviceId)
+		if err != nil {
+			return task.TraceOptions{}, err
+		} else if len(containerId) == 0 || containerId == comm.CLEANED_CONTAINER_ID {
+			return task.TraceOptions{}, errno.ERR_SERVICE_CONTAINER_ID_NOT_FOUND.
+				F("id: %s", id)
+		}
+		return task.TraceOptions{
+			Id:          id,
+			Host:        dc.GetHost(),
+			ContainerId: containerId,
+			Binary:      task.ServiceBinary(dc),
+		}, nil
+	}
+
+	clients, err := curveadm.Storage().GetClient(id)
+	if err != nil {
+		return task.TraceOptions{}, err
+	} else if len(clients) != 1 {
+		return task.TraceOptions{}, errno.ERR_NO_SERVICE_OR_CLIENT_MATCHED.
+			F("id: %s", id)
+	}
+	client := clients[0]
+	return task.TraceOptions{
+		Id:          id,
+		Host:        client.Host,
+		ContainerId: client.ContainerId,
+		Binary:      task.ClientBinary(client.Kind),
+	}, nil
+}
+
+func genTraceOptions(curveadm *cli.CurveAdm, options traceOptions) (task.TraceOptions, error) {
+	err := checkTraceOptions(options)
+	if err != nil {
+		return task.TraceOptions{}, err
+	}
+
+	traceOptions, err := locateTraceTarget(curveadm, options.id)
+	if err != nil {
+		return traceOptions, err
+	} else if options.tool == task.TRACE_TOOL_BT && traceOptions.Binary != task.CLIENT_FUSE_BINARY {
+		return traceOptions, errno.ERR_UNSUPPORTED_TRACE_TOOL.
+			F("tool 'bt' only supports curvefs client, but %s is running %s",
+				options.id, path.Base(traceOptions.Binary))
+	}
+
+	output, err := filepath.Abs(options.output)
+	if err == nil {
+		err = os.MkdirAll(output, 0755)
+	}
+	if err != nil {
+		return traceOptions, errno.ERR_CREATE_DIRECTORY_FAILED.E(err)
+	}
+
+	// trace-6ff561598c6f-perf-20231022153012
+	traceOptions.Tool = options.tool
+	traceOptions.Duration = options.duration
+	traceOptions.Image = options.image
+	traceOptions.Output = output
+	traceOptions.Name = fmt.Sprintf("trace-%s-%s-%s",
+		options.id, options.tool, time.Now().Format("20060102150405"))
+	return traceOptions, nil
+}
+
+func runTrace(curveadm *cli.CurveAdm, options traceOptions) error {
+	// 1) generate trace options
+	traceOptions, err := genTraceOptions(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) generate trace playbook
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.TRACE_PROCESS,
+		Configs: nil,
+		Options: map[string]interface{}{
+			comm.KEY_TRACE_OPTIONS: traceOptions,
+		},
+	})
+
+	// 3) run playbook
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) print where trace result saved
+	curveadm.WriteOutln(color.GreenString("Trace result saved to %s",
+		path.Join(traceOptions.Output, traceOptions.Name)))
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TRACE_HOSTS = `
+hosts:
+  - host: server-host
+    hostname: 10.0.0.1
+    private_key_file: %s
+`
+
+	TRACE_TOPOLOGY = `
+kind: curvefs
+global:
+  container_image: opencurvedocker/curvefs:latest
+  data_dir: /tmp/curvefs/data/${service_role}${service_host_sequence}
+  log_dir: /tmp/curvefs/logs/${service_role}${service_host_sequence}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380${service_host_sequence}
+    listen.client_port: 2379${service_host_sequence}
+  deploy:
+    - host: server-host
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 670${service_host_sequence}
+    listen.dummy_port: 770${service_host_sequence}
+  deploy:
+    - host: server-host
+
+metaserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 680${service_host_sequence}
+    listen.external_port: 780${service_host_sequence}
+  deploy:
+    - host: server-host
+`
+)
+
+// newTraceCurveAdm returns curveadm whose home is a temporary directory,
+// the current cluster has one service of each role and one client
+func newTraceCurveAdm(t *testing.T) *cli.CurveAdm {
+	assert := assert.New(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	keyFile := path.Join(home, "id_rsa")
+	assert.Nil(os.WriteFile(keyFile, []byte("PRIVATE KEY"), 0600))
+
+	curveadm, err := cli.NewCurveAdm()
+	assert.Nil(err)
+	s := curveadm.Storage()
+	assert.Nil(s.SetHosts(fmt.Sprintf(TRACE_HOSTS, keyFile)))
+	assert.Nil(s.InsertCluster("test", "", TRACE_TOPOLOGY))
+	assert.Nil(s.CheckoutCluster("test"))
+	assert.Nil(s.InsertClient("5d5b3e1b0a1c", "curvefs", "client-host", "8e2f0c1d7b3a", ""))
+
+	// reload current cluster and hosts
+	curveadm, err = cli.NewCurveAdm()
+	assert.Nil(err)
+	return curveadm
+}
+
+func TestCheckTraceOptions(t *testing.T) {
+	assert := assert.New(t)
+	options := traceOptions{tool: task.TRACE_TOOL_PERF, duration: 30 * time.Second}
+	assert.Nil(checkTraceOptions(options))
+
+	options.tool = task.TRACE_TOOL_OFFCPU
+	options.duration = time.Second
+	assert.Nil(checkTraceOptions(options))
+
+	options.tool = "strace"
+	assert.ErrorIs(checkTraceOptions(options), errno.ERR_UNSUPPORTED_TRACE_TOOL)
+
+	options.tool = task.TRACE_TOOL_BT
+	options.duration = 500 * time.Millisecond
+	assert.ErrorIs(checkTraceOptions(options), errno.ERR_INVALID_TRACE_DURATION)
+}
+
+func TestLocateTraceTarget(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := newTraceCurveAdm(t)
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(err)
+	assert.Len(dcs, 3)
+
+	// service which has container
+	dc := dcs[1]
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	assert.Nil(curveadm.Storage().InsertService(curveadm.ClusterId(), serviceId, "3a2a3e0a8a7e"))
+	options, err := locateTraceTarget(curveadm, serviceId)
+	assert.Nil(err)
+	assert.Equal("server-host", options.Host)
+	assert.Equal("3a2a3e0a8a7e", options.ContainerId)
+	assert.Equal(task.ServiceBinary(dc), options.Binary)
+
+	// service which not deployed yet
+	serviceId = curveadm.GetServiceId(dcs[2].GetId())
+	_, err = locateTraceTarget(curveadm, serviceId)
+	assert.ErrorIs(err, errno.ERR_SERVICE_CONTAINER_ID_NOT_FOUND)
+
+	// client
+	options, err = locateTraceTarget(curveadm, "5d5b3e1b0a1c")
+	assert.Nil(err)
+	assert.Equal("client-host", options.Host)
+	assert.Equal("8e2f0c1d7b3a", options.ContainerId)
+	assert.Equal(task.CLIENT_FUSE_BINARY, options.Binary)
+
+	// neither service nor client
+	_, err = locateTraceTarget(curveadm, "000000000000")
+	assert.ErrorIs(err, errno.ERR_NO_SERVICE_OR_CLIENT_MATCHED)
+}
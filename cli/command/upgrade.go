@@ -25,12 +25,16 @@
 package command
 
 import (
+	"os"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/bundle"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -38,10 +42,13 @@ import (
 
 var (
 	UPGRADE_PLAYBOOK_STEPS = []int{
+		// pull and verify image before stopping service, so the service
+		// keeps running if the image is unavailable or mismatched
+		playbook.PULL_IMAGE,
+		playbook.VERIFY_IMAGE_DIGEST,
 		// TODO(P0): we can skip it for upgrade one service more than once
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE,
-		playbook.PULL_IMAGE,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SERVICE,
@@ -49,10 +56,11 @@ var (
 )
 
 type upgradeOptions struct {
-	id    string
-	role  string
-	host  string
-	force bool
+	id     string
+	role   string
+	host   string
+	force  bool
+	bundle string
 }
 
 func NewUpgradeCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -76,6 +84,7 @@ func NewUpgradeCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
 	flags.StringVar(&options.host, "host", "*", "Specify service host")
 	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	flags.StringVar(&options.bundle, "bundle", "", "Distribute images from offline bundle instead of pulling them")
 
 	return cmd
 }
@@ -95,9 +104,18 @@ func genUpgradePlaybook(curveadm *cli.CurveAdm,
 	steps := UPGRADE_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
+		// images have been loaded from bundle before upgrading
+		if step == playbook.PULL_IMAGE && len(options.bundle) > 0 {
+			continue
+		}
+
+		configs := dcs
+		if step == playbook.VERIFY_IMAGE_DIGEST {
+			configs = dcs[:1]
+		}
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
-			Configs: dcs,
+			Configs: configs,
 			Options: map[string]interface{}{
 				comm.KEY_CLEAN_ITEMS:      []string{comm.CLEAN_ITEM_CONTAINER},
 				comm.KEY_CLEAN_BY_RECYCLE: true,
@@ -107,6 +125,23 @@ func genUpgradePlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// loadBundleImages loads images of all services to upgrade from bundle once,
+// instead of unpacking the bundle for each service
+func loadBundleImages(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options upgradeOptions) error {
+	bo, err := bundle.NewLoadOptions(curveadm, options.bundle, task.TopologyImages(dcs))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(bo.Dir)
+
+	pb := playbook.NewPlaybook(curveadm)
+	err = bundle.AddLoadSteps(curveadm, pb, deployHosts(dcs), bo)
+	if err != nil {
+		return err
+	}
+	return pb.Run()
+}
+
 func displayTitle(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options upgradeOptions) {
 	total := len(dcs)
 	if options.force {
@@ -197,11 +232,19 @@ func runUpgrade(curveadm *cli.CurveAdm, options upgradeOptions) error {
 		return errno.ERR_NO_SERVICES_MATCHED
 	}
 
-	// 3.1) upgrade service at once
+	// 3) load images from bundle
+	if len(options.bundle) > 0 {
+		err = loadBundleImages(curveadm, dcs, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4.1) upgrade service at once
 	if options.force {
 		return upgradeAtOnce(curveadm, dcs, options)
 	}
 
-	// 3.2) OR upgrade service one by one
+	// 4.2) OR upgrade service one by one
 	return upgradeOneByOne(curveadm, dcs, options)
 }
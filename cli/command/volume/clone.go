@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CLONE_EXAMPLE = `Examples:
+  $ curveadm volume clone create /test /test-clone --user curve         # Clone volume /test to /test-clone
+  $ curveadm volume clone create <UUID> /test-clone --user curve --lazy # Clone from snapshot lazily
+  $ curveadm volume clone ls --user curve                               # List all clone tasks of user curve
+  $ curveadm volume clone flatten <UUID> --user curve                   # Flatten lazy cloned volume`
+)
+
+type cloneOptions struct {
+	user        string
+	source      string
+	destination string
+	uuid        string
+	lazy        bool
+}
+
+func NewCloneCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clone",
+		Short:   "Manage volume clones",
+		Args:    cliutil.NoArgs,
+		Example: CLONE_EXAMPLE,
+		RunE:    cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		newCreateCloneCommand(curveadm),
+		newListClonesCommand(curveadm),
+		newFlattenCloneCommand(curveadm),
+	)
+	return cmd
+}
+
+func newCreateCloneCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneOptions
+
+	cmd := &cobra.Command{
+		Use:   "create SOURCE DESTINATION [OPTIONS]",
+		Short: "Clone volume from volume or snapshot",
+		Args:  cliutil.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.source = args[0]
+			options.destination = args[1]
+			if !strings.HasPrefix(options.destination, "/") {
+				return errno.ERR_VOLUME_NAME_MUST_START_WITH_SLASH_PREFIX.
+					F("volume name: %s", options.destination)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreateClone(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.user, "user", "", "Specify the volume user")
+	flags.BoolVar(&options.lazy, "lazy", false, "Clone lazily, the volume is available before data copied")
+	cmd.MarkFlagRequired("user")
+
+	return cmd
+}
+
+func newListClonesCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List clone tasks",
+		Args:    cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runListClones(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.user, "user", "", "Specify the volume user")
+	cmd.MarkFlagRequired("user")
+
+	return cmd
+}
+
+func newFlattenCloneCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneOptions
+
+	cmd := &cobra.Command{
+		Use:   "flatten UUID [OPTIONS]",
+		Short: "Flatten lazy cloned volume",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.uuid = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFlattenClone(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.user, "user", "", "Specify the volume user")
+	cmd.MarkFlagRequired("user")
+
+	return cmd
+}
+
+func runCreateClone(curveadm *cli.CurveAdm, options cloneOptions) error {
+	response, err := requestSnapshotClone(curveadm, bs.SnapshotCloneOptions{
+		Action:      bs.SNAPSHOT_CLONE_ACTION_CLONE,
+		User:        options.user,
+		Source:      options.source,
+		Destination: options.destination,
+		Lazy:        options.lazy,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Cloning '%s' to '%s', task uuid: %s",
+		options.source, options.destination, response.UUID)
+	return nil
+}
+
+func runListClones(curveadm *cli.CurveAdm, options cloneOptions) error {
+	response, err := requestSnapshotClone(curveadm, bs.SnapshotCloneOptions{
+		Action: bs.SNAPSHOT_CLONE_ACTION_LIST_CLONES,
+		User:   options.user,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatCloneTasks(response.TaskInfos))
+	return nil
+}
+
+func runFlattenClone(curveadm *cli.CurveAdm, options cloneOptions) error {
+	_, err := requestSnapshotClone(curveadm, bs.SnapshotCloneOptions{
+		Action: bs.SNAPSHOT_CLONE_ACTION_FLATTEN,
+		User:   options.user,
+		UUID:   options.uuid,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Flattening clone task '%s'", options.uuid)
+	return nil
+}
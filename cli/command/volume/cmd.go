@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ENV_VOLUME_PASSWORD = "CURVEADM_VOLUME_PASSWORD"
+)
+
+func NewVolumeCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volume",
+		Short: "Manage volumes of CurveBS",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewListCommand(curveadm),
+		NewCreateCommand(curveadm),
+		NewExtendCommand(curveadm),
+		NewDeleteCommand(curveadm),
+		NewRestoreCommand(curveadm),
+		NewSnapshotCommand(curveadm),
+		NewCloneCommand(curveadm),
+	)
+	return cmd
+}
+
+// all volume operations are executed in the first service of specified role
+func getServiceConfig(curveadm *cli.CurveAdm, role string) (*topology.DeployConfig, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	} else if len(dcs) == 0 || dcs[0].GetKind() != topology.KIND_CURVEBS {
+		return nil, errno.ERR_VOLUME_REQUIRE_CURVEBS_CLUSTER
+	}
+
+	dcs = curveadm.FilterDeployConfigByRole(dcs, role)
+	if len(dcs) == 0 && role == topology.ROLE_SNAPSHOTCLONE {
+		return nil, errno.ERR_NO_SNAPSHOTCLONE_SERVICE
+	} else if len(dcs) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+	return dcs[0], nil
+}
+
+func runPlaybook(curveadm *cli.CurveAdm, step int, dc *topology.DeployConfig,
+	options map[string]interface{}) error {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    step,
+		Configs: dc,
+		Options: options,
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar: true,
+		},
+	})
+	return pb.Run()
+}
+
+// the password of volume user is read from file, environment variable or prompt
+func manageVolume(curveadm *cli.CurveAdm, options bs.VolumeOptions, passwordFile string) error {
+	dc, err := getServiceConfig(curveadm, topology.ROLE_MDS)
+	if err != nil {
+		return err
+	}
+
+	options.Password, err = cli.ReadSecret(cli.Secret{
+		Name: fmt.Sprintf("password of volume user '%s'", options.User),
+		File: passwordFile,
+		Env:  ENV_VOLUME_PASSWORD,
+	})
+	if err != nil {
+		return err
+	}
+	return runPlaybook(curveadm, playbook.MANAGE_VOLUME, dc, map[string]interface{}{
+		comm.KEY_VOLUME_OPTIONS: options,
+	})
+}
+
+func requestSnapshotClone(curveadm *cli.CurveAdm,
+	options bs.SnapshotCloneOptions) (*bs.SnapshotCloneResponse, error) {
+	dc, err := getServiceConfig(curveadm, topology.ROLE_SNAPSHOTCLONE)
+	if err != nil {
+		return nil, err
+	}
+	err = runPlaybook(curveadm, playbook.REQUEST_SNAPSHOT_CLONE, dc, map[string]interface{}{
+		comm.KEY_SNAPSHOT_CLONE_OPTIONS: options,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return curveadm.MemStorage().Get(comm.KEY_SNAPSHOT_CLONE_RESPONSE).(*bs.SnapshotCloneResponse), nil
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CREATE_EXAMPLE = `Examples:
+  $ curveadm volume create curve:/test --size 20GiB              # Create volume /test which size is 20GiB for user curve
+  $ curveadm volume create curve:/test --poolset ssd             # Create volume /test in poolset ssd`
+)
+
+type createOptions struct {
+	image        string
+	size         string
+	poolset      string
+	passwordFile string
+}
+
+func NewCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options createOptions
+
+	cmd := &cobra.Command{
+		Use:     "create USER:VOLUME [OPTIONS]",
+		Short:   "Create volume",
+		Args:    cliutil.ExactArgs(1),
+		Example: CREATE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return checkCreateOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
+	flags.StringVar(&options.poolset, "poolset", "", "Specify the poolset")
+	flags.StringVar(&options.passwordFile, "password-file", "", "Specify the file which contains the password of volume user")
+
+	return cmd
+}
+
+func checkCreateOptions(options createOptions) error {
+	if _, _, err := client.ParseImage(options.image); err != nil {
+		return err
+	} else if _, err = client.ParseSize(options.size); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runCreate(curveadm *cli.CurveAdm, options createOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	size, _ := client.ParseSize(options.size)
+	err := manageVolume(curveadm, bs.VolumeOptions{
+		Action:  bs.VOLUME_ACTION_CREATE,
+		User:    user,
+		Volume:  name,
+		Size:    size,
+		Poolset: options.poolset,
+	}, options.passwordFile)
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Created volume '%s'", options.image)
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DELETE_EXAMPLE = `Examples:
+  $ curveadm volume rm curve:/test          # Move volume /test into recycle bin
+  $ curveadm volume rm curve:/test --force  # Delete volume /test permanently`
+)
+
+type deleteOptions struct {
+	image        string
+	passwordFile string
+	force        bool
+	yes          bool
+}
+
+func NewDeleteCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options deleteOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm USER:VOLUME [OPTIONS]",
+		Aliases: []string{"delete"},
+		Short:   "Delete volume",
+		Args:    cliutil.ExactArgs(1),
+		Example: DELETE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.passwordFile, "password-file", "", "Specify the file which contains the password of volume user")
+	flags.BoolVarP(&options.force, "force", "f", false, "Delete volume permanently instead of moving it into recycle bin")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Skip confirmation")
+
+	return cmd
+}
+
+func runDelete(curveadm *cli.CurveAdm, options deleteOptions) error {
+	// 1) confirm by user
+	if !options.yes {
+		if pass := tui.ConfirmYes(tui.PromptDeleteVolume(options.image, options.force)); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("delete volume"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 2) delete volume
+	user, name, _ := client.ParseImage(options.image)
+	err := manageVolume(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_DELETE,
+		User:   user,
+		Volume: name,
+		Force:  options.force,
+	}, options.passwordFile)
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Deleted volume '%s'", options.image)
+	return nil
+}
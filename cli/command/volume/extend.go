@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	EXTEND_EXAMPLE = `Examples:
+  $ curveadm volume extend curve:/test --size 30GiB  # Extend volume /test to 30GiB`
+)
+
+type extendOptions struct {
+	image        string
+	size         string
+	passwordFile string
+}
+
+func NewExtendCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options extendOptions
+
+	cmd := &cobra.Command{
+		Use:     "extend USER:VOLUME [OPTIONS]",
+		Aliases: []string{"resize"},
+		Short:   "Extend volume size",
+		Args:    cliutil.ExactArgs(1),
+		Example: EXTEND_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return checkExtendOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExtend(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.size, "size", "", "Specify the new volume size")
+	flags.StringVar(&options.passwordFile, "password-file", "", "Specify the file which contains the password of volume user")
+	cmd.MarkFlagRequired("size")
+
+	return cmd
+}
+
+func checkExtendOptions(options extendOptions) error {
+	if _, _, err := client.ParseImage(options.image); err != nil {
+		return err
+	} else if _, err = client.ParseSize(options.size); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runExtend(curveadm *cli.CurveAdm, options extendOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	size, _ := client.ParseSize(options.size)
+	err := manageVolume(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_EXTEND,
+		User:   user,
+		Volume: name,
+		Size:   size,
+	}, options.passwordFile)
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Extended volume '%s' to %s", options.image, options.size)
+	return nil
+}
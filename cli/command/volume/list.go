@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LIST_EXAMPLE = `Examples:
+  $ curveadm volume ls                        # List all volumes
+  $ curveadm volume ls --dir /test            # List volumes under directory /test
+  $ curveadm volume ls --recycle              # List volumes in recycle bin
+  $ curveadm volume ls --password-file FILE   # List volumes with the password of root user in file`
+)
+
+type listOptions struct {
+	dir          string
+	user         string
+	passwordFile string
+	recycle      bool
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List volumes",
+		Args:    cliutil.NoArgs,
+		Example: LIST_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkListOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.dir, "dir", "/", "Specify the directory to list")
+	flags.StringVar(&options.user, "user", "root", "Specify the user to list volumes")
+	flags.StringVar(&options.passwordFile, "password-file", "", "Specify the file which contains the password of volume user")
+	flags.BoolVar(&options.recycle, "recycle", false, "List volumes in recycle bin")
+
+	return cmd
+}
+
+func checkListOptions(options listOptions) error {
+	if !strings.HasPrefix(options.dir, "/") {
+		return errno.ERR_VOLUME_NAME_MUST_START_WITH_SLASH_PREFIX.
+			F("directory: %s", options.dir)
+	}
+	return nil
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) list volumes in mds container
+	dir := options.dir
+	if options.recycle {
+		dir = bs.RECYCLE_BIN_DIR
+	}
+	err := manageVolume(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_LIST,
+		User:   options.user,
+		Volume: dir,
+	}, options.passwordFile)
+	if err != nil {
+		return err
+	}
+
+	// 2) print volumes
+	volumes := []bs.Volume{}
+	value := curveadm.MemStorage().Get(comm.KEY_ALL_VOLUMES)
+	if value != nil {
+		volumes = value.([]bs.Volume)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatVolumes(volumes))
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	RESTORE_EXAMPLE = `Examples:
+  $ curveadm volume restore curve:/test  # Restore volume /test from recycle bin`
+)
+
+type restoreOptions struct {
+	image        string
+	passwordFile string
+}
+
+func NewRestoreCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options restoreOptions
+
+	cmd := &cobra.Command{
+		Use:     "restore USER:VOLUME [OPTIONS]",
+		Short:   "Restore volume from recycle bin",
+		Args:    cliutil.ExactArgs(1),
+		Example: RESTORE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestore(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.passwordFile, "password-file", "", "Specify the file which contains the password of volume user")
+
+	return cmd
+}
+
+func runRestore(curveadm *cli.CurveAdm, options restoreOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	err := manageVolume(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_RESTORE,
+		User:   user,
+		Volume: name,
+	}, options.passwordFile)
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Restored volume '%s'", options.image)
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SNAPSHOT_EXAMPLE = `Examples:
+  $ curveadm volume snapshot create curve:/test --name snap1      # Create snapshot 'snap1' for volume /test
+  $ curveadm volume snapshot ls curve:/test                       # List all snapshots of volume /test
+  $ curveadm volume snapshot rm curve:/test --uuid <UUID>         # Delete specified snapshot of volume /test`
+)
+
+type snapshotOptions struct {
+	image string
+	name  string
+	uuid  string
+}
+
+func NewSnapshotCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot",
+		Short:   "Manage volume snapshots",
+		Args:    cliutil.NoArgs,
+		Example: SNAPSHOT_EXAMPLE,
+		RunE:    cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		newCreateSnapshotCommand(curveadm),
+		newListSnapshotsCommand(curveadm),
+		newDeleteSnapshotCommand(curveadm),
+	)
+	return cmd
+}
+
+func newCreateSnapshotCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options snapshotOptions
+
+	cmd := &cobra.Command{
+		Use:   "create USER:VOLUME [OPTIONS]",
+		Short: "Create snapshot for volume",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreateSnapshot(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Specify the snapshot name")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func newListSnapshotsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options snapshotOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls USER:VOLUME",
+		Aliases: []string{"list"},
+		Short:   "List snapshots of volume",
+		Args:    cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runListSnapshots(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func newDeleteSnapshotCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options snapshotOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm USER:VOLUME [OPTIONS]",
+		Aliases: []string{"delete"},
+		Short:   "Delete snapshot of volume",
+		Args:    cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeleteSnapshot(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.uuid, "uuid", "", "Specify the snapshot uuid")
+	cmd.MarkFlagRequired("uuid")
+
+	return cmd
+}
+
+func runCreateSnapshot(curveadm *cli.CurveAdm, options snapshotOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	response, err := requestSnapshotClone(curveadm, bs.SnapshotCloneOptions{
+		Action: bs.SNAPSHOT_CLONE_ACTION_CREATE_SNAPSHOT,
+		User:   user,
+		Volume: name,
+		Name:   options.name,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Created snapshot '%s' for volume '%s', uuid: %s",
+		options.name, options.image, response.UUID)
+	return nil
+}
+
+func runListSnapshots(curveadm *cli.CurveAdm, options snapshotOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	response, err := requestSnapshotClone(curveadm, bs.SnapshotCloneOptions{
+		Action: bs.SNAPSHOT_CLONE_ACTION_LIST_SNAPSHOTS,
+		User:   user,
+		Volume: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatSnapshots(response.Snapshots))
+	return nil
+}
+
+func runDeleteSnapshot(curveadm *cli.CurveAdm, options snapshotOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	_, err := requestSnapshotClone(curveadm, bs.SnapshotCloneOptions{
+		Action: bs.SNAPSHOT_CLONE_ACTION_DELETE_SNAPSHOT,
+		User:   user,
+		Volume: name,
+		UUID:   options.uuid,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("Deleted snapshot '%s' of volume '%s'", options.uuid, options.image)
+	return nil
+}
@@ -22,7 +22,10 @@
 
 package manager
 
-import "github.com/opencurve/pigeon"
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/pigeon"
+)
 
 var METHOD_REQUEST map[string]Request
 
@@ -31,6 +34,7 @@ type (
 
 	Context struct {
 		Data interface{}
+		adm  *cli.CurveAdm // shared by audit and handler in one request
 	}
 
 	Request struct {
@@ -41,6 +45,19 @@ type (
 	}
 )
 
+// CurveAdm returns the curveadm of current request, the audited request
+// reuses the one which inserted the audit log, so all its tasks correlated
+func (ctx *Context) CurveAdm() (*cli.CurveAdm, error) {
+	if ctx.adm == nil {
+		adm, err := cli.NewCurveAdm()
+		if err != nil {
+			return nil, err
+		}
+		ctx.adm = adm
+	}
+	return ctx.adm, nil
+}
+
 func init() {
 	METHOD_REQUEST = map[string]Request{}
 	for _, request := range requests {
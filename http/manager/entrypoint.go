@@ -23,14 +23,65 @@
 package manager
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/mcuadros/go-defaults"
+	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/http/core"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/opencurve/pigeon"
 )
 
+const (
+	HEADER_AUTHORIZATION = "Authorization"
+)
+
+// only the fingerprint of token recorded in audit log
+func tokenFingerprint(authorization string) string {
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	if len(token) == 0 {
+		return ""
+	}
+	return utils.MD5Sum(token)[:8]
+}
+
+// the request which modify cluster (POST) is recorded in audit log like command,
+// e.g. http CommitHost {"hosts":"..."}
+func auditCommand(r *pigeon.Request, ctx *Context) string {
+	command := fmt.Sprintf("http %s", r.Args["method"])
+	bytes, err := json.Marshal(ctx.Data)
+	if err != nil {
+		return command
+	}
+	return fmt.Sprintf("%s %s", command, string(bytes))
+}
+
+func audit(r *pigeon.Request, ctx *Context, handler HandlerFunc) bool {
+	adm, err := ctx.CurveAdm()
+	if err != nil {
+		return newAdmFail(r, err)
+	}
+	adm.SetOperator(cli.Operator{
+		User:   utils.GetCurrentUser(),
+		Client: r.Context.ClientIP(),
+		Token:  tokenFingerprint(r.HeadersIn[HEADER_AUTHORIZATION]),
+	})
+
+	// the request can't be replayed by curveadm, so no argv recorded
+	id := adm.StartAudit(time.Now(), auditCommand(r, ctx), nil)
+	rc := handler(r, ctx)
+	if r.Status != 200 {
+		err = fmt.Errorf("http status: %d", r.Status)
+	}
+	adm.PostAudit(id, err)
+	return rc
+}
+
 func Entrypoint(r *pigeon.Request) bool {
 	if r.Method != pigeon.HTTP_METHOD_GET &&
 		r.Method != pigeon.HTTP_METHOD_POST {
@@ -52,5 +103,9 @@ func Entrypoint(r *pigeon.Request) bool {
 		return core.Exit(r, errno.ERR_BAD_REQUEST_FORM_PARAM)
 	}
 	defaults.SetDefaults(data)
-	return request.handler(r, &Context{data})
+	ctx := &Context{Data: data}
+	if r.Method == pigeon.HTTP_METHOD_POST {
+		return audit(r, ctx, request.handler)
+	}
+	return request.handler(r, ctx)
 }
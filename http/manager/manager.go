@@ -26,7 +26,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/cli/command"
 	"github.com/opencurve/curveadm/cli/command/cluster"
 	"github.com/opencurve/curveadm/cli/command/config"
@@ -65,7 +64,7 @@ func newAdmFail(r *pigeon.Request, err error) bool {
 }
 
 func ListHost(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -79,7 +78,7 @@ func ListHost(r *pigeon.Request, ctx *Context) bool {
 }
 
 func CommitHost(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -93,7 +92,7 @@ func CommitHost(r *pigeon.Request, ctx *Context) bool {
 }
 
 func ListDisk(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -107,7 +106,7 @@ func ListDisk(r *pigeon.Request, ctx *Context) bool {
 }
 
 func CommitDisk(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -121,7 +120,7 @@ func CommitDisk(r *pigeon.Request, ctx *Context) bool {
 }
 
 func GetFormatStatus(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -135,7 +134,7 @@ func GetFormatStatus(r *pigeon.Request, ctx *Context) bool {
 }
 
 func FormatDisk(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -148,7 +147,7 @@ func FormatDisk(r *pigeon.Request, ctx *Context) bool {
 }
 
 func ShowConfig(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -162,7 +161,7 @@ func ShowConfig(r *pigeon.Request, ctx *Context) bool {
 }
 
 func CommitConfig(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -177,7 +176,7 @@ func CommitConfig(r *pigeon.Request, ctx *Context) bool {
 }
 
 func ListCluster(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -191,7 +190,7 @@ func ListCluster(r *pigeon.Request, ctx *Context) bool {
 }
 
 func CheckoutCluster(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -206,7 +205,7 @@ func CheckoutCluster(r *pigeon.Request, ctx *Context) bool {
 }
 
 func AddCluster(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -230,7 +229,7 @@ func AddCluster(r *pigeon.Request, ctx *Context) bool {
 }
 
 func DeployCluster(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
@@ -279,7 +278,7 @@ func getServicesAddrFromConf(dcs []*topology.DeployConfig, mcs []*configure.Moni
 }
 
 func GetClusterServicesAddr(r *pigeon.Request, ctx *Context) bool {
-	adm, err := cli.NewCurveAdm()
+	adm, err := ctx.CurveAdm()
 	if err != nil {
 		return newAdmFail(r, err)
 	}
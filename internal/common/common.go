@@ -67,8 +67,20 @@ const (
 	DISK_FORMAT_CONTAINER_IMAGE = "container_image"
 
 	// format
-	KEY_ALL_FORMAT_STATUS = "ALL_FORMAT_STATUS"
-	FORMAT_INCREMENTAL    = "FORMAT_INCREMENTAL"
+	KEY_ALL_FORMAT_STATUS   = "ALL_FORMAT_STATUS"
+	FORMAT_INCREMENTAL      = "FORMAT_INCREMENTAL"
+	FORMAT_CONCURRENCY      = "FORMAT_CONCURRENCY"
+	FORMAT_HOST_CONCURRENCY = "FORMAT_HOST_CONCURRENCY"
+	FORMAT_BANDWIDTH        = "FORMAT_BANDWIDTH"
+
+	// disks discover
+	KEY_ALL_BLOCK_DEVICES = "ALL_BLOCK_DEVICES"
+
+	// disks replace
+	KEY_REPLACE_CHUNKSERVER        = "REPLACE_CHUNKSERVER"
+	KEY_REPLACE_RETIRE_TIMEOUT     = "REPLACE_RETIRE_TIMEOUT"
+	KEY_REPLACE_FORMAT_TIMEOUT     = "REPLACE_FORMAT_TIMEOUT"
+	KEY_REPLACE_NEW_CHUNKSERVER_ID = "REPLACE_NEW_CHUNKSERVER_ID"
 
 	// check
 	KEY_CHECK_WITH_WEAK          = "CHECK_WITH_WEAK"
@@ -105,6 +117,13 @@ const (
 	KERNERL_MODULE_NBD    = "nbd"
 	KERNERL_MODULE_FUSE   = "fuse"
 
+	// client reconcile
+	KEY_ALL_CLIENT_CONTAINERS   = "ALL_CLIENT_CONTAINERS"
+	CLIENT_RECONCILE_OK         = "OK"
+	CLIENT_RECONCILE_STOPPED    = "Stopped"
+	CLIENT_RECONCILE_STALE      = "Stale"
+	CLIENT_RECONCILE_UNRECORDED = "Unrecorded"
+
 	// polarfs
 	KEY_POLARFS_HOST   = "POLARFS_HOST"
 	KEY_OS_RELEASE     = "OS_RELEASE"
@@ -114,14 +133,46 @@ const (
 	OS_RELEASE_UNKNOWN = "unknown"
 
 	// collect
-	KEY_SUPPORT_UPLOAD_URL_FORMAT = "SUPPORT_UPLOAD_URL"
-	KEY_SECRET                    = "SECRET"
-	KEY_ALL_CLIENT_IDS            = "ALL_CLIENT_IDS"
+	KEY_SUPPORT_OPTIONS = "SUPPORT_OPTIONS"
+	KEY_ALL_CLIENT_IDS  = "ALL_CLIENT_IDS"
+
+	// bundle
+	KEY_BUNDLE_OPTIONS = "BUNDLE_OPTIONS"
+
+	// image digest
+	KEY_RESOLVED_IMAGE_DIGESTS = "RESOLVED_IMAGE_DIGESTS"
+	KEY_PULLED_IMAGE_DIGESTS   = "PULLED_IMAGE_DIGESTS"
+	KEY_ALL_SERVICE_IMAGES     = "ALL_SERVICE_IMAGES"
+	IMAGE_STATUS_MATCHED       = "Matched"
+	IMAGE_STATUS_MISMATCHED    = "Mismatched"
+	IMAGE_STATUS_UNPINNED      = "Unpinned"
+
+	// cores
+	KEY_CORES_OPTIONS  = "CORES_OPTIONS"
+	KEY_ALL_CORE_FILES = "ALL_CORE_FILES"
+	KEY_CORE_BACKTRACE = "CORE_BACKTRACE"
+
+	// trace
+	KEY_TRACE_OPTIONS = "TRACE_OPTIONS"
 
 	// target
 	KEY_TARGET_OPTIONS = "TARGET_OPTIONS"
 	KEY_ALL_TARGETS    = "ALL_TARGETS"
 
+	// export
+	KEY_EXPORT_OPTIONS = "EXPORT_OPTIONS"
+
+	// volume
+	KEY_VOLUME_OPTIONS          = "VOLUME_OPTIONS"
+	KEY_ALL_VOLUMES             = "ALL_VOLUMES"
+	KEY_SNAPSHOT_CLONE_OPTIONS  = "SNAPSHOT_CLONE_OPTIONS"
+	KEY_SNAPSHOT_CLONE_RESPONSE = "SNAPSHOT_CLONE_RESPONSE"
+
+	// filesystem
+	KEY_FILESYSTEM_OPTIONS = "FILESYSTEM_OPTIONS"
+	KEY_ALL_FILESYSTEMS    = "ALL_FILESYSTEMS"
+	KEY_FILESYSTEM_USAGE   = "FILESYSTEM_USAGE"
+
 	// playground
 	KEY_ALL_PLAYGROUNDS_STATUS = "ALL_PLAYGROUNDS_STATUS"
 	PLAYGROUDN_STATUS_LOSED    = "Losed"
@@ -134,6 +185,22 @@ const (
 
 	// website
 	KEY_WEBSITE_STATUS = "WEBSITE_STATUS"
+
+	// logs
+	KEY_LOGS_OPTIONS = "LOGS_OPTIONS"
+	KEY_LOGS_MERGER  = "LOGS_MERGER"
+)
+
+// history
+const (
+	HISTORY_KIND_TOPOLOGY = "topology"
+	HISTORY_KIND_POOL     = "pool"
+	HISTORY_KIND_HOSTS    = "hosts"
+	HISTORY_KIND_DISKS    = "disks"
+	HISTORY_KIND_MONITOR  = "monitor"
+
+	// hosts and disks are shared by all clusters
+	HISTORY_GLOBAL_CLUSTER_ID = -1
 )
 
 // others
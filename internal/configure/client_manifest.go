@@ -0,0 +1,219 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/build"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/spf13/viper"
+)
+
+const (
+	DEFAULT_MANIFEST_CLIENT_CONF = "client.yaml"
+	DEFAULT_MANIFEST_HOST        = "localhost"
+	DEFAULT_MANIFEST_VOLUME_SIZE = "10GiB"
+	DEFAULT_MANIFEST_FSTYPE      = "s3"
+	DEFAULT_MANIFEST_BLOCKSIZE   = "4096B"
+)
+
+/*
+ * clients.yaml:
+ *
+ * conf: client.yaml  # default client configure for all items
+ * maps:
+ *   - host: machine1
+ *     volume: curve:/vol1
+ *     size: 10GiB
+ *     create: true
+ *     poolset: ssd
+ * mounts:
+ *   - host: machine1
+ *     fsname: /s3_001
+ *     mount_point: /mnt/s3_001
+ *     conf: fs-client.yaml
+ *     config:  # override items in client configure
+ *       s3.bucket_name: curvefs
+ * targets:
+ *   - host: machine2
+ *     volume: curve:/vol2
+ *     create: true
+ */
+type (
+	ManifestMap struct {
+		Host        string                 `mapstructure:"host"`
+		Volume      string                 `mapstructure:"volume"`
+		Size        string                 `mapstructure:"size"`
+		Create      bool                   `mapstructure:"create"`
+		NoExclusive bool                   `mapstructure:"no_exclusive"`
+		Poolset     string                 `mapstructure:"poolset"`
+		Persistent  bool                   `mapstructure:"persistent"`
+		Conf        string                 `mapstructure:"conf"`
+		Config      map[string]interface{} `mapstructure:"config"`
+	}
+
+	ManifestMount struct {
+		Host       string                 `mapstructure:"host"`
+		FSName     string                 `mapstructure:"fsname"`
+		FSType     string                 `mapstructure:"fstype"`
+		MountPoint string                 `mapstructure:"mount_point"`
+		Persistent bool                   `mapstructure:"persistent"`
+		Conf       string                 `mapstructure:"conf"`
+		Config     map[string]interface{} `mapstructure:"config"`
+	}
+
+	ManifestTarget struct {
+		Host       string                 `mapstructure:"host"`
+		Volume     string                 `mapstructure:"volume"`
+		Size       string                 `mapstructure:"size"`
+		Create     bool                   `mapstructure:"create"`
+		Blocksize  string                 `mapstructure:"blocksize"`
+		Persistent bool                   `mapstructure:"persistent"`
+		Conf       string                 `mapstructure:"conf"`
+		Config     map[string]interface{} `mapstructure:"config"`
+	}
+
+	ClientManifest struct {
+		Conf    string            `mapstructure:"conf"`
+		Maps    []*ManifestMap    `mapstructure:"maps"`
+		Mounts  []*ManifestMount  `mapstructure:"mounts"`
+		Targets []*ManifestTarget `mapstructure:"targets"`
+
+		dir   string
+		cache map[string]map[string]interface{}
+	}
+)
+
+func (m *ClientManifest) setDefault() {
+	m.Conf = withDefault(m.Conf, DEFAULT_MANIFEST_CLIENT_CONF)
+	for _, item := range m.Maps {
+		item.Host = withDefault(item.Host, DEFAULT_MANIFEST_HOST)
+		item.Size = withDefault(item.Size, DEFAULT_MANIFEST_VOLUME_SIZE)
+		item.Conf = withDefault(item.Conf, m.Conf)
+	}
+	for _, item := range m.Mounts {
+		item.Host = withDefault(item.Host, DEFAULT_MANIFEST_HOST)
+		item.FSType = withDefault(item.FSType, DEFAULT_MANIFEST_FSTYPE)
+		item.MountPoint = strings.TrimRight(item.MountPoint, "/")
+		item.Conf = withDefault(item.Conf, m.Conf)
+	}
+	for _, item := range m.Targets {
+		item.Host = withDefault(item.Host, DEFAULT_MANIFEST_HOST)
+		item.Size = withDefault(item.Size, DEFAULT_MANIFEST_VOLUME_SIZE)
+		item.Blocksize = withDefault(item.Blocksize, DEFAULT_MANIFEST_BLOCKSIZE)
+		item.Conf = withDefault(item.Conf, m.Conf)
+	}
+}
+
+func (m *ClientManifest) checkDuplicate() error {
+	exist := map[string]bool{}
+	keys := []string{}
+	for _, item := range m.Maps {
+		keys = append(keys, fmt.Sprintf("map %s %s", item.Host, item.Volume))
+	}
+	for _, item := range m.Mounts {
+		keys = append(keys, fmt.Sprintf("mount %s %s", item.Host, item.MountPoint))
+	}
+	for _, item := range m.Targets {
+		keys = append(keys, fmt.Sprintf("target %s %s", item.Host, item.Volume))
+	}
+	for _, key := range keys {
+		if exist[key] {
+			return errno.ERR_DUPLICATE_CLIENT_IN_MANIFEST.F("client: %s", key)
+		}
+		exist[key] = true
+	}
+	return nil
+}
+
+func withDefault(value, def string) string {
+	if len(value) == 0 {
+		return def
+	}
+	return value
+}
+
+func ParseClientManifest(filename string) (*ClientManifest, error) {
+	parser := viper.NewWithOptions(viper.KeyDelimiter("::"))
+	parser.SetConfigFile(filename)
+	parser.SetConfigType("yaml")
+	if err := parser.ReadInConfig(); err != nil {
+		return nil, errno.ERR_PARSE_CLIENT_MANIFEST_FAILED.E(err)
+	}
+
+	manifest := &ClientManifest{}
+	if err := parser.Unmarshal(manifest); err != nil {
+		return nil, errno.ERR_PARSE_CLIENT_MANIFEST_FAILED.E(err)
+	}
+	build.DEBUG(build.DEBUG_CLIENT_CONFIGURE, manifest)
+
+	manifest.dir = filepath.Dir(filename)
+	manifest.cache = map[string]map[string]interface{}{}
+	manifest.setDefault()
+	return manifest, manifest.checkDuplicate()
+}
+
+func (m *ClientManifest) readConf(conf string) (map[string]interface{}, error) {
+	if !filepath.IsAbs(conf) {
+		conf = filepath.Join(m.dir, conf)
+	}
+	if config, ok := m.cache[conf]; ok {
+		return config, nil
+	}
+
+	parser := viper.NewWithOptions(viper.KeyDelimiter("::"))
+	parser.SetConfigFile(conf)
+	parser.SetConfigType("yaml")
+	if err := parser.ReadInConfig(); err != nil {
+		return nil, errno.ERR_PARSE_CLIENT_CONFIGURE_FAILED.E(err)
+	}
+
+	config := map[string]interface{}{}
+	if err := parser.Unmarshal(&config); err != nil {
+		return nil, errno.ERR_PARSE_CLIENT_CONFIGURE_FAILED.E(err)
+	}
+	m.cache[conf] = config
+	return config, nil
+}
+
+// GetClientConfig returns a new client configure for each item, which
+// merged the configure file and its overrides
+func (m *ClientManifest) GetClientConfig(conf string,
+	overrides map[string]interface{}) (*ClientConfig, error) {
+	base, err := m.readConf(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]interface{}{}
+	for k, v := range base {
+		config[k] = v
+	}
+	for k, v := range overrides {
+		config[strings.ToLower(k)] = v
+	}
+	return NewClientConfig(config)
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientConf = `
+kind: curvebs
+mds.listen.addr: 10.0.0.1:6700
+`
+	testManifest = `
+conf: client.yaml
+maps:
+  - host: machine1
+    volume: curve:/vol1
+    create: true
+  - volume: curve:/vol2
+    config:
+      mds.listen.addr: 10.0.0.2:6700
+mounts:
+  - host: machine2
+    fsname: /s3_001
+    mount_point: /mnt/s3_001/
+`
+)
+
+func writeManifest(t *testing.T, manifest string) string {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "client.yaml"), []byte(testClientConf), 0644))
+	filename := filepath.Join(dir, "clients.yaml")
+	assert.Nil(t, os.WriteFile(filename, []byte(manifest), 0644))
+	return filename
+}
+
+func TestParseClientManifest(t *testing.T) {
+	assert := assert.New(t)
+	manifest, err := ParseClientManifest(writeManifest(t, testManifest))
+	assert.Nil(err)
+	assert.Len(manifest.Maps, 2)
+	assert.Len(manifest.Mounts, 1)
+
+	// defaults
+	assert.Equal("machine1", manifest.Maps[0].Host)
+	assert.True(manifest.Maps[0].Create)
+	assert.Equal(DEFAULT_MANIFEST_HOST, manifest.Maps[1].Host)
+	assert.Equal(DEFAULT_MANIFEST_VOLUME_SIZE, manifest.Maps[1].Size)
+	assert.Equal("/mnt/s3_001", manifest.Mounts[0].MountPoint)
+	assert.Equal(DEFAULT_MANIFEST_FSTYPE, manifest.Mounts[0].FSType)
+
+	// client configure overrides
+	cc, err := manifest.GetClientConfig(manifest.Maps[0].Conf, manifest.Maps[0].Config)
+	assert.Nil(err)
+	assert.Equal("10.0.0.1:6700", cc.GetClusterMDSAddr())
+	cc, err = manifest.GetClientConfig(manifest.Maps[1].Conf, manifest.Maps[1].Config)
+	assert.Nil(err)
+	assert.Equal("10.0.0.2:6700", cc.GetClusterMDSAddr())
+}
+
+func TestParseClientManifest_Duplicate(t *testing.T) {
+	assert := assert.New(t)
+	_, err := ParseClientManifest(writeManifest(t, `
+maps:
+  - volume: curve:/vol1
+  - volume: curve:/vol1
+`))
+	assert.NotNil(err)
+}
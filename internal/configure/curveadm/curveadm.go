@@ -34,39 +34,67 @@ import (
 /*
  * [defaults]
  * log_level = error
+ * log_max_size = 100
+ * log_max_age = 30
+ * log_max_backups = 10
  * sudo_alias = "sudo"
  * timeout = 180
+ * telemetry = true
+ * report_url = "http://curveadm.aspirer.wang:19302/"
+ * registry_mirror = "harbor.local:5000"
  *
  * [ssh_connections]
  * retries = 3
  * timeout = 10
+ *
+ * [database]
+ * url = "sqlite:///home/curve/.curveadm/data/curveadm.db"
+ * secret_key_file = "/home/curve/.curveadm/data/secret.key"
  */
 const (
-	KEY_LOG_LEVEL    = "log_level"
-	KEY_SUDO_ALIAS   = "sudo_alias"
-	KEY_ENGINE       = "engine"
-	KEY_TIMEOUT      = "timeout"
-	KEY_AUTO_UPGRADE = "auto_upgrade"
-	KEY_SSH_RETRIES  = "retries"
-	KEY_SSH_TIMEOUT  = "timeout"
-
-	WITHOUT_SUDO = " "
+	KEY_LOG_LEVEL       = "log_level"
+	KEY_LOG_MAX_SIZE    = "log_max_size"
+	KEY_LOG_MAX_AGE     = "log_max_age"
+	KEY_LOG_MAX_BACKUPS = "log_max_backups"
+	KEY_SUDO_ALIAS      = "sudo_alias"
+	KEY_ENGINE          = "engine"
+	KEY_TIMEOUT         = "timeout"
+	KEY_AUTO_UPGRADE    = "auto_upgrade"
+	KEY_TELEMETRY       = "telemetry"
+	KEY_REPORT_URL      = "report_url"
+	KEY_REGISTRY        = "registry_mirror"
+	KEY_SSH_RETRIES     = "retries"
+	KEY_SSH_TIMEOUT     = "timeout"
+	KEY_DB_URL          = "url"
+	KEY_SECRET_KEY_FILE = "secret_key_file"
+
+	WITHOUT_SUDO       = " "
+	DEFAULT_REPORT_URL = "http://curveadm.aspirer.wang:19302/"
 )
 
 type (
 	CurveAdmConfig struct {
-		LogLevel    string
-		SudoAlias   string
-		Engine      string
-		Timeout     int
-		AutoUpgrade bool
-		SSHRetries  int
-		SSHTimeout  int
+		LogLevel      string
+		LogMaxSize    int // megabytes of log file before it gets rotated
+		LogMaxAge     int // days to retain rotated log files
+		LogMaxBackups int // number of rotated log files to retain
+		SudoAlias     string
+		Engine        string
+		Timeout       int
+		AutoUpgrade   bool
+		Telemetry     bool   // disable all phone-home behavior (usage report, version check) if false
+		ReportURL     string // the collector which receives the usage report
+		Registry      string // pull images from private registry mirror instead of docker hub
+		SSHRetries    int
+		SSHTimeout    int
+		DBUrl         string
+		SecretKeyFile string // the key which encrypts sensitive data in database, should be shared with database
 	}
 
 	CurveAdm struct {
 		Defaults       map[string]interface{} `mapstructure:"defaults"`
 		SSHConnections map[string]interface{} `mapstructure:"ssh_connections"`
+		Database       map[string]interface{} `mapstructure:"database"`
 	}
 )
 
@@ -74,13 +102,18 @@ var (
 	GlobalCurveAdmConfig *CurveAdmConfig
 
 	defaultCurveAdmConfig = &CurveAdmConfig{
-		LogLevel:    "error",
-		SudoAlias:   "sudo",
-		Engine:      "docker",
-		Timeout:     180,
-		AutoUpgrade: true,
-		SSHRetries:  3,
-		SSHTimeout:  10,
+		LogLevel:      "error",
+		LogMaxSize:    100,
+		LogMaxAge:     30,
+		LogMaxBackups: 10,
+		SudoAlias:     "sudo",
+		Engine:        "docker",
+		Timeout:       180,
+		AutoUpgrade:   true,
+		Telemetry:     true,
+		ReportURL:     DEFAULT_REPORT_URL,
+		SSHRetries:    3,
+		SSHTimeout:    10,
 	}
 
 	SUPPORT_LOG_LEVEL = map[string]bool{
@@ -132,6 +165,28 @@ func parseDefaultsSection(cfg *CurveAdmConfig, defaults map[string]interface{})
 			}
 			cfg.LogLevel = v.(string)
 
+		// log rotation
+		case KEY_LOG_MAX_SIZE:
+			num, err := requirePositiveInt(KEY_LOG_MAX_SIZE, v)
+			if err != nil {
+				return err
+			}
+			cfg.LogMaxSize = num
+
+		case KEY_LOG_MAX_AGE:
+			num, err := requirePositiveInt(KEY_LOG_MAX_AGE, v)
+			if err != nil {
+				return err
+			}
+			cfg.LogMaxAge = num
+
+		case KEY_LOG_MAX_BACKUPS:
+			num, err := requirePositiveInt(KEY_LOG_MAX_BACKUPS, v)
+			if err != nil {
+				return err
+			}
+			cfg.LogMaxBackups = num
+
 		// sudo_alias
 		case KEY_SUDO_ALIAS:
 			cfg.SudoAlias = v.(string)
@@ -155,6 +210,27 @@ func parseDefaultsSection(cfg *CurveAdmConfig, defaults map[string]interface{})
 			}
 			cfg.AutoUpgrade = yes
 
+		// telemetry
+		case KEY_TELEMETRY:
+			yes, err := requirePositiveBool(KEY_TELEMETRY, v)
+			if err != nil {
+				return err
+			}
+			cfg.Telemetry = yes
+
+		// report_url
+		case KEY_REPORT_URL:
+			url, ok := v.(string)
+			if !ok || len(url) == 0 {
+				return errno.ERR_CONFIGURE_VALUE_REQUIRES_NON_EMPTY_STRING.
+					F("%s: %v", KEY_REPORT_URL, v)
+			}
+			cfg.ReportURL = url
+
+		// registry_mirror
+		case KEY_REGISTRY:
+			cfg.Registry = v.(string)
+
 		default:
 			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
 				F("%s: %s", k, v)
@@ -196,6 +272,40 @@ func parseConnectionSection(cfg *CurveAdmConfig, connection map[string]interface
 	return nil
 }
 
+func parseDatabaseSection(cfg *CurveAdmConfig, database map[string]interface{}) error {
+	if database == nil {
+		return nil
+	}
+
+	for k, v := range database {
+		switch k {
+		// database url
+		case KEY_DB_URL:
+			url, ok := v.(string)
+			if !ok || len(url) == 0 {
+				return errno.ERR_CONFIGURE_VALUE_REQUIRES_NON_EMPTY_STRING.
+					F("%s: %v", KEY_DB_URL, v)
+			}
+			cfg.DBUrl = url
+
+		// secret key file
+		case KEY_SECRET_KEY_FILE:
+			filename, ok := v.(string)
+			if !ok || len(filename) == 0 {
+				return errno.ERR_CONFIGURE_VALUE_REQUIRES_NON_EMPTY_STRING.
+					F("%s: %v", KEY_SECRET_KEY_FILE, v)
+			}
+			cfg.SecretKeyFile = filename
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	return nil
+}
+
 func ParseCurveAdmConfig(filename string) (*CurveAdmConfig, error) {
 	cfg := defaultCurveAdmConfig
 	if !utils.PathExist(filename) {
@@ -226,17 +336,29 @@ func ParseCurveAdmConfig(filename string) (*CurveAdmConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = parseDatabaseSection(cfg, global.Database)
+	if err != nil {
+		return nil, err
+	}
 
 	build.DEBUG(build.DEBUG_CURVEADM_CONFIGURE, cfg)
 	return cfg, nil
 }
 
-func (cfg *CurveAdmConfig) GetLogLevel() string  { return cfg.LogLevel }
-func (cfg *CurveAdmConfig) GetTimeout() int      { return cfg.Timeout }
-func (cfg *CurveAdmConfig) GetAutoUpgrade() bool { return cfg.AutoUpgrade }
-func (cfg *CurveAdmConfig) GetSSHRetries() int   { return cfg.SSHRetries }
-func (cfg *CurveAdmConfig) GetSSHTimeout() int   { return cfg.SSHTimeout }
-func (cfg *CurveAdmConfig) GetEngine() string    { return cfg.Engine }
+func (cfg *CurveAdmConfig) GetLogLevel() string       { return cfg.LogLevel }
+func (cfg *CurveAdmConfig) GetLogMaxSize() int        { return cfg.LogMaxSize }
+func (cfg *CurveAdmConfig) GetLogMaxAge() int         { return cfg.LogMaxAge }
+func (cfg *CurveAdmConfig) GetLogMaxBackups() int     { return cfg.LogMaxBackups }
+func (cfg *CurveAdmConfig) GetTimeout() int           { return cfg.Timeout }
+func (cfg *CurveAdmConfig) GetAutoUpgrade() bool      { return cfg.AutoUpgrade }
+func (cfg *CurveAdmConfig) GetTelemetry() bool        { return cfg.Telemetry }
+func (cfg *CurveAdmConfig) GetReportURL() string      { return cfg.ReportURL }
+func (cfg *CurveAdmConfig) GetSSHRetries() int        { return cfg.SSHRetries }
+func (cfg *CurveAdmConfig) GetRegistryMirror() string { return cfg.Registry }
+func (cfg *CurveAdmConfig) GetSSHTimeout() int        { return cfg.SSHTimeout }
+func (cfg *CurveAdmConfig) GetEngine() string         { return cfg.Engine }
+func (cfg *CurveAdmConfig) GetDBUrl() string          { return cfg.DBUrl }
+func (cfg *CurveAdmConfig) GetSecretKeyFile() string  { return cfg.SecretKeyFile }
 func (cfg *CurveAdmConfig) GetSudoAlias() string {
 	if len(cfg.SudoAlias) == 0 {
 		return WITHOUT_SUDO
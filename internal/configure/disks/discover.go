@@ -0,0 +1,453 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package disks
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// lsblk --pairs --bytes --paths --output $LSBLK_COLUMNS
+	LSBLK_COLUMNS = "NAME,TYPE,SIZE,ROTA,MODEL,SERIAL,MOUNTPOINT,FSTYPE,UUID,PKNAME"
+
+	DEV_DISK_BY_ID_DIR = "/dev/disk/by-id"
+
+	DISK_TYPE_SSD = "ssd"
+	DISK_TYPE_HDD = "hdd"
+
+	// discover status
+	DISCOVER_STATUS_AVAILABLE   = "Available"
+	DISCOVER_STATUS_COMMITTED   = "Committed"
+	DISCOVER_STATUS_SYSTEM      = "System"
+	DISCOVER_STATUS_MOUNTED     = "Mounted"
+	DISCOVER_STATUS_PARTITIONED = "Partitioned"
+	DISCOVER_STATUS_IN_USE      = "InUse"
+	DISCOVER_STATUS_FILTERED    = "Filtered"
+)
+
+var (
+	// the devices which mounted on these directories are system disks
+	SYSTEM_MOUNT_POINTS = map[string]bool{
+		"/":         true,
+		"/boot":     true,
+		"/boot/efi": true,
+		"/usr":      true,
+		"/var":      true,
+		"[SWAP]":    true,
+	}
+
+	// the device which has these filesystem signature is used by others
+	IN_USE_FSTYPES = map[string]bool{
+		"LVM2_member":       true,
+		"linux_raid_member": true,
+		"zfs_member":        true,
+		"ceph_bluestore":    true,
+	}
+
+	lsblkPairRegex = regexp.MustCompile(`([A-Z:\-]+)="([^"]*)"`)
+)
+
+type (
+	BlockDevice struct {
+		Host       string
+		Device     string // e.g. /dev/nvme0n1
+		Size       uint64
+		Rotational bool
+		Model      string
+		Serial     string
+		FSType     string
+		UUID       string
+		ById       string // e.g. nvme-SAMSUNG_MZQL23T8HCLS-00A07_S64HNE0R123456
+		Status     string
+		Reason     string
+	}
+
+	DiscoverRule struct {
+		MinSize  uint64
+		MaxSize  uint64
+		DiskType string // ssd/hdd, empty means any
+		Model    *regexp.Regexp
+		Serial   *regexp.Regexp
+	}
+
+	GenerateOption struct {
+		MountPrefix        string
+		FormatPercent      int
+		ContainerImage     string
+		ServiceMountDevice bool
+	}
+
+	lsblkItem struct {
+		name       string
+		dtype      string
+		mountPoint string
+		parent     string
+		attrs      map[string]string
+	}
+)
+
+func (bd *BlockDevice) GetDiskType() string {
+	if bd.Rotational {
+		return DISK_TYPE_HDD
+	}
+	return DISK_TYPE_SSD
+}
+
+// URI which is stable across reboot: filesystem UUID first, and then /dev/disk/by-id
+func (bd *BlockDevice) GetURI() string {
+	if len(bd.UUID) > 0 {
+		return GenDiskURI(DISK_URI_PROTO_FS_UUID, bd.UUID)
+	} else if len(bd.ById) > 0 {
+		return GenDiskURI(DISK_URI_PROTO_BY_ID, bd.ById)
+	}
+	return common.DISK_DEFAULT_NULL_URI
+}
+
+func NewDiscoverRule(minSize, maxSize, diskType, model, serial string) (*DiscoverRule, error) {
+	var err error
+	rule := &DiscoverRule{DiskType: diskType}
+	if len(minSize) > 0 {
+		if rule.MinSize, err = humanize.ParseBytes(minSize); err != nil {
+			return nil, errno.ERR_INVALID_DISCOVER_RULE.F("min size: %s", minSize)
+		}
+	}
+	if len(maxSize) > 0 {
+		if rule.MaxSize, err = humanize.ParseBytes(maxSize); err != nil {
+			return nil, errno.ERR_INVALID_DISCOVER_RULE.F("max size: %s", maxSize)
+		}
+	}
+	if diskType != "" && diskType != DISK_TYPE_SSD && diskType != DISK_TYPE_HDD {
+		return nil, errno.ERR_INVALID_DISCOVER_RULE.F("disk type: %s", diskType)
+	}
+	if len(model) > 0 {
+		if rule.Model, err = regexp.Compile(model); err != nil {
+			return nil, errno.ERR_INVALID_DISCOVER_RULE.E(err)
+		}
+	}
+	if len(serial) > 0 {
+		if rule.Serial, err = regexp.Compile(serial); err != nil {
+			return nil, errno.ERR_INVALID_DISCOVER_RULE.E(err)
+		}
+	}
+	return rule, nil
+}
+
+func (r *DiscoverRule) Match(bd *BlockDevice) (bool, string) {
+	if r.MinSize > 0 && bd.Size < r.MinSize {
+		return false, fmt.Sprintf("size < %s", humanize.IBytes(r.MinSize))
+	} else if r.MaxSize > 0 && bd.Size > r.MaxSize {
+		return false, fmt.Sprintf("size > %s", humanize.IBytes(r.MaxSize))
+	} else if len(r.DiskType) > 0 && bd.GetDiskType() != r.DiskType {
+		return false, fmt.Sprintf("type != %s", r.DiskType)
+	} else if r.Model != nil && !r.Model.MatchString(bd.Model) {
+		return false, fmt.Sprintf("model !~ %s", r.Model)
+	} else if r.Serial != nil && !r.Serial.MatchString(bd.Serial) {
+		return false, fmt.Sprintf("serial !~ %s", r.Serial)
+	}
+	return true, ""
+}
+
+/*
+ * byId: the output of `find /dev/disk/by-id -type l -printf '%f %l\n'`
+ *   nvme-SAMSUNG_MZQL23T8HCLS-00A07_S64HNE0R123456 ../../nvme0n1
+ *   wwn-0x5002538e40a1b2c3 ../../sda
+ */
+func parseById(byId string) map[string]string {
+	m := map[string]string{}
+	for _, line := range strings.Split(byId, "\n") {
+		items := strings.Fields(line)
+		if len(items) != 2 {
+			continue
+		}
+		device := path.Join("/dev", path.Base(items[1]))
+		// prefer the name which is more readable than wwn
+		if old, ok := m[device]; !ok || strings.HasPrefix(old, "wwn-") {
+			m[device] = items[0]
+		}
+	}
+	return m
+}
+
+/*
+ * lsblk: the output of `lsblk --pairs --bytes --paths --output $LSBLK_COLUMNS`
+ *   NAME="/dev/sda" TYPE="disk" SIZE="480103981056" ROTA="0" MODEL="..." ...
+ *   NAME="/dev/sda1" TYPE="part" SIZE="1073741824" ... MOUNTPOINT="/boot" ... PKNAME="/dev/sda"
+ */
+func ParseBlockDevices(host, lsblk, byId string) ([]*BlockDevice, error) {
+	// the device which has multiple parents (e.g. LVM) occurs once per parent
+	all := []*lsblkItem{}
+	items := map[string]*lsblkItem{}
+	disks := []*lsblkItem{}
+	for _, line := range strings.Split(lsblk, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		attrs := map[string]string{}
+		for _, mu := range lsblkPairRegex.FindAllStringSubmatch(line, -1) {
+			attrs[mu[1]] = strings.TrimSpace(mu[2])
+		}
+		if len(attrs["NAME"]) == 0 {
+			return nil, errno.ERR_PARSE_BLOCK_DEVICES_FAILED.F("line: %s", line)
+		}
+
+		item := &lsblkItem{
+			name:       attrs["NAME"],
+			dtype:      attrs["TYPE"],
+			mountPoint: attrs["MOUNTPOINT"],
+			parent:     attrs["PKNAME"],
+			attrs:      attrs,
+		}
+		all = append(all, item)
+		items[item.name] = item
+		if item.dtype == "disk" {
+			disks = append(disks, item)
+		}
+	}
+
+	// mark the top disk of each partition/holder
+	type usage struct{ children, mounted, system bool }
+	usages := map[string]*usage{}
+	for _, item := range all {
+		if item.dtype == "disk" {
+			continue
+		}
+		top, seen := item, map[string]bool{}
+		for len(top.parent) > 0 && items[top.parent] != nil && !seen[top.parent] {
+			seen[top.parent] = true
+			top = items[top.parent]
+		}
+		if top == item {
+			continue
+		}
+		if usages[top.name] == nil {
+			usages[top.name] = &usage{}
+		}
+		u := usages[top.name]
+		u.children = true
+		u.mounted = u.mounted || len(item.mountPoint) > 0
+		u.system = u.system || SYSTEM_MOUNT_POINTS[item.mountPoint]
+	}
+
+	byIds := parseById(byId)
+	devices := []*BlockDevice{}
+	for _, item := range disks {
+		size, err := strconv.ParseUint(item.attrs["SIZE"], 10, 64)
+		if err != nil {
+			return nil, errno.ERR_PARSE_BLOCK_DEVICES_FAILED.
+				F("device: %s, size: %s", item.name, item.attrs["SIZE"])
+		}
+
+		bd := &BlockDevice{
+			Host:       host,
+			Device:     item.name,
+			Size:       size,
+			Rotational: item.attrs["ROTA"] == "1",
+			Model:      item.attrs["MODEL"],
+			Serial:     item.attrs["SERIAL"],
+			FSType:     item.attrs["FSTYPE"],
+			UUID:       item.attrs["UUID"],
+			ById:       byIds[item.name],
+			Status:     DISCOVER_STATUS_AVAILABLE,
+		}
+		u := usages[item.name]
+		if u == nil {
+			u = &usage{}
+		}
+		switch {
+		case u.system || SYSTEM_MOUNT_POINTS[item.mountPoint]:
+			bd.Status, bd.Reason = DISCOVER_STATUS_SYSTEM, "system disk"
+		case len(item.mountPoint) > 0:
+			bd.Status, bd.Reason = DISCOVER_STATUS_MOUNTED, item.mountPoint
+		case u.mounted:
+			bd.Status, bd.Reason = DISCOVER_STATUS_MOUNTED, "partition mounted"
+		case u.children:
+			bd.Status, bd.Reason = DISCOVER_STATUS_PARTITIONED, "has partitions or holders"
+		case IN_USE_FSTYPES[bd.FSType]:
+			bd.Status, bd.Reason = DISCOVER_STATUS_IN_USE, bd.FSType
+		case size == 0:
+			bd.Status, bd.Reason = DISCOVER_STATUS_IN_USE, "empty device"
+		}
+		devices = append(devices, bd)
+	}
+	return devices, nil
+}
+
+// yaml node helpers
+func mappingGet(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mappingSet(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func mappingDelete(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func newScalarNode(value interface{}) *yaml.Node {
+	node := &yaml.Node{}
+	node.Encode(value)
+	return node
+}
+
+func newSequenceNode(values []string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, value := range values {
+		node.Content = append(node.Content, newScalarNode(value))
+	}
+	return node
+}
+
+func newDisksDocument(option GenerateOption) *yaml.Node {
+	global := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if len(option.ContainerImage) > 0 {
+		mappingSet(global, common.DISK_FORMAT_CONTAINER_IMAGE, newScalarNode(option.ContainerImage))
+	}
+	mappingSet(global, common.DISK_FORMAT_PERCENT, newScalarNode(option.FormatPercent))
+	mappingSet(global, common.DISK_SERVICE_MOUNT_DEVICE, newScalarNode(option.ServiceMountDevice))
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mappingSet(root, "global", global)
+	mappingSet(root, "disk", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"})
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+}
+
+func encodeDisksDocument(doc *yaml.Node) (string, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return "", errno.ERR_PARSE_DISKS_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
+
+/*
+ * GenerateDisks appends the available devices into disks data:
+ *   1) the device which already exist in disks will be appended to its host list
+ *   2) the new device will be added as new disk with increasing mount point
+ *   3) the comments and others in disks data are kept as it is
+ */
+func GenerateDisks(data string, devices []*BlockDevice, option GenerateOption) (string, error) {
+	doc := &yaml.Node{}
+	dcs := []*DiskConfig{}
+	if len(strings.TrimSpace(data)) == 0 {
+		doc = newDisksDocument(option)
+	} else if err := yaml.Unmarshal([]byte(data), doc); err != nil {
+		return "", errno.ERR_PARSE_DISKS_FAILED.E(err)
+	} else if dcs, err = ParseDisks(data); err != nil {
+		return "", err
+	}
+
+	root := doc.Content[0]
+	diskNode := mappingGet(root, "disk")
+	if diskNode == nil || len(diskNode.Content) != len(dcs) {
+		return "", errno.ERR_PARSE_DISKS_FAILED.F("invalid disk field")
+	}
+
+	// existing devices and mount points
+	device2index := map[string]int{}
+	mountPoints := map[string]bool{}
+	hosts := map[int][]string{}
+	for i, dc := range dcs {
+		device2index[dc.GetDevice()] = i
+		mountPoints[dc.GetMountPoint()] = true
+		hosts[i] = dc.GetHost()
+	}
+
+	// group devices by device path
+	changed := false
+	newDevices := []string{}
+	newHosts := map[string][]string{}
+	for _, bd := range devices {
+		if bd.Status != DISCOVER_STATUS_AVAILABLE {
+			continue
+		}
+		if i, ok := device2index[bd.Device]; ok {
+			if utils.Slice2Map(hosts[i])[bd.Host] {
+				continue // already exist
+			}
+			changed = true
+			hosts[i] = append(hosts[i], bd.Host)
+			mappingSet(diskNode.Content[i], common.DISK_FILTER_HOST, newSequenceNode(hosts[i]))
+			mappingDelete(diskNode.Content[i], common.DISK_EXCLUDE_HOST)
+			continue
+		}
+		if _, ok := newHosts[bd.Device]; !ok {
+			newDevices = append(newDevices, bd.Device)
+		}
+		newHosts[bd.Device] = append(newHosts[bd.Device], bd.Host)
+	}
+	if !changed && len(newDevices) == 0 {
+		return data, nil
+	}
+
+	sort.Strings(newDevices)
+	seq := 0
+	for _, device := range newDevices {
+		mountPoint := fmt.Sprintf("%s%d", option.MountPrefix, seq)
+		for mountPoints[mountPoint] {
+			seq++
+			mountPoint = fmt.Sprintf("%s%d", option.MountPrefix, seq)
+		}
+		mountPoints[mountPoint] = true
+
+		disk := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		mappingSet(disk, common.DISK_FILTER_DEVICE, newScalarNode(device))
+		mappingSet(disk, common.DISK_FORMAT_MOUNT_POINT, newScalarNode(mountPoint))
+		mappingSet(disk, common.DISK_FILTER_HOST, newSequenceNode(newHosts[device]))
+		diskNode.Content = append(diskNode.Content, disk)
+	}
+
+	return encodeDisksDocument(doc)
+}
@@ -0,0 +1,136 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package disks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	lsblkOutput = `NAME="/dev/sda" TYPE="disk" SIZE="480103981056" ROTA="0" MODEL="INTEL SSDSC2KB48" SERIAL="BTYF1234" MOUNTPOINT="" FSTYPE="" UUID="" PKNAME=""
+NAME="/dev/sda1" TYPE="part" SIZE="1073741824" ROTA="0" MODEL="" SERIAL="" MOUNTPOINT="/boot" FSTYPE="xfs" UUID="1111" PKNAME="/dev/sda"
+NAME="/dev/sda2" TYPE="part" SIZE="479030239232" ROTA="0" MODEL="" SERIAL="" MOUNTPOINT="" FSTYPE="LVM2_member" UUID="2222" PKNAME="/dev/sda"
+NAME="/dev/mapper/root" TYPE="lvm" SIZE="479030239232" ROTA="0" MODEL="" SERIAL="" MOUNTPOINT="/" FSTYPE="xfs" UUID="3333" PKNAME="/dev/sda2"
+NAME="/dev/sdb" TYPE="disk" SIZE="4000787030016" ROTA="1" MODEL="ST4000NM0035" SERIAL="ZC1ABCDE" MOUNTPOINT="" FSTYPE="" UUID="" PKNAME=""
+NAME="/dev/sdc" TYPE="disk" SIZE="4000787030016" ROTA="1" MODEL="ST4000NM0035" SERIAL="ZC1FGHIJ" MOUNTPOINT="/data/backup" FSTYPE="ext4" UUID="4444" PKNAME=""
+NAME="/dev/nvme0n1" TYPE="disk" SIZE="3840755982336" ROTA="0" MODEL="SAMSUNG MZQL23T8HCLS-00A07" SERIAL="S64HNE0R123456" MOUNTPOINT="" FSTYPE="ext4" UUID="5555" PKNAME=""
+NAME="/dev/sr0" TYPE="rom" SIZE="1073741312" ROTA="1" MODEL="DVD-ROM" SERIAL="" MOUNTPOINT="" FSTYPE="" UUID="" PKNAME=""`
+
+	byIdOutput = `wwn-0x5000c500a1b2c3d4 ../../sdb
+ata-ST4000NM0035_ZC1ABCDE ../../sdb
+nvme-SAMSUNG_MZQL23T8HCLS-00A07_S64HNE0R123456 ../../nvme0n1`
+)
+
+func TestParseBlockDevices(t *testing.T) {
+	assert := assert.New(t)
+	devices, err := ParseBlockDevices("host1", lsblkOutput, byIdOutput)
+	assert.Nil(err)
+	assert.Len(devices, 4)
+
+	status := map[string]string{}
+	for _, bd := range devices {
+		status[bd.Device] = bd.Status
+	}
+	assert.Equal(DISCOVER_STATUS_SYSTEM, status["/dev/sda"])
+	assert.Equal(DISCOVER_STATUS_AVAILABLE, status["/dev/sdb"])
+	assert.Equal(DISCOVER_STATUS_MOUNTED, status["/dev/sdc"])
+	assert.Equal(DISCOVER_STATUS_AVAILABLE, status["/dev/nvme0n1"])
+
+	assert.Equal("dev:by-id//ata-ST4000NM0035_ZC1ABCDE", devices[1].GetURI())
+	assert.Equal(DISK_TYPE_HDD, devices[1].GetDiskType())
+	assert.Equal("fs:uuid//5555", devices[3].GetURI())
+	assert.Equal(DISK_TYPE_SSD, devices[3].GetDiskType())
+}
+
+func TestDiscoverRule(t *testing.T) {
+	assert := assert.New(t)
+	devices, err := ParseBlockDevices("host1", lsblkOutput, byIdOutput)
+	assert.Nil(err)
+	sdb, nvme := devices[1], devices[3]
+
+	rule, err := NewDiscoverRule("1TiB", "", DISK_TYPE_SSD, "^SAMSUNG", "")
+	assert.Nil(err)
+	ok, _ := rule.Match(sdb)
+	assert.False(ok)
+	ok, _ = rule.Match(nvme)
+	assert.True(ok)
+
+	rule, err = NewDiscoverRule("4TiB", "", "", "", "")
+	assert.Nil(err)
+	ok, _ = rule.Match(nvme)
+	assert.False(ok)
+
+	_, err = NewDiscoverRule("", "", "nvme", "", "")
+	assert.NotNil(err)
+}
+
+func TestGenerateDisks(t *testing.T) {
+	assert := assert.New(t)
+	option := GenerateOption{MountPrefix: "/data/chunkserver", FormatPercent: 90}
+	devices := []*BlockDevice{
+		{Host: "curve-1", Device: "/dev/sdb", Status: DISCOVER_STATUS_AVAILABLE},
+		{Host: "curve-2", Device: "/dev/sdb", Status: DISCOVER_STATUS_AVAILABLE},
+		{Host: "curve-1", Device: "/dev/sdc", Status: DISCOVER_STATUS_AVAILABLE},
+		{Host: "curve-1", Device: "/dev/sda", Status: DISCOVER_STATUS_SYSTEM},
+	}
+
+	// generate from scratch
+	data, err := GenerateDisks("", devices, option)
+	assert.Nil(err)
+	dcs, err := ParseDisks(data)
+	assert.Nil(err)
+	assert.Len(dcs, 2)
+	assert.Equal("/dev/sdb", dcs[0].GetDevice())
+	assert.Equal([]string{"curve-1", "curve-2"}, dcs[0].GetHost())
+	assert.Equal("/data/chunkserver0", dcs[0].GetMountPoint())
+	assert.Equal("/dev/sdc", dcs[1].GetDevice())
+	assert.Equal("/data/chunkserver1", dcs[1].GetMountPoint())
+
+	// append to existing disks, the comments should be kept
+	old := `# disks for curvebs
+global:
+  format_percent: 95
+  host:
+    - curve-1
+disk:
+  - device: /dev/sdb
+    mount: /data/chunkserver0
+`
+	data, err = GenerateDisks(old, devices, option)
+	assert.Nil(err)
+	assert.Contains(data, "# disks for curvebs")
+	dcs, err = ParseDisks(data)
+	assert.Nil(err)
+	assert.Len(dcs, 2)
+	assert.Equal([]string{"curve-1", "curve-2"}, dcs[0].GetHost())
+	assert.Equal("/dev/sdc", dcs[1].GetDevice())
+	assert.Equal("/data/chunkserver1", dcs[1].GetMountPoint())
+	assert.Equal(95, dcs[1].GetFormatPercent())
+
+	// nothing changed
+	out, err := GenerateDisks(data, devices, option)
+	assert.Nil(err)
+	assert.Equal(data, out)
+}
@@ -37,6 +37,7 @@ import (
 const (
 	DISK_URI_SEP           = "//"
 	DISK_URI_PROTO_FS_UUID = "fs:uuid"
+	DISK_URI_PROTO_BY_ID   = "dev:by-id"
 )
 
 type (
@@ -127,7 +128,8 @@ func returnInvalidDiskUriError(disk storage.Disk) error {
 func GetDiskId(disk storage.Disk) (diskId, diskUriProto string, err error) {
 	// valide disk uri:
 	// 1. fs:uuid//8035a617-72ec-4c06-8719-8aca79234ef9
-	// 2. (not implemented) maybe "nvme:pci//00:00.1"
+	// 2. dev:by-id//nvme-SAMSUNG_MZQL23T8HCLS-00A07_S64HNE0R123456 (recorded by disks discover)
+	// 3. (not implemented) maybe "nvme:pci//00:00.1"
 	diskUriComponants := strings.Split(disk.URI, DISK_URI_SEP)
 	if len(diskUriComponants) < 2 {
 		return "", diskUriProto, returnInvalidDiskUriError(disk)
@@ -135,7 +137,8 @@ func GetDiskId(disk storage.Disk) (diskId, diskUriProto string, err error) {
 
 	diskUriProto = diskUriComponants[0]
 	switch diskUriProto {
-	case DISK_URI_PROTO_FS_UUID:
+	case DISK_URI_PROTO_FS_UUID,
+		DISK_URI_PROTO_BY_ID:
 		return diskUriComponants[1], diskUriProto, nil
 	default:
 		return "", diskUriProto, returnInvalidDiskUriError(disk)
@@ -218,18 +221,24 @@ func ParseDisks(data string) ([]*DiskConfig, error) {
 			return nil, err
 		}
 
-		if _, ok := exist[dc.GetDevice()]; ok {
-			return nil, errno.ERR_DUPLICATE_DISK.
-				F("duplicate disk: %s", dc.GetDevice())
-		}
-		if _, ok := exist[dc.GetMountPoint()]; ok {
-			return nil, errno.ERR_DUPLICATE_DISK.
-				F("duplicate disk mount point: %s", dc.GetMountPoint())
+		// NOTE: the same device or mount point is allowed on different hosts,
+		// e.g. a replaced disk which has different device path on one host
+		for _, host := range dc.GetHost() {
+			device := strings.Join([]string{host, dc.GetDevice()}, ":")
+			mountPoint := strings.Join([]string{host, dc.GetMountPoint()}, ":")
+			if _, ok := exist[device]; ok {
+				return nil, errno.ERR_DUPLICATE_DISK.
+					F("duplicate disk: %s", dc.GetDevice())
+			}
+			if _, ok := exist[mountPoint]; ok {
+				return nil, errno.ERR_DUPLICATE_DISK.
+					F("duplicate disk mount point: %s", dc.GetMountPoint())
+			}
+			exist[device] = true
+			exist[mountPoint] = true
 		}
 
 		dcs = append(dcs, dc)
-		exist[dc.GetDevice()] = true
-		exist[dc.GetMountPoint()] = true
 	}
 
 	build.DEBUG(build.DEBUG_DISKS, disks)
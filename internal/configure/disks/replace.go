@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package disks
+
+import (
+	"github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+ * ReplaceDisk replaces the device of specified host in disks data:
+ *   1) the disk which only belongs to the host will be changed in place
+ *   2) otherwise the host will be removed from the disk, and a new disk
+ *      with the same configure (e.g. mount point) will be added for it
+ */
+func ReplaceDisk(data, host, oldDevice, newDevice string) (string, error) {
+	dcs, err := ParseDisks(data)
+	if err != nil {
+		return "", err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(data), doc); err != nil {
+		return "", errno.ERR_PARSE_DISKS_FAILED.E(err)
+	}
+	root := doc.Content[0]
+	diskNode := mappingGet(root, "disk")
+	if diskNode == nil || len(diskNode.Content) != len(dcs) {
+		return "", errno.ERR_PARSE_DISKS_FAILED.F("invalid disk field")
+	}
+
+	index := -1
+	for i, dc := range dcs {
+		if !utils.Slice2Map(dc.GetHost())[host] {
+			continue
+		} else if dc.GetDevice() == newDevice {
+			return "", errno.ERR_REPLACE_DISK_ALREADY_EXIST.
+				F("host=%s device=%s", host, newDevice)
+		} else if dc.GetDevice() == oldDevice {
+			index = i
+		}
+	}
+	if index == -1 {
+		return "", errno.ERR_REPLACE_DISK_NOT_FOUND.
+			F("host=%s device=%s", host, oldDevice)
+	}
+
+	node := diskNode.Content[index]
+	hosts := dcs[index].GetHost()
+	if len(hosts) == 1 {
+		mappingSet(node, common.DISK_FILTER_DEVICE, newScalarNode(newDevice))
+		return encodeDisksDocument(doc)
+	}
+
+	remain := []string{}
+	for _, h := range hosts {
+		if h != host {
+			remain = append(remain, h)
+		}
+	}
+	mappingSet(node, common.DISK_FILTER_HOST, newSequenceNode(remain))
+	mappingDelete(node, common.DISK_EXCLUDE_HOST)
+
+	disk := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := *node.Content[i+1]
+		mappingSet(disk, node.Content[i].Value, &value)
+	}
+	mappingSet(disk, common.DISK_FILTER_DEVICE, newScalarNode(newDevice))
+	mappingSet(disk, common.DISK_FILTER_HOST, newSequenceNode([]string{host}))
+	diskNode.Content = append(diskNode.Content[:index+1],
+		append([]*yaml.Node{disk}, diskNode.Content[index+1:]...)...)
+	return encodeDisksDocument(doc)
+}
@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package disks
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	replaceDisksData = `global:
+  format_percent: 90
+  host:
+    - host1
+    - host2
+
+disk:
+  - device: /dev/sdb
+    mount: /data/chunkserver0
+  - device: /dev/sdc
+    mount: /data/chunkserver1
+    host:
+      - host1
+`
+)
+
+func TestReplaceDisk(t *testing.T) {
+	assert := assert.New(t)
+
+	// shared disk: split host into a new disk
+	data, err := ReplaceDisk(replaceDisksData, "host2", "/dev/sdb", "/dev/sdd")
+	assert.Nil(err)
+	dcs, err := ParseDisks(data)
+	assert.Nil(err)
+	assert.Len(dcs, 3)
+	assert.Equal([]string{"host1"}, dcs[0].GetHost())
+	assert.Equal("/dev/sdd", dcs[1].GetDevice())
+	assert.Equal("/data/chunkserver0", dcs[1].GetMountPoint())
+	assert.Equal([]string{"host2"}, dcs[1].GetHost())
+
+	// exclusive disk: change device in place
+	data, err = ReplaceDisk(replaceDisksData, "host1", "/dev/sdc", "/dev/sdd")
+	assert.Nil(err)
+	dcs, err = ParseDisks(data)
+	assert.Nil(err)
+	assert.Len(dcs, 2)
+	assert.Equal("/dev/sdd", dcs[1].GetDevice())
+
+	// invalid
+	_, err = ReplaceDisk(replaceDisksData, "host2", "/dev/sdc", "/dev/sdd")
+	assert.Equal(errno.ERR_REPLACE_DISK_NOT_FOUND.GetCode(), err.(*errno.ErrorCode).GetCode())
+	_, err = ReplaceDisk(replaceDisksData, "host1", "/dev/sdb", "/dev/sdc")
+	assert.Equal(errno.ERR_REPLACE_DISK_ALREADY_EXIST.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KEY_HOSTS = "hosts"
+)
+
+// NOTE: we edit the yaml node instead of struct to keep the comments of hosts
+func loadHostsNode(data string) (*yaml.Node, *yaml.Node, error) {
+	doc := &yaml.Node{}
+	if len(strings.TrimSpace(data)) > 0 {
+		err := yaml.Unmarshal([]byte(data), doc)
+		if err != nil {
+			return nil, nil, errno.ERR_PARSE_HOSTS_FAILED.E(err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, errno.ERR_PARSE_HOSTS_FAILED.
+			F("hosts requires a mapping")
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == KEY_HOSTS {
+			return doc, root.Content[i+1], nil
+		}
+	}
+
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	root.Content = append(root.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: KEY_HOSTS}, seq)
+	return doc, seq, nil
+}
+
+func dumpHostsNode(doc *yaml.Node) (string, error) {
+	bytes, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", errno.ERR_PARSE_HOSTS_FAILED.E(err)
+	}
+	return string(bytes), nil
+}
+
+// AppendHosts appends host items to the end of hosts
+func AppendHosts(data string, items []map[string]interface{}) (string, error) {
+	doc, seq, err := loadHostsNode(data)
+	if err != nil {
+		return "", err
+	}
+
+	// the empty sequence maybe in flow style (e.g. hosts: [])
+	seq.Kind = yaml.SequenceNode
+	seq.Tag = "!!seq"
+	seq.Style = 0
+	for _, item := range items {
+		node := &yaml.Node{}
+		if err := node.Encode(item); err != nil {
+			return "", errno.ERR_PARSE_HOSTS_FAILED.E(err)
+		}
+		seq.Content = append(seq.Content, node)
+	}
+	return dumpHostsNode(doc)
+}
+
+// RemoveHosts removes the host items which matched
+func RemoveHosts(data string, match func(item map[string]interface{}) bool) (string, error) {
+	doc, seq, err := loadHostsNode(data)
+	if err != nil {
+		return "", err
+	}
+
+	content := []*yaml.Node{}
+	for _, node := range seq.Content {
+		item := map[string]interface{}{}
+		if err := node.Decode(&item); err != nil {
+			return "", errno.ERR_PARSE_HOSTS_FAILED.E(err)
+		} else if !match(item) {
+			content = append(content, node)
+		}
+	}
+	seq.Content = content
+	return dumpHostsNode(doc)
+}
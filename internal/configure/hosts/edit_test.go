@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendAndRemoveHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `global:
+  user: curve
+hosts:
+  # the first host
+  - host: server-host1
+    hostname: 10.0.1.1
+`
+	out, err := AppendHosts(data, []map[string]interface{}{
+		{"host": "playground-node1", "hostname": "172.18.0.2", "user": "root"},
+	})
+	assert.Nil(err)
+	assert.Contains(out, "# the first host")
+	assert.Contains(out, "host: playground-node1")
+	assert.Contains(out, "hostname: 172.18.0.2")
+
+	out, err = RemoveHosts(out, func(item map[string]interface{}) bool {
+		return item["host"] == "playground-node1"
+	})
+	assert.Nil(err)
+	assert.Contains(out, "host: server-host1")
+	assert.NotContains(out, "playground-node1")
+
+	// empty hosts
+	out, err = AppendHosts("", []map[string]interface{}{
+		{"host": "playground-node1", "hostname": "172.18.0.2"},
+	})
+	assert.Nil(err)
+	assert.Contains(out, "hosts:")
+	assert.Contains(out, "host: playground-node1")
+}
+
+func TestPrivateKeyFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	// the key files needn't exist
+	keyfiles, err := PrivateKeyFiles(`global:
+  private_key_file: /home/curve/.ssh/id_rsa
+hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+  - host: server-host2
+    hostname: 10.0.1.2
+    private_key_file: /home/curve/.ssh/id_ed25519
+`)
+	assert.Nil(err)
+	assert.Equal([]string{"/home/curve/.ssh/id_rsa", "/home/curve/.ssh/id_ed25519"}, keyfiles)
+
+	_, err = PrivateKeyFiles("")
+	assert.NotNil(err)
+}
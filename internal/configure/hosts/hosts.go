@@ -366,7 +366,7 @@ func replicateHostConfig(src *HostConfig, instances_sequence int) *HostConfig {
 	}
 }
 
-func ParseHosts(data string) ([]*HostConfig, error) {
+func unmarshalHosts(data string) (*Hosts, error) {
 	if len(data) == 0 {
 		return nil, errno.ERR_EMPTY_HOSTS
 	}
@@ -381,6 +381,40 @@ func ParseHosts(data string) ([]*HostConfig, error) {
 	if err := parser.Unmarshal(hosts); err != nil {
 		return nil, errno.ERR_PARSE_HOSTS_FAILED.E(err)
 	}
+	return hosts, nil
+}
+
+// PrivateKeyFiles returns the SSH private key files referenced by hosts,
+// unlike ParseHosts, it doesn't require the key files exist
+func PrivateKeyFiles(data string) ([]string, error) {
+	hosts, err := unmarshalHosts(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keyfiles := []string{}
+	key := CONFIG_PRIVATE_CONFIG_FILE.Key()
+	for i, host := range hosts.Host {
+		host = newIfNil(host)
+		merge(hosts.Global, host)
+		hc := NewHostConfig(i, map[string]interface{}{})
+		if value, ok := host[key]; ok {
+			v, err := itemset.Build(key, value)
+			if err != nil {
+				return nil, err
+			}
+			hc.config[key] = v
+		}
+		keyfiles = append(keyfiles, hc.GetPrivateKeyFile())
+	}
+	return keyfiles, nil
+}
+
+func ParseHosts(data string) ([]*HostConfig, error) {
+	hosts, err := unmarshalHosts(data)
+	if err != nil {
+		return nil, err
+	}
 
 	hcs := []*HostConfig{}
 	exist := map[string]bool{}
@@ -23,12 +23,15 @@
 package configure
 
 import (
+	"fmt"
+
 	"github.com/opencurve/curveadm/internal/configure/topology"
 )
 
 const (
 	DEFAULT_CURVEBS_CONTAINER_IMAGE = "opencurvedocker/curvebs-playground:v1.2"
 	DEFAULT_CURVEFS_CONTAINER_IMAGE = "opencurvedocker/curvefs-playground:v2.3"
+	DEFAULT_PLAYGROUND_NODE_IMAGE   = "docker:24-dind"
 )
 
 type (
@@ -40,6 +43,11 @@ type (
 
 		DeployConfigs []*topology.DeployConfig
 		ClientConfig  *ClientConfig
+
+		// multi-node playground: each node is a container which acts as a host
+		Nodes          []string
+		NodeImage      string
+		PrivateKeyFile string
 	}
 )
 
@@ -57,3 +65,20 @@ func (cfg *PlaygroundConfig) GetContainIamge() string {
 	}
 	return DEFAULT_CURVEFS_CONTAINER_IMAGE
 }
+
+func (cfg *PlaygroundConfig) IsMultiNode() bool         { return len(cfg.Nodes) > 0 }
+func (cfg *PlaygroundConfig) GetNodes() []string        { return cfg.Nodes }
+func (cfg *PlaygroundConfig) GetNetwork() string        { return cfg.Name }
+func (cfg *PlaygroundConfig) GetPrivateKeyFile() string { return cfg.PrivateKeyFile }
+
+func (cfg *PlaygroundConfig) GetNodeImage() string {
+	if len(cfg.NodeImage) > 0 {
+		return cfg.NodeImage
+	}
+	return DEFAULT_PLAYGROUND_NODE_IMAGE
+}
+
+// playground-curvebs-1656035415-node1
+func (cfg *PlaygroundConfig) GetNodeContainerName(node string) string {
+	return fmt.Sprintf("%s-%s", cfg.Name, node)
+}
@@ -25,7 +25,8 @@
 package topology
 
 type Context struct {
-	m map[string]string
+	m        map[string]string
+	fallback string // hostname for the host which not added
 }
 
 func NewContext() *Context {
@@ -37,5 +38,8 @@ func (ctx *Context) Add(host, hostname string) {
 }
 
 func (ctx *Context) Lookup(host string) string {
-	return ctx.m[host]
+	if hostname, ok := ctx.m[host]; ok {
+		return hostname
+	}
+	return ctx.fallback
 }
@@ -209,3 +209,26 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 
 	return dcs, nil
 }
+
+// returns the kind and distinct hosts which services deployed on, without hosts configure
+func ParseTopologyHosts(data string) (string, []string, error) {
+	ctx := NewContext()
+	ctx.fallback = "127.0.0.1"
+	dcs, err := ParseTopology(data, ctx)
+	if err != nil {
+		return "", nil, err
+	} else if len(dcs) == 0 {
+		return "", nil, errno.ERR_NO_SERVICES_IN_TOPOLOGY
+	}
+
+	hosts := []string{}
+	exist := map[string]bool{}
+	for _, dc := range dcs {
+		host := dc.GetHost()
+		if !exist[host] {
+			exist[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return dcs[0].GetKind(), hosts, nil
+}
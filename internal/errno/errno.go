@@ -207,7 +207,10 @@ var (
 	ERR_INIT_LOGGER_FAILED                  = EC(000003, "init logger failed")
 
 	// 100: database/SQL (init failed)
-	ERR_INIT_SQL_DATABASE_FAILED = EC(100000, "init SQLite database failed")
+	ERR_INIT_SQL_DATABASE_FAILED               = EC(100000, "init database failed")
+	ERR_DATABASE_SCHEMA_IS_NEWER_THAN_CURVEADM = EC(100001, "database schema is newer than current curveadm, please upgrade curveadm")
+	ERR_BACKUP_DATABASE_FAILED                 = EC(100002, "backup database before migrating failed")
+	ERR_MIGRATE_DATABASE_FAILED                = EC(100003, "migrate database schema failed")
 
 	// 110: database/SQL (execute SQL statement: hosts table)
 	ERR_GET_HOSTS_FAILED    = EC(110000, "execute SQL failed while get hosts")
@@ -249,21 +252,53 @@ var (
 	ERR_GET_MONITOR_FAILED     = EC(118000, "execute SQL failed while get monitor")
 	ERR_REPLACE_MONITOR_FAILED = EC(118001, "execute SQL failed while replace monitor")
 	ERR_UPDATE_MONITOR_FAILED  = EC(118002, "execute SQL failed while update monitor")
+	// 119: database/SQL (execute SQL statement: history table)
+	ERR_GET_HISTORY_FAILED = EC(119000, "execute SQL failed while get history")
+	// 120: database/SQL (execute SQL statement: image_digests table)
+	ERR_GET_IMAGE_DIGESTS_FAILED = EC(120000, "execute SQL failed while get image digests")
+	ERR_SET_IMAGE_DIGESTS_FAILED = EC(120001, "execute SQL failed while set image digests")
 
 	// 200: command options (hosts)
 
 	// 210: command options (cluster)
-	ERR_ID_NOT_FOUND                   = EC(210000, "id not found")
-	ERR_UNSUPPORT_CURVEBS_ROLE         = EC(210001, "unsupport curvebs role (etcd/mds/chunkserver/snapshotclone)")
-	ERR_UNSUPPORT_CURVEFS_ROLE         = EC(210002, "unsupport curvefs role (etcd/mds/metaserver)")
-	ERR_UNSUPPORT_SKIPPED_SERVICE_ROLE = EC(210003, "unsupport skipped service role")
-	ERR_UNSUPPORT_SKIPPED_CHECK_ITEM   = EC(210004, "unsupport skipped check item")
-	ERR_UNSUPPORT_CLEAN_ITEM           = EC(210005, "unsupport clean item")
-	ERR_NO_SERVICES_MATCHED            = EC(210006, "no services matched")
-	ERR_INVALID_DISK_TYPE              = EC(210007, "diskType must be lowercase and only can only be one of ssd, hdd and nvme")
+	ERR_ID_NOT_FOUND                    = EC(210000, "id not found")
+	ERR_UNSUPPORT_CURVEBS_ROLE          = EC(210001, "unsupport curvebs role (etcd/mds/chunkserver/snapshotclone)")
+	ERR_UNSUPPORT_CURVEFS_ROLE          = EC(210002, "unsupport curvefs role (etcd/mds/metaserver)")
+	ERR_UNSUPPORT_SKIPPED_SERVICE_ROLE  = EC(210003, "unsupport skipped service role")
+	ERR_UNSUPPORT_SKIPPED_CHECK_ITEM    = EC(210004, "unsupport skipped check item")
+	ERR_UNSUPPORT_CLEAN_ITEM            = EC(210005, "unsupport clean item")
+	ERR_NO_SERVICES_MATCHED             = EC(210006, "no services matched")
+	ERR_INVALID_DISK_TYPE               = EC(210007, "diskType must be lowercase and only can only be one of ssd, hdd and nvme")
+	ERR_UNSUPPORT_HISTORY_KIND          = EC(210008, "unsupport history kind (topology/pool/hosts/disks/monitor)")
+	ERR_HISTORY_REVISION_NOT_FOUND      = EC(210009, "history revision not found")
+	ERR_REQUIRES_AT_MOST_TWO_REVISIONS  = EC(210010, "requires at most two revisions for diff")
+	ERR_REQUIRES_TOPOLOGY_OR_REVISION   = EC(210011, "requires topology file or revision for diff")
+	ERR_EXPORT_SECRETS_REQUIRES_ENCRYPT = EC(210012, "export secrets requires encrypt the archive (--encrypt)")
+	ERR_PASSPHRASE_MISMATCH             = EC(210013, "passphrase mismatch")
+	ERR_EMPTY_PASSPHRASE                = EC(210014, "passphrase is empty")
+	ERR_INVALID_LOGS_SINCE              = EC(210015, "invalid duration for --since, it should be like 30s, 10m, 2h")
+	ERR_FOLLOW_CONFLICT_WITH_OUTPUT     = EC(210016, "can't follow logs (-f) and save them (--output) at the same time")
+	ERR_INCOMPLETE_S3_OPTIONS           = EC(210017, "upload to S3 requires bucket, access key and secret key")
+	ERR_UPLOAD_URL_CONFLICT_WITH_S3     = EC(210018, "can't upload to url (--upload-url) and S3 (--s3-endpoint) at the same time")
+	ERR_BUNDLE_FILE_NOT_FOUND           = EC(210019, "bundle file not found")
+	ERR_CORE_FILE_NOT_FOUND             = EC(210020, "core file not found, please list cores by 'curveadm cores ls'")
+	ERR_UNSUPPORTED_TRACE_TOOL          = EC(210021, "unsupported trace tool")
+	ERR_INVALID_TRACE_DURATION          = EC(210022, "invalid duration for --duration, it should be like 10s, 1m")
+	ERR_NO_SERVICE_OR_CLIENT_MATCHED    = EC(210023, "no service or client matched")
+	ERR_INVALID_AUDIT_ID                = EC(210024, "invalid audit id")
+	ERR_AUDIT_LOG_NOT_FOUND             = EC(210025, "audit log not found, please list audit logs by 'curveadm audit'")
+	ERR_INVALID_AUDIT_STATUS            = EC(210026, "invalid audit status (success/fail/cancel/abort)")
+	ERR_INVALID_AUDIT_TIME              = EC(210027, "invalid time for --since/--until, it should be like 2h, 2023-10-01 or '2023-10-01 10:00:00'")
+	ERR_UNSUPPORTED_AUDIT_FORMAT        = EC(210028, "unsupported audit format (table/json/csv)")
+	ERR_AUDIT_COMMAND_NOT_REPLAYABLE    = EC(210029, "audit command is not replayable")
+	ERR_REPLAY_AUDIT_COMMAND_FAILED     = EC(210030, "replay audit command failed")
+	ERR_READ_SECRET_FILE_FAILED         = EC(210031, "read secret file failed")
+	ERR_REPLAY_AUDIT_CLUSTER_MISMATCHED = EC(210032, "audit command was executed in another cluster, please checkout it first")
 
 	// 220: commad options (client common)
-	ERR_UNSUPPORT_CLIENT_KIND = EC(220000, "unsupport client kind")
+	ERR_UNSUPPORT_CLIENT_KIND           = EC(220000, "unsupport client kind")
+	ERR_CLIENT_MANIFEST_FILE_NOT_EXIST  = EC(220001, "client manifest file not exist")
+	ERR_APPLY_CLIENT_MANIFEST_PARTIALLY = EC(220002, "some clients in manifest are not applied")
 	// 221: command options (client/bs)
 	ERR_INVALID_VOLUME_FORMAT                      = EC(221000, "invalid volume format")
 	ERR_ROOT_VOLUME_USER_NOT_ALLOWED               = EC(221001, "root as volume user is not allowed")
@@ -277,14 +312,38 @@ var (
 	ERR_VOLUME_BLOCKSIZE_MUST_END_WITH_BYTE_SUFFIX = EC(201009, "volume block size must end with \"B\" suffix")
 	ERR_VOLUME_BLOCKSIZE_REQUIRES_POSITIVE_INTEGER = EC(221010, "volume block size requires a positive integer")
 	ERR_VOLUME_BLOCKSIZE_BE_MULTIPLE_OF_512        = EC(221011, "volume block size be a multiple of 512B, like 1KiB, 2KiB, 3KiB...")
+	ERR_CHAP_REQUIRES_USER_AND_PASSWORD            = EC(221012, "CHAP requires both user and password")
+	ERR_CHAP_PASSWORD_TOO_SHORT                    = EC(221013, "CHAP password requires at least 12 characters")
+	ERR_MUTUAL_CHAP_REQUIRES_INCOMING_CHAP         = EC(221014, "mutual CHAP requires incoming CHAP")
+	ERR_INVALID_INITIATOR_ADDRESS                  = EC(221015, "invalid initiator address")
+	ERR_INVALID_INITIATOR_NAME                     = EC(221016, "invalid initiator name, it should be like iqn.yyyy-mm.naming-authority:unique-name")
+	ERR_INVALID_CHAP_CREDENTIALS                   = EC(221017, "CHAP user and password can't contain whitespace or quote")
+	ERR_UNSUPPORT_EXPORT_PROTOCOL                  = EC(221018, "unsupport export protocol")
+	ERR_INVALID_EXPORT_LISTEN_ADDRESS              = EC(221019, "invalid export listen address")
+	ERR_INVALID_EXPORT_ALLOW_HOST                  = EC(221020, "invalid export allow host, it should be like nqn.yyyy-mm.naming-authority:unique-name")
+	ERR_NVMEOF_EXPORT_REQUIRES_ALLOW_HOST          = EC(221021, "NVMe-oF export requires at least one allow host (--allow-host)")
 	// 222: command options (client/fs)
 	ERR_FS_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH = EC(222000, "mount point must be an absolute path")
+	// 223: command options (volume)
+	ERR_VOLUME_REQUIRE_CURVEBS_CLUSTER = EC(223000, "volume command requires curvebs cluster")
+	ERR_NO_SNAPSHOTCLONE_SERVICE       = EC(223001, "no snapshotclone service in cluster")
+	// 224: command options (fs)
+	ERR_FS_REQUIRE_CURVEFS_CLUSTER    = EC(224000, "fs command requires curvefs cluster")
+	ERR_UNSUPPORT_FILESYSTEM_TYPE     = EC(224001, "unsupport filesystem type")
+	ERR_INVALID_FILESYSTEM_BLOCK_SIZE = EC(224002, "invalid filesystem block size")
+	ERR_INVALID_FILESYSTEM_CAPACITY   = EC(224003, "invalid filesystem capacity")
+	ERR_FILESYSTEM_REQUIRES_S3_INFO   = EC(224004, "s3 filesystem requires S3 access key, secret key, address and bucket name")
+	ERR_FILESYSTEM_NOT_FOUND          = EC(224005, "filesystem not found")
+	ERR_FILESYSTEM_IS_MOUNTED         = EC(224006, "filesystem is still mounted, please umount it first")
 
 	// 230: command options (playground)
 	ERR_UNSUPPORT_PLAYGROUND_KIND                      = EC(230000, "unsupport playground kind")
 	ERR_MUST_SPECIFY_MOUNTPOINT_FOR_CURVEFS_PLAYGROUND = EC(230001, "you must specify mountpoint for curvefs playground")
 	ERR_PLAYGROUND_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH    = EC(230002, "mount point must be an absolute path")
 	ERR_PLAYGROUND_MOUNTPOINT_NOT_EXIST                = EC(230003, "mount point not exist")
+	ERR_PLAYGROUND_NODE_ALREADY_EXIST                  = EC(230004, "playground node already exist in hosts")
+	ERR_PLAYGROUND_TOPOLOGY_KIND_MISMATCH              = EC(230005, "the kind of playground topology mismatch")
+	ERR_INVALID_PLAYGROUND_SPARE_NODES                 = EC(230006, "spare nodes of playground must be a non-negative number")
 
 	// 301: configure (common: invalid configure value)
 	ERR_UNSUPPORT_CONFIGURE_VALUE_TYPE = EC(301000, "unsupport configure value type")
@@ -336,6 +395,11 @@ var (
 	ERR_DISK_FORMAT_PERCENT_EXCEED_100 = EC(323008, "disk format percent is greater than 100")
 	ERR_DELETE_SERVICE_BINDING_DISK    = EC(323009, "cannot remove service binding disk")
 	ERR_INVALID_DISK_URI               = EC(323010, "invalid disk uri")
+	ERR_INVALID_DISCOVER_RULE          = EC(323011, "invalid disk discover rule")
+	ERR_PARSE_BLOCK_DEVICES_FAILED     = EC(323012, "parse block devices failed")
+	ERR_REPLACE_DISK_NOT_FOUND         = EC(323013, "disk to be replaced not found")
+	ERR_REPLACE_DISK_ALREADY_EXIST     = EC(323014, "new disk already exist")
+	ERR_DISK_NOT_BINDING_SERVICE       = EC(323015, "disk is not used by any chunkserver service")
 
 	// 324: configure (monitor.yaml: parse failed)
 	ERR_PARSE_MONITOR_CONFIGURE_FAILED = EC(324000, "parse monitor configure failed")
@@ -385,16 +449,19 @@ var (
 	ERR_MOUNT_POINT_REQUIRE_ABSOLUTE_PATH        = EC(341002, "mount point must be an absolute path")
 	ERR_FORMAT_PERCENT_REQUIRES_INTERGET         = EC(341003, "format percentage requires an integer")
 	ERR_FORMAT_PERCENT_MUST_BE_BETWEEN_1_AND_100 = EC(341004, "format percentage must be between 1 and 100")
+	ERR_INVALID_FORMAT_BANDWIDTH                 = EC(341005, "invalid format bandwidth")
 
 	// 350: configure (client.yaml: parse failed)
 	ERR_PARSE_CLIENT_CONFIGURE_FAILED  = EC(350000, "parse client configure failed")
 	ERR_RESOLVE_CLIENT_VARIABLE_FAILED = EC(350001, "resolve client variable failed")
+	ERR_PARSE_CLIENT_MANIFEST_FAILED   = EC(350002, "parse client manifest failed")
 	// 351: configure (client.yaml: invalid configure value)
 	ERR_UNSUPPORT_CLIENT_CONFIGURE_KIND            = EC(351000, "unsupport client configure kind")
 	ERR_UNSUPPORT_CLIENT_CONFIGURE_VALUE_TYPE      = EC(351001, "unsupport client configure value type")
 	ERR_REQUIRE_CURVEBS_KIND_CLIENT_CONFIGURE_FILE = EC(351002, "require curvebs kind client configure file")
 	ERR_REQUIRE_CURVEFS_KIND_CLIENT_CONFIGURE_FILE = EC(351003, "require curvefs kind client configure file")
 	ERR_INVALID_CLUSTER_LISTEN_MDS_ADDRESS         = EC(351004, "invalid cluster MDS listen address")
+	ERR_DUPLICATE_CLIENT_IN_MANIFEST               = EC(351005, "client is duplicated in manifest")
 
 	// 360: sqlite database
 	ERR_DATABASE_EMPTY_QUERY_RESULT = EC(360000, "empty query result")
@@ -427,6 +494,36 @@ var (
 	ERR_ENCRYPT_FILE_FAILED                  = EC(410021, "encrypt file failed")
 	ERR_CLIENT_ID_NOT_FOUND                  = EC(410022, "client id not found")
 	ERR_ENABLE_ETCD_AUTH_FAILED              = EC(410023, "enable etcd auth failed")
+	ERR_CLUSTER_UUID_ALREADY_EXIST           = EC(410024, "cluster with the same uuid already exist")
+	ERR_READ_CLUSTER_ARCHIVE_FAILED          = EC(410025, "read cluster archive failed")
+	ERR_WRITE_CLUSTER_ARCHIVE_FAILED         = EC(410026, "write cluster archive failed")
+	ERR_INVALID_CLUSTER_ARCHIVE              = EC(410027, "invalid cluster archive")
+	ERR_DECRYPT_CLUSTER_ARCHIVE_FAILED       = EC(410028, "decrypt cluster archive failed, please check the passphrase")
+	ERR_IMPORT_CLUSTER_FAILED                = EC(410029, "import cluster failed")
+	ERR_READ_PRIVATE_KEY_FILE_FAILED         = EC(410030, "read SSH private key file failed")
+	ERR_WRITE_PRIVATE_KEY_FILE_FAILED        = EC(410031, "write SSH private key file failed")
+	ERR_RETIRE_CHUNKSERVER_FAILED            = EC(410032, "retire chunkserver failed")
+	ERR_WAIT_CHUNKSERVER_RETIRED_TIMEOUT     = EC(410033, "wait chunkserver retired timeout")
+	ERR_WAIT_CHUNKSERVER_REGISTERED_TIMEOUT  = EC(410034, "wait chunkserver registered timeout")
+	ERR_READ_REPLACE_PROGRESS_FAILED         = EC(410035, "read disk replacement progress failed")
+	ERR_WRITE_REPLACE_PROGRESS_FAILED        = EC(410036, "write disk replacement progress failed")
+	ERR_DECODE_CLIENT_AUX_INFO_FAILED        = EC(410037, "decode client aux info failed")
+	ERR_READ_SECRET_KEY_FILE_FAILED          = EC(410038, "read curveadm secret key file failed")
+	ERR_WRITE_SECRET_KEY_FILE_FAILED         = EC(410039, "write curveadm secret key file failed")
+	ERR_ENCRYPT_TARGET_CREDENTIALS_FAILED    = EC(410040, "encrypt target credentials failed")
+	ERR_SAVE_SERVICE_LOGS_FAILED             = EC(410041, "save service logs failed")
+	ERR_ENCODE_BUNDLE_MANIFEST_FAILED        = EC(410042, "encode bundle manifest failed")
+	ERR_DECODE_BUNDLE_MANIFEST_FAILED        = EC(410043, "decode bundle manifest failed")
+	ERR_IMAGE_NOT_FOUND_IN_BUNDLE            = EC(410044, "image not found in bundle, please recreate bundle for current topology")
+	ERR_GET_CURVEADM_BINARY_PATH_FAILED      = EC(410045, "get curveadm binary path failed")
+	ERR_DECODE_IMAGE_REPO_DIGESTS_FAILED     = EC(410046, "decode image repo digests failed")
+	ERR_IMAGE_DIGEST_NOT_FOUND               = EC(410047, "image digest not found, the image should be pushed to registry")
+	ERR_IMAGE_DIGEST_MISMATCH                = EC(410048, "image digest mismatch between hosts or with the pinned digest")
+	ERR_UNKNOWN_SERVICE_OF_CORE_FILE         = EC(410049, "can't determine which service the core file belongs to")
+	ERR_FORMAT_CHUNKFILE_POOL_FAILED         = EC(410050, "format chunkfile pool failed")
+	ERR_WAIT_FORMAT_DONE_TIMEOUT             = EC(410051, "wait formatting chunkfile pool done timeout")
+	ERR_DECRYPT_TARGET_CREDENTIALS_FAILED    = EC(410052, "decrypt target credentials failed, please check the secret key")
+	ERR_TRACE_TOOL_NOT_FOUND                 = EC(410053, "trace tool not found in image, please specify an image which installed it by --image")
 
 	// 420: common (curvebs client)
 	ERR_VOLUME_ALREADY_MAPPED             = EC(420000, "volume already mapped")
@@ -438,12 +535,20 @@ var (
 	ERR_UNMAP_VOLUME_FAILED               = EC(420006, "unmap volume failed")
 	ERR_OLD_TARGET_DAEMON_IS_ABNORMAL     = EC(420007, "old target daemon is abnormal")
 	ERR_TARGET_DAEMON_IS_ABNORMAL         = EC(420008, "target daemon is abnormal")
+	ERR_MANAGE_VOLUME_FAILED              = EC(420009, "manage volume failed")
+	ERR_SNAPSHOT_CLONE_REQUEST_FAILED     = EC(420010, "request snapshotclone service failed")
+	ERR_DECODE_SNAPSHOT_CLONE_RESPONSE    = EC(420011, "decode snapshotclone service response failed")
+	ERR_VOLUME_ALREADY_EXPORTED           = EC(420012, "volume already exported")
+	ERR_VOLUME_NOT_EXPORTED               = EC(420013, "volume not exported")
+	ERR_EXPORT_VOLUME_FAILED              = EC(420014, "export volume failed")
+	ERR_UNEXPORT_VOLUME_FAILED            = EC(420015, "unexport volume failed")
 
 	// 430: common (curvefs client)
 	ERR_FS_PATH_ALREADY_MOUNTED  = EC(430000, "path already mounted")
 	ERR_CREATE_FILESYSTEM_FAILED = EC(430001, "create filesystem failed")
 	ERR_MOUNT_FILESYSTEM_FAILED  = EC(430002, "mount filesystem failed")
 	ERR_UMOUNT_FILESYSTEM_FAILED = EC(430003, "umount filesystem failed")
+	ERR_MANAGE_FILESYSTEM_FAILED = EC(430004, "manage filesystem failed")
 
 	// 440: common (polarfs)
 	ERR_GET_OS_REELASE_FAILED       = EC(440000, "get os release failed")
@@ -451,7 +556,9 @@ var (
 	ERR_INSTALL_PFSD_PACKAGE_FAILED = EC(440002, "install pfsd package failed")
 
 	// 450: common (playground)
-	ERR_PLAYGROUND_NOT_FOUND = EC(450000, "playground not found")
+	ERR_PLAYGROUND_NOT_FOUND               = EC(450000, "playground not found")
+	ERR_GENERATE_PLAYGROUND_SSH_KEY_FAILED = EC(450001, "generate ssh key for playground failed")
+	ERR_INIT_PLAYGROUND_NODE_FAILED        = EC(450002, "init playground node failed")
 
 	// 500: checker (topology/s3)
 	ERR_INVALID_S3_ACCESS_KEY  = EC(500000, "invalid S3 access key")
@@ -553,6 +660,7 @@ var (
 	ERR_INSTALL_OR_REMOVE_DEBIAN_PACKAGE_FAILED    = EC(620024, "install or remove debian package failed (dpkg)")
 	ERR_INSTALL_OR_REMOVE_RPM_PACKAGE_FAILED       = EC(620025, "install or remove rpm package failed (rpm)")
 	ERR_SECURE_COPY_FILE_TO_REMOTE_FAILED          = EC(620026, "secure copy file to remote failed (scp)")
+	ERR_CONTROL_SYSTEMD_UNIT_FAILED                = EC(620027, "control systemd unit failed (systemctl)")
 	ERR_RUN_SCRIPT_FAILED                          = EC(620998, "run script failed (bash script.sh)")
 	ERR_RUN_A_BASH_COMMAND_FAILED                  = EC(620999, "run a bash command failed (bash -c)")
 
@@ -572,6 +680,11 @@ var (
 	ERR_INSPECT_CONTAINER_FAILED         = EC(630012, "get container low-level information failed")
 	ERR_GET_CONTAINER_LOGS_FAILED        = EC(630013, "get container logs failed")
 	ERR_UPDATE_CONTAINER_FAILED          = EC(630014, "update container failed")
+	ERR_TAG_IMAGE_FAILED                 = EC(630015, "tag image failed")
+	ERR_SAVE_IMAGES_FAILED               = EC(630016, "save images to tar archive failed")
+	ERR_LOAD_IMAGE_FAILED                = EC(630017, "load image from tar archive failed")
+	ERR_INSPECT_IMAGE_FAILED             = EC(630018, "get image low-level information failed")
+	ERR_RUN_CONTAINER_FAILED             = EC(630019, "run container failed")
 
 	// 690: execuetr task (others)
 	ERR_START_CRONTAB_IN_CONTAINER_FAILED = EC(690000, "start crontab in container failed")
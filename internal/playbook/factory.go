@@ -57,6 +57,8 @@ const (
 
 	// common
 	PULL_IMAGE
+	RESOLVE_IMAGE_DIGEST
+	VERIFY_IMAGE_DIGEST
 	CREATE_CONTAINER
 	SYNC_CONFIG
 	START_SERVICE
@@ -73,15 +75,27 @@ const (
 	UPDATE_TOPOLOGY
 	INIT_SERVIE_STATUS
 	GET_SERVICE_STATUS
+	GET_SERVICE_IMAGE
+	LIST_CORES
+	ANALYZE_CORE
+	FETCH_CORE
+	TRACE_PROCESS
 	CLEAN_SERVICE
 	INIT_SUPPORT
 	COLLECT_REPORT
 	COLLECT_CURVEADM
 	COLLECT_SERVICE
+	STREAM_SERVICE_LOGS
 	COLLECT_CLIENT
+	PACK_SUPPORT_BUNDLE
+	CREATE_BUNDLE
+	UNPACK_BUNDLE
+	LOAD_BUNDLE_IMAGES
 	BACKUP_ETCD_DATA
 	CHECK_MDS_ADDRESS
 	GET_CLIENT_STATUS
+	SCAN_CLIENT_CONTAINERS
+	START_CLIENT_CONTAINER
 	INSTALL_CLIENT
 	UNINSTALL_CLIENT
 
@@ -95,6 +109,13 @@ const (
 	MAP_IMAGE
 	UNMAP_IMAGE
 	CLEAN_FORMAT
+	DISCOVER_DISKS
+	RETIRE_CHUNKSERVER
+	UMOUNT_DISK
+	WAIT_FORMAT_DONE
+	WAIT_CHUNKSERVER_REGISTERED
+	MANAGE_VOLUME
+	REQUEST_SNAPSHOT_CLONE
 
 	// monitor
 	PULL_MONITOR_IMAGE
@@ -126,10 +147,15 @@ const (
 	DELETE_TARGET
 	LIST_TARGETS
 
+	// bs/export
+	ADD_EXPORT
+	DELETE_EXPORT
+
 	// fs
 	CHECK_CLIENT_S3
 	MOUNT_FILESYSTEM
 	UMOUNT_FILESYSTEM
+	MANAGE_FILESYSTEM
 
 	// polarfs
 	DETECT_OS_RELEASE
@@ -138,6 +164,7 @@ const (
 
 	// playground
 	CREATE_PLAYGROUND
+	CREATE_PLAYGROUND_NODES
 	INIT_PLAYGROUND
 	START_PLAYGROUND
 	REMOVE_PLAYGROUND
@@ -214,6 +241,10 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		// common
 		case PULL_IMAGE:
 			t, err = comm.NewPullImageTask(curveadm, config.GetDC(i))
+		case RESOLVE_IMAGE_DIGEST:
+			t, err = comm.NewResolveImageDigestTask(curveadm, config.GetDC(i))
+		case VERIFY_IMAGE_DIGEST:
+			t, err = comm.NewVerifyImageDigestTask(curveadm, nil)
 		case CREATE_CONTAINER:
 			t, err = comm.NewCreateContainerTask(curveadm, config.GetDC(i))
 		case SYNC_CONFIG:
@@ -240,6 +271,16 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewInitServiceStatusTask(curveadm, config.GetDC(i))
 		case GET_SERVICE_STATUS:
 			t, err = comm.NewGetServiceStatusTask(curveadm, config.GetDC(i))
+		case GET_SERVICE_IMAGE:
+			t, err = comm.NewGetServiceImageTask(curveadm, config.GetDC(i))
+		case LIST_CORES:
+			t, err = comm.NewListCoresTask(curveadm, config.GetDC(i))
+		case ANALYZE_CORE:
+			t, err = comm.NewAnalyzeCoreTask(curveadm, config.GetDC(i))
+		case FETCH_CORE:
+			t, err = comm.NewFetchCoreTask(curveadm, config.GetDC(i))
+		case TRACE_PROCESS:
+			t, err = comm.NewTraceTask(curveadm, nil)
 		case CLEAN_SERVICE:
 			t, err = comm.NewCleanServiceTask(curveadm, config.GetDC(i))
 		case INIT_SUPPORT:
@@ -250,12 +291,26 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewCollectCurveAdmTask(curveadm, config.GetDC(i))
 		case COLLECT_SERVICE:
 			t, err = comm.NewCollectServiceTask(curveadm, config.GetDC(i))
+		case STREAM_SERVICE_LOGS:
+			t, err = comm.NewStreamLogsTask(curveadm, config.GetDC(i))
 		case COLLECT_CLIENT:
 			t, err = comm.NewCollectClientTask(curveadm, config.GetAny(i))
+		case PACK_SUPPORT_BUNDLE:
+			t, err = comm.NewPackSupportBundleTask(curveadm, config.GetDC(i))
+		case CREATE_BUNDLE:
+			t, err = comm.NewCreateBundleTask(curveadm, config.GetDC(i))
+		case UNPACK_BUNDLE:
+			t, err = comm.NewUnpackBundleTask(curveadm, config.GetHC(i))
+		case LOAD_BUNDLE_IMAGES:
+			t, err = comm.NewLoadBundleImagesTask(curveadm, config.GetHC(i))
 		case BACKUP_ETCD_DATA:
 			t, err = comm.NewBackupEtcdDataTask(curveadm, config.GetDC(i))
 		case GET_CLIENT_STATUS:
 			t, err = comm.NewGetClientStatusTask(curveadm, config.GetAny(i))
+		case SCAN_CLIENT_CONTAINERS:
+			t, err = comm.NewScanClientContainersTask(curveadm, config.GetHC(i))
+		case START_CLIENT_CONTAINER:
+			t, err = comm.NewStartClientContainerTask(curveadm, config.GetAny(i))
 		case INSTALL_CLIENT:
 			t, err = comm.NewInstallClientTask(curveadm, config.GetCC(i))
 		case UNINSTALL_CLIENT:
@@ -269,6 +324,20 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = bs.NewStopFormatTask(curveadm, config.GetFC(i))
 		case CLEAN_FORMAT:
 			t, err = bs.NewCleanFormatTask(curveadm, config.GetFC(i))
+		case DISCOVER_DISKS:
+			t, err = bs.NewDiscoverDisksTask(curveadm, config.GetHC(i))
+		case RETIRE_CHUNKSERVER:
+			t, err = bs.NewRetireChunkServerTask(curveadm, config.GetDC(i))
+		case UMOUNT_DISK:
+			t, err = bs.NewUmountDiskTask(curveadm, config.GetFC(i))
+		case WAIT_FORMAT_DONE:
+			t, err = bs.NewWaitFormatDoneTask(curveadm, config.GetFC(i))
+		case WAIT_CHUNKSERVER_REGISTERED:
+			t, err = bs.NewWaitChunkServerRegisteredTask(curveadm, config.GetDC(i))
+		case MANAGE_VOLUME:
+			t, err = bs.NewManageVolumeTask(curveadm, config.GetDC(i))
+		case REQUEST_SNAPSHOT_CLONE:
+			t, err = bs.NewSnapshotCloneTask(curveadm, config.GetDC(i))
 		case BALANCE_LEADER:
 			t, err = bs.NewBalanceTask(curveadm, config.GetDC(i))
 		case START_NEBD_SERVICE:
@@ -278,7 +347,7 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		case MAP_IMAGE:
 			t, err = bs.NewMapTask(curveadm, config.GetCC(i))
 		case UNMAP_IMAGE:
-			t, err = bs.NewUnmapTask(curveadm, nil)
+			t, err = bs.NewUnmapTask(curveadm, config.GetAny(i))
 		// bs/target
 		case START_TARGET_DAEMON:
 			t, err = bs.NewStartTargetDaemonTask(curveadm, config.GetCC(i))
@@ -290,13 +359,20 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = bs.NewDeleteTargetTask(curveadm, nil)
 		case LIST_TARGETS:
 			t, err = bs.NewListTargetsTask(curveadm, nil)
+		// bs/export
+		case ADD_EXPORT:
+			t, err = bs.NewAddExportTask(curveadm, config.GetCC(i))
+		case DELETE_EXPORT:
+			t, err = bs.NewDeleteExportTask(curveadm, nil)
 		// fs
 		case CHECK_CLIENT_S3:
 			t, err = checker.NewClientS3ConfigureTask(curveadm, config.GetCC(i))
 		case MOUNT_FILESYSTEM:
 			t, err = fs.NewMountFSTask(curveadm, config.GetCC(i))
 		case UMOUNT_FILESYSTEM:
-			t, err = fs.NewUmountFSTask(curveadm, config.GetCC(i))
+			t, err = fs.NewUmountFSTask(curveadm, config.GetAny(i))
+		case MANAGE_FILESYSTEM:
+			t, err = fs.NewManageFilesystemTask(curveadm, config.GetDC(i))
 		// polarfs
 		case DETECT_OS_RELEASE:
 			t, err = bs.NewDetectOSReleaseTask(curveadm, nil)
@@ -307,6 +383,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		// playground
 		case CREATE_PLAYGROUND:
 			t, err = pg.NewCreatePlaygroundTask(curveadm, config.GetPGC(i))
+		case CREATE_PLAYGROUND_NODES:
+			t, err = pg.NewCreatePlaygroundNodesTask(curveadm, config.GetPGC(i))
 		case INIT_PLAYGROUND:
 			t, err = pg.NewInitPlaygroundTask(curveadm, config.GetPGC(i))
 		case START_PLAYGROUND:
@@ -370,6 +448,7 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t.SetTid(config.GetDC(i).GetId())
 			t.SetPtid(config.GetDC(i).GetParentId())
 		}
+		t.SetCorrelationId(curveadm.AuditId())
 		ts.AddTask(t)
 	}
 
@@ -26,6 +26,7 @@ package playbook
 
 import (
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/task/task"
 	"github.com/opencurve/curveadm/internal/tasks"
 )
 
@@ -61,7 +62,8 @@ type (
 		postSteps []*PlaybookStep
 	}
 
-	ExecOptions = tasks.ExecOptions
+	ExecOptions    = tasks.ExecOptions
+	PolicyOverride = task.PolicyOverride
 )
 
 func NewPlaybook(curveadm *cli.CurveAdm) *Playbook {
@@ -80,15 +82,19 @@ func (p *Playbook) AddPostStep(s *PlaybookStep) {
 }
 
 func (p *Playbook) run(steps []*PlaybookStep) error {
+	var lastErr error
 	for i, step := range steps {
 		tasks, err := p.createTasks(step)
 		if err != nil {
 			return err
 		}
 
+		// NOTE: continue to execute next step only if the step opted in
 		err = tasks.Execute(step.ExecOptions)
-		if err != nil {
+		if err != nil && !step.ExecOptions.ContinueOnError {
 			return err
+		} else if err != nil {
+			lastErr = err
 		}
 
 		isLast := (i == len(steps)-1)
@@ -96,7 +102,7 @@ func (p *Playbook) run(steps []*PlaybookStep) error {
 			p.curveadm.WriteOutln("")
 		}
 	}
-	return nil
+	return lastErr
 }
 
 func (p *Playbook) Run() error {
@@ -0,0 +1,254 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package archive
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+/*
+ * cluster archive (JSON):
+ *   {
+ *     "format": "curveadm-cluster-archive",
+ *     "version": 1,
+ *     "encryption": null,       // or {"cipher": "aes-256-gcm", "kdf": "pbkdf2-sha256", ...}
+ *     "archive": {...},         // plaintext archive, iff not encrypted
+ *     "ciphertext": "..."       // encrypted archive (base64), iff encrypted
+ *   }
+ */
+const (
+	FORMAT  = "curveadm-cluster-archive"
+	VERSION = 1
+
+	CIPHER_AES_256_GCM = "aes-256-gcm"
+	KDF_PBKDF2_SHA256  = "pbkdf2-sha256"
+
+	DEFAULT_KDF_ITERATIONS = 100000
+	KEY_LENGTH             = 32
+	SALT_LENGTH            = 16
+)
+
+var (
+	ErrUnsupportFormat = fmt.Errorf("unsupport cluster archive format")
+	ErrDecryptFailed   = fmt.Errorf("message authentication failed")
+)
+
+type (
+	Cluster struct {
+		Name        string    `json:"name"`
+		UUId        string    `json:"uuid"`
+		Description string    `json:"description"`
+		CreateTime  time.Time `json:"create_time"`
+		Topology    string    `json:"topology"`
+		Pool        string    `json:"pool"`
+	}
+
+	Service struct {
+		Id          string `json:"id"`
+		ContainerId string `json:"container_id"`
+	}
+
+	Disk struct {
+		Host               string `json:"host"`
+		Device             string `json:"device"`
+		Size               string `json:"size"`
+		URI                string `json:"uri"`
+		MountPoint         string `json:"mount_point"`
+		FormatPercent      int    `json:"format_percent"`
+		ContainerImage     string `json:"container_image"`
+		ChunkServerID      string `json:"chunkserver_id"`
+		ServiceMountDevice int    `json:"service_mount_device"`
+	}
+
+	ImageDigest struct {
+		ServiceId string `json:"service_id"`
+		Image     string `json:"image"`
+		Digest    string `json:"digest"`
+	}
+
+	Client struct {
+		Id          string `json:"id"`
+		Kind        string `json:"kind"`
+		Host        string `json:"host"`
+		ContainerId string `json:"container_id"`
+		AuxInfo     string `json:"aux_info"`
+	}
+
+	Archive struct {
+		CurveAdmVersion string    `json:"curveadm_version"`
+		ExportTime      time.Time `json:"export_time"`
+		Cluster         Cluster   `json:"cluster"`
+		Services        []Service `json:"services"`
+		Monitor         string    `json:"monitor"`
+		Hosts           string    `json:"hosts"`
+		Disks           string    `json:"disks"`
+		DiskRecords     []Disk    `json:"disk_records"`
+		Clients         []Client  `json:"clients"`
+		// pinned image digests of services, empty in legacy archive
+		ImageDigests []ImageDigest `json:"image_digests,omitempty"`
+		// SSH private keys which referenced by hosts, key is the file path
+		Secrets map[string]string `json:"secrets,omitempty"`
+	}
+
+	Encryption struct {
+		Cipher     string `json:"cipher"`
+		KDF        string `json:"kdf"`
+		Iterations int    `json:"iterations"`
+		Salt       []byte `json:"salt"`
+		Nonce      []byte `json:"nonce"`
+	}
+
+	envelope struct {
+		Format     string      `json:"format"`
+		Version    int         `json:"version"`
+		Encryption *Encryption `json:"encryption"`
+		Archive    *Archive    `json:"archive,omitempty"`
+		Ciphertext []byte      `json:"ciphertext,omitempty"`
+	}
+)
+
+func newGCM(passphrase string, encryption *Encryption) (cipher.AEAD, error) {
+	key := pbkdf2.Key([]byte(passphrase), encryption.Salt,
+		encryption.Iterations, KEY_LENGTH, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encrypt(plaintext []byte, passphrase string) (*Encryption, []byte, error) {
+	encryption := &Encryption{
+		Cipher:     CIPHER_AES_256_GCM,
+		KDF:        KDF_PBKDF2_SHA256,
+		Iterations: DEFAULT_KDF_ITERATIONS,
+		Salt:       make([]byte, SALT_LENGTH),
+	}
+	if _, err := rand.Read(encryption.Salt); err != nil {
+		return nil, nil, err
+	}
+
+	gcm, err := newGCM(passphrase, encryption)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryption.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(encryption.Nonce); err != nil {
+		return nil, nil, err
+	}
+	return encryption, gcm.Seal(nil, encryption.Nonce, plaintext, nil), nil
+}
+
+func decrypt(ciphertext []byte, passphrase string, encryption *Encryption) ([]byte, error) {
+	if encryption.Cipher != CIPHER_AES_256_GCM || encryption.KDF != KDF_PBKDF2_SHA256 {
+		return nil, fmt.Errorf("unsupport encryption: %s/%s", encryption.Cipher, encryption.KDF)
+	}
+
+	gcm, err := newGCM(passphrase, encryption)
+	if err != nil {
+		return nil, err
+	} else if len(encryption.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size: %d", len(encryption.Nonce))
+	}
+
+	plaintext, err := gcm.Open(nil, encryption.Nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptFailed
+	}
+	return plaintext, nil
+}
+
+// Marshal encodes the archive, it will be encrypted iff passphrase is not empty
+func Marshal(archive *Archive, passphrase string) ([]byte, error) {
+	e := envelope{Format: FORMAT, Version: VERSION}
+	if len(passphrase) == 0 {
+		e.Archive = archive
+	} else {
+		plaintext, err := json.Marshal(archive)
+		if err != nil {
+			return nil, err
+		}
+		e.Encryption, e.Ciphertext, err = encrypt(plaintext, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.MarshalIndent(e, "", "  ")
+}
+
+func decode(data []byte) (*envelope, error) {
+	e := &envelope{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, ErrUnsupportFormat
+	} else if e.Format != FORMAT {
+		return nil, ErrUnsupportFormat
+	} else if e.Version <= 0 || e.Version > VERSION {
+		return nil, fmt.Errorf("unsupport cluster archive version %d, "+
+			"supported version: %d", e.Version, VERSION)
+	}
+	return e, nil
+}
+
+// IsArchive returns false for the legacy export file which is not JSON
+func IsArchive(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+func IsEncrypted(data []byte) (bool, error) {
+	e, err := decode(data)
+	if err != nil {
+		return false, err
+	}
+	return e.Encryption != nil, nil
+}
+
+func Unmarshal(data []byte, passphrase string) (*Archive, error) {
+	e, err := decode(data)
+	if err != nil {
+		return nil, err
+	} else if e.Encryption == nil {
+		if e.Archive == nil {
+			return nil, fmt.Errorf("cluster archive is empty")
+		}
+		return e.Archive, nil
+	}
+
+	plaintext, err := decrypt(e.Ciphertext, passphrase, e.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	archive := &Archive{}
+	if err := json.Unmarshal(plaintext, archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
@@ -0,0 +1,93 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package archive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newArchive() *Archive {
+	return &Archive{
+		CurveAdmVersion: "0.2.0",
+		Cluster: Cluster{
+			Name:     "my-cluster",
+			UUId:     "0123456789ABCDEF",
+			Topology: "kind: curvebs",
+			Pool:     "{}",
+		},
+		Services: []Service{{Id: "c9570d1a1f15", ContainerId: "3a2a3e0a8a7e"}},
+		ImageDigests: []ImageDigest{{
+			ServiceId: "c9570d1a1f15",
+			Image:     "opencurvedocker/curvebs:v1.2",
+			Digest:    "sha256:4d7a8f1e",
+		}},
+		Secrets: map[string]string{"/root/.ssh/id_rsa": "PRIVATE KEY"},
+	}
+}
+
+func TestArchive_Plaintext(t *testing.T) {
+	assert := assert.New(t)
+	data, err := Marshal(newArchive(), "")
+	assert.Nil(err)
+	assert.True(IsArchive(data))
+
+	encrypted, err := IsEncrypted(data)
+	assert.Nil(err)
+	assert.False(encrypted)
+
+	archive, err := Unmarshal(data, "")
+	assert.Nil(err)
+	assert.Equal(newArchive(), archive)
+}
+
+func TestArchive_Encrypted(t *testing.T) {
+	assert := assert.New(t)
+	data, err := Marshal(newArchive(), "passphrase")
+	assert.Nil(err)
+	assert.False(strings.Contains(string(data), "PRIVATE KEY"))
+
+	encrypted, err := IsEncrypted(data)
+	assert.Nil(err)
+	assert.True(encrypted)
+
+	archive, err := Unmarshal(data, "passphrase")
+	assert.Nil(err)
+	assert.Equal(newArchive(), archive)
+
+	_, err = Unmarshal(data, "wrong passphrase")
+	assert.Equal(ErrDecryptFailed, err)
+}
+
+func TestArchive_Unsupport(t *testing.T) {
+	assert := assert.New(t)
+	assert.False(IsArchive([]byte("--- 0001 11\nmy-cluster\n")))
+
+	_, err := Unmarshal([]byte(`{"format": "unknown", "version": 1}`), "")
+	assert.Equal(ErrUnsupportFormat, err)
+
+	_, err = Unmarshal([]byte(`{"format": "curveadm-cluster-archive", "version": 100}`), "")
+	assert.NotNil(err)
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type MySQLDriver struct {
+	baseDriver
+}
+
+var _ IDataBaseDriver = (*MySQLDriver)(nil)
+
+func NewMySQLDriver() *MySQLDriver {
+	return &MySQLDriver{
+		baseDriver: baseDriver{
+			replacer: strings.NewReplacer(
+				MACRO_ID, "INTEGER PRIMARY KEY AUTO_INCREMENT",
+				MACRO_KEY, "VARCHAR(255)",
+				MACRO_DATETIME, "DATETIME(6)",
+			),
+		},
+	}
+}
+
+func (d *MySQLDriver) Name() string {
+	return DRIVER_MYSQL
+}
+
+func (d *MySQLDriver) Open(dsn string) error {
+	config, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return err
+	}
+
+	// scan DATETIME into time.Time and report matched rows
+	// instead of changed rows for UPDATE statement
+	config.ParseTime = true
+	config.ClientFoundRows = true
+	config.MultiStatements = true
+	return d.open("mysql", config.FormatDSN())
+}
+
+func (d *MySQLDriver) Insert(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	return d.insert(tx, d.Rebind(query), args...)
+}
+
+func (d *MySQLDriver) TableExist(name string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_name = ?`, name)
+}
+
+func (d *MySQLDriver) ColumnExist(table, column string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?`, table, column)
+}
+
+func (d *MySQLDriver) IndexExist(table, index string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?`, table, index)
+}
+
+// the named lock belongs to session, so we hold a dedicated connection until unlock
+func (d *MySQLDriver) Lock(name string) (func(), error) {
+	ctx := context.Background()
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired sql.NullInt64
+	err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, LOCK_TIMEOUT).Scan(&acquired)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	} else if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return nil, fmt.Errorf("acquire lock '%s' timeout after %d seconds", name, LOCK_TIMEOUT)
+	}
+
+	return func() {
+		conn.ExecContext(ctx, "DO RELEASE_LOCK(?)", name)
+		conn.Close()
+	}, nil
+}
+
+func (d *MySQLDriver) Backup(tag string) (string, error) {
+	return "", nil
+}
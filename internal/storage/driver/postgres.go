@@ -0,0 +1,141 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+type PostgresDriver struct {
+	baseDriver
+}
+
+var _ IDataBaseDriver = (*PostgresDriver)(nil)
+
+func NewPostgresDriver() *PostgresDriver {
+	return &PostgresDriver{
+		baseDriver: baseDriver{
+			replacer: strings.NewReplacer(
+				MACRO_ID, "SERIAL PRIMARY KEY",
+				MACRO_KEY, "TEXT",
+				MACRO_DATETIME, "TIMESTAMP",
+			),
+		},
+	}
+}
+
+func (d *PostgresDriver) Name() string {
+	return DRIVER_POSTGRES
+}
+
+func (d *PostgresDriver) Open(dsn string) error {
+	return d.open("postgres", dsn)
+}
+
+// postgres use $1, $2... as placeholder
+func (d *PostgresDriver) Rebind(query string) string {
+	query = d.baseDriver.Rebind(query)
+	var sb strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+		} else {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+func (d *PostgresDriver) insertQuery(query string) string {
+	return d.Rebind(query) + " RETURNING id"
+}
+
+// postgres doesn't support LastInsertId(), we use RETURNING clause instead
+func (d *PostgresDriver) Insert(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	var id int64
+	query = d.insertQuery(query)
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(query, args...).Scan(&id)
+	} else {
+		err = d.db.QueryRow(query, args...).Scan(&id)
+	}
+	if err != nil {
+		return -1, err
+	}
+	return id, nil
+}
+
+func (d *PostgresDriver) TableExist(name string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_name = $1`, name)
+}
+
+func (d *PostgresDriver) ColumnExist(table, column string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2`, table, column)
+}
+
+func (d *PostgresDriver) IndexExist(table, index string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM pg_indexes
+		WHERE schemaname = current_schema() AND tablename = $1 AND indexname = $2`, table, index)
+}
+
+// advisory lock is identified by integer key, which hashed from the name
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// the advisory lock belongs to session, so we hold a dedicated connection until unlock
+func (d *PostgresDriver) Lock(name string) (func(), error) {
+	ctx := context.Background()
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := advisoryLockKey(name)
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return func() {
+		conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", key)
+		conn.Close()
+	}, nil
+}
+
+func (d *PostgresDriver) Backup(tag string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package driver
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type SQLiteDriver struct {
+	baseDriver
+	dbfile string
+}
+
+var _ IDataBaseDriver = (*SQLiteDriver)(nil)
+
+func NewSQLiteDriver() *SQLiteDriver {
+	return &SQLiteDriver{
+		baseDriver: baseDriver{
+			replacer: strings.NewReplacer(
+				MACRO_ID, "INTEGER PRIMARY KEY AUTOINCREMENT",
+				MACRO_KEY, "TEXT",
+				MACRO_DATETIME, "DATE",
+			),
+		},
+	}
+}
+
+func (d *SQLiteDriver) Name() string {
+	return DRIVER_SQLITE
+}
+
+func (d *SQLiteDriver) Open(dsn string) error {
+	// dsn: /path/to/curveadm.db or file:/path/to/curveadm.db?cache=shared
+	dbfile := strings.TrimPrefix(dsn, "file:")
+	if i := strings.Index(dbfile, "?"); i >= 0 {
+		dbfile = dbfile[:i]
+	}
+	d.dbfile = dbfile
+	return d.open("sqlite3", dsn)
+}
+
+func (d *SQLiteDriver) Insert(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	return d.insert(tx, d.Rebind(query), args...)
+}
+
+func (d *SQLiteDriver) TableExist(name string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name)
+}
+
+func (d *SQLiteDriver) ColumnExist(table, column string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column)
+}
+
+func (d *SQLiteDriver) IndexExist(table, index string) (bool, error) {
+	return d.exist(`SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'index' AND tbl_name = ? AND name = ?`, table, index)
+}
+
+// lock file: /path/to/curveadm.db-$NAME.lock
+func (d *SQLiteDriver) Lock(name string) (func(), error) {
+	lockfile := fmt.Sprintf("%s-%s.lock", d.dbfile, name)
+	f, err := os.OpenFile(lockfile, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	} else if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// backup file: /path/to/curveadm.db-$TAG-$TIMESTAMP.backup
+func (d *SQLiteDriver) Backup(tag string) (string, error) {
+	if _, err := os.Stat(d.dbfile); err != nil {
+		return "", err
+	}
+
+	// VACUUM INTO generates a consistent copy of database,
+	// which is safer than copying the file directly
+	backup := fmt.Sprintf("%s-%s-%d.backup", d.dbfile, tag, time.Now().Unix())
+	_, err := d.db.Exec("VACUUM INTO ?", backup)
+	if err != nil {
+		return "", err
+	}
+	return backup, nil
+}
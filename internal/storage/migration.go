@@ -0,0 +1,287 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage/driver"
+	"github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+/*
+ * Every change of database schema should be appended as a new migration
+ * with increasing version, NEVER modify the migration which already released.
+ */
+type Migration struct {
+	Version     int
+	Description string
+	Statements  []string
+}
+
+const (
+	MIGRATION_LOCK = "curveadm_migrate"
+)
+
+var (
+	// MySQL commits DDL statement implicitly, a failed migration may leave
+	// some columns or indexes created, we detect them by these patterns and
+	// skip them when the migration is applied again
+	REGEX_ADD_COLUMN   = regexp.MustCompile(`(?i)^\s*ALTER\s+TABLE\s+(\w+)\s+ADD\s+COLUMN\s+(\w+)`)
+	REGEX_CREATE_INDEX = regexp.MustCompile(`(?i)^\s*CREATE\s+INDEX\s+(\w+)\s+ON\s+(\w+)`)
+)
+
+var MIGRATIONS = []Migration{
+	{
+		Version:     1,
+		Description: "initial schema",
+		Statements: []string{
+			CREATE_VERSION_TABLE,
+			CREATE_HOSTS_TABLE,
+			CREATE_CLUSTERS_TABLE,
+			CREATE_CONTAINERS_TABLE,
+			CREATE_CLIENTS_TABLE,
+			CREATE_PLAYGROUND_TABLE,
+			CREATE_AUDIT_TABLE,
+			CREATE_DISKS_TABLE,
+			CREATE_DISK_TABLE,
+			CREATE_MONITOR_TABLE,
+		},
+	},
+	{
+		Version:     2,
+		Description: "add pool column to clusters table",
+		Statements: []string{
+			ADD_CLUSTERS_POOL_COLUMN,
+			FILL_CLUSTERS_POOL_COLUMN,
+		},
+	},
+	{
+		Version:     3,
+		Description: "add history table",
+		Statements: []string{
+			CREATE_HISTORY_TABLE,
+			CREATE_HISTORY_INDEX,
+		},
+	},
+	{
+		Version:     4,
+		Description: "add image digests table",
+		Statements: []string{
+			CREATE_IMAGE_DIGESTS_TABLE,
+		},
+	},
+	{
+		Version:     5,
+		Description: "add operator, cluster, revision and duration columns to audit table",
+		Statements: []string{
+			ADD_AUDIT_USERNAME_COLUMN,
+			ADD_AUDIT_CLIENT_COLUMN,
+			ADD_AUDIT_TOKEN_COLUMN,
+			ADD_AUDIT_CLUSTER_ID_COLUMN,
+			ADD_AUDIT_REVISION_BEFORE_COLUMN,
+			ADD_AUDIT_REVISION_AFTER_COLUMN,
+			ADD_AUDIT_DURATION_COLUMN,
+			FILL_AUDIT_OPERATOR_COLUMNS,
+		},
+	},
+	{
+		Version:     6,
+		Description: "add argv column to audit table",
+		Statements: []string{
+			ADD_AUDIT_ARGV_COLUMN,
+		},
+	},
+}
+
+func LatestSchemaVersion() int {
+	return MIGRATIONS[len(MIGRATIONS)-1].Version
+}
+
+func (s *dbStorage) schemaVersion() (int, error) {
+	version := 0
+	err := s.db.DB().QueryRow(s.db.Rebind(SELECT_SCHEMA_VERSION)).Scan(&version)
+	return version, err
+}
+
+/*
+ * The SQLite database which created before schema migrations introduced
+ * has no schema_migrations table, we detect its version by clusters table.
+ */
+func (s *dbStorage) legacyVersion() (int, error) {
+	if s.db.Name() != driver.DRIVER_SQLITE {
+		return 0, nil
+	}
+
+	exist, err := s.db.TableExist("clusters")
+	if err != nil || !exist {
+		return 0, err
+	}
+
+	exist, err = s.db.ColumnExist("clusters", "pool")
+	if err != nil {
+		return 0, err
+	} else if !exist {
+		return 1, nil
+	}
+	return 2, nil
+}
+
+// return true if the column or index which statement creates already exist
+func (s *dbStorage) applied(statement string) (bool, error) {
+	if mu := REGEX_ADD_COLUMN.FindStringSubmatch(statement); mu != nil {
+		return s.db.ColumnExist(mu[1], mu[2])
+	} else if mu := REGEX_CREATE_INDEX.FindStringSubmatch(statement); mu != nil {
+		return s.db.IndexExist(mu[2], mu[1])
+	}
+	return false, nil
+}
+
+func (s *dbStorage) applyMigration(m Migration, skip bool) error {
+	statements := []string{}
+	for _, statement := range m.Statements {
+		if skip {
+			break
+		} else if applied, err := s.applied(statement); err != nil {
+			return err
+		} else if !applied {
+			statements = append(statements, statement)
+		}
+	}
+
+	tx, err := s.db.DB().Begin()
+	if err != nil {
+		return err
+	}
+
+	err = func() error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(s.db.Rebind(statement)); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(s.db.Rebind(INSERT_SCHEMA_MIGRATION),
+			m.Version, m.Description, time.Now())
+		return err
+	}()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *dbStorage) backup(version int) error {
+	location, err := s.db.Backup(fmt.Sprintf("v%d", version))
+	if err != nil {
+		return errno.ERR_BACKUP_DATABASE_FAILED.E(err)
+	} else if len(location) > 0 {
+		log.Info("Backup database before migrating",
+			log.Field("SchemaVersion", version),
+			log.Field("Backup", location))
+	}
+	return nil
+}
+
+/*
+ * migrate steps (all steps are protected by a lock across processes):
+ *   1) refuse to run if database schema is newer than current curveadm,
+ *      which means the curveadm has been downgraded
+ *   2) backup the database if there are pending migrations
+ *   3) apply pending migrations one by one, each in a transaction,
+ *      and the column or index which already created will be skipped
+ */
+func (s *dbStorage) migrate() error {
+	unlock, err := s.db.Lock(MIGRATION_LOCK)
+	if err != nil {
+		return errno.ERR_MIGRATE_DATABASE_FAILED.F("acquire migration lock: %s", err)
+	}
+	defer unlock()
+
+	exist, err := s.db.TableExist("schema_migrations")
+	if err != nil {
+		return err
+	}
+
+	legacy := 0
+	if !exist {
+		if legacy, err = s.legacyVersion(); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.DB().Exec(s.db.Rebind(CREATE_SCHEMA_MIGRATIONS_TABLE))
+	if err != nil {
+		return err
+	}
+
+	current, err := s.schemaVersion()
+	if err != nil {
+		return err
+	}
+
+	// (1) refuse to run against newer database
+	latest := LatestSchemaVersion()
+	if current > latest {
+		return errno.ERR_DATABASE_SCHEMA_IS_NEWER_THAN_CURVEADM.
+			F("database schema version: %d, supported schema version: %d", current, latest)
+	}
+
+	pending := []Migration{}
+	for _, m := range MIGRATIONS {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// (2) backup database which has data
+	if current > 0 || legacy > 0 {
+		if err := s.backup(utils.Max(current, legacy)); err != nil {
+			return err
+		}
+	}
+
+	// (3) apply pending migrations
+	for _, m := range pending {
+		// the tables of legacy database may be incomplete, but all
+		// statements in initial schema are idempotent, so we still
+		// execute it and only skip the later migrations.
+		skip := m.Version <= legacy && m.Version != 1
+		if err := s.applyMigration(m, skip); err != nil {
+			return errno.ERR_MIGRATE_DATABASE_FAILED.
+				F("migration #%d (%s): %s", m.Version, m.Description, err)
+		}
+		log.Info("Apply database migration success",
+			log.Field("Version", m.Version),
+			log.Field("Description", m.Description))
+	}
+	return nil
+}
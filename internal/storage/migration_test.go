@@ -0,0 +1,276 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	LEGACY_CLUSTERS_TABLE = `
+		CREATE TABLE clusters (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			uuid TEXT NOT NULL,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			topology TEXT NULL,
+			create_time DATE NOT NULL,
+			current INTEGER DEFAULT 0
+		)
+	`
+	LEGACY_INSERT_CLUSTER = `
+		INSERT INTO clusters(uuid, name, description, topology, create_time)
+		VALUES(hex(randomblob(16)), 'legacy', '', 'topology', datetime('now','localtime'))
+	`
+	LEGACY_AUDIT_TABLE = `
+		CREATE TABLE audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			execute_time DATE NOT NULL,
+			work_directory TEXT NOT NULL,
+			command TEXT NOT NULL,
+			status INTEGER DEFAULT 0,
+			error_code INTEGER DEFAULT 0
+		)
+	`
+	LEGACY_INSERT_AUDIT_LOG = `
+		INSERT INTO audit(execute_time, work_directory, command, status)
+		VALUES(datetime('now','localtime'), '/root', 'curveadm deploy', 1)
+	`
+)
+
+func execSQLite(t *testing.T, dbfile string, statements ...string) {
+	assert := assert.New(t)
+	db, err := sql.Open("sqlite3", dbfile)
+	assert.Nil(err)
+	defer db.Close()
+	for _, statement := range statements {
+		_, err := db.Exec(statement)
+		assert.Nil(err)
+	}
+}
+
+func backups(t *testing.T, dbfile string) []string {
+	files, err := filepath.Glob(dbfile + "-*.backup")
+	assert.Nil(t, err)
+	return files
+}
+
+func TestMigrate_FreshDatabase(t *testing.T) {
+	assert := assert.New(t)
+	dbfile := path.Join(t.TempDir(), "curveadm.db")
+
+	s, err := NewStorage("sqlite://" + dbfile)
+	assert.Nil(err)
+	version, err := s.(*dbStorage).schemaVersion()
+	assert.Nil(err)
+	assert.Equal(LatestSchemaVersion(), version)
+	assert.Nil(s.Close())
+
+	// nothing to do for the second time
+	s, err = NewStorage("sqlite://" + dbfile)
+	assert.Nil(err)
+	assert.Nil(s.Close())
+	assert.Len(backups(t, dbfile), 0)
+}
+
+func TestMigrate_LegacyDatabase(t *testing.T) {
+	assert := assert.New(t)
+	dbfile := path.Join(t.TempDir(), "curveadm.db")
+	execSQLite(t, dbfile, LEGACY_CLUSTERS_TABLE, LEGACY_INSERT_CLUSTER,
+		LEGACY_AUDIT_TABLE, LEGACY_INSERT_AUDIT_LOG)
+
+	s, err := NewStorage("sqlite://" + dbfile)
+	assert.Nil(err)
+	defer s.Close()
+
+	clusters, err := s.GetClusters("legacy")
+	assert.Nil(err)
+	assert.Len(clusters, 1)
+	assert.Equal("topology", clusters[0].Topology)
+	assert.Equal("", clusters[0].Pool)
+	assert.Len(backups(t, dbfile), 1)
+
+	// the new columns of audit table should be filled
+	auditLogs, err := s.GetAuditLogs(AuditFilter{})
+	assert.Nil(err)
+	assert.Len(auditLogs, 1)
+	assert.Equal("curveadm deploy", auditLogs[0].Command)
+	assert.Equal("", auditLogs[0].User)
+	assert.Equal(-1, auditLogs[0].ClusterId)
+
+	// the new tables should be created
+	assert.Nil(s.SetHosts("hosts"))
+	hostses, err := s.GetHostses()
+	assert.Nil(err)
+	assert.Len(hostses, 1)
+}
+
+func TestMigrate_NewerDatabase(t *testing.T) {
+	assert := assert.New(t)
+	dbfile := path.Join(t.TempDir(), "curveadm.db")
+	s, err := NewStorage("sqlite://" + dbfile)
+	assert.Nil(err)
+	assert.Nil(s.Close())
+
+	execSQLite(t, dbfile, `INSERT INTO schema_migrations(version, description, applied_time)
+		VALUES(10000, 'from future', datetime('now','localtime'))`)
+	_, err = NewStorage("sqlite://" + dbfile)
+	assert.Equal(errno.ERR_DATABASE_SCHEMA_IS_NEWER_THAN_CURVEADM.GetCode(),
+		err.(*errno.ErrorCode).GetCode())
+}
+
+func TestLegacyVersion(t *testing.T) {
+	assert := assert.New(t)
+	for _, c := range []struct {
+		statements []string
+		version    int
+	}{
+		{[]string{}, 0},
+		{[]string{LEGACY_AUDIT_TABLE}, 0},
+		{[]string{LEGACY_CLUSTERS_TABLE}, 1},
+		{[]string{LEGACY_CLUSTERS_TABLE, `ALTER TABLE clusters ADD COLUMN pool TEXT NULL`}, 2},
+	} {
+		dbfile := path.Join(t.TempDir(), "curveadm.db")
+		execSQLite(t, dbfile, c.statements...)
+
+		d := driver.NewSQLiteDriver()
+		assert.Nil(d.Open(dbfile))
+		version, err := (&dbStorage{db: d}).legacyVersion()
+		assert.Nil(err)
+		assert.Equal(c.version, version)
+		d.Close()
+	}
+}
+
+func TestMigrate_PartiallyApplied(t *testing.T) {
+	assert := assert.New(t)
+	dbfile := path.Join(t.TempDir(), "curveadm.db")
+	s, err := NewStorage("sqlite://" + dbfile)
+	assert.Nil(err)
+	assert.Nil(s.Close())
+
+	// simulate the DDL committed implicitly by MySQL before migration failed
+	execSQLite(t, dbfile,
+		`DELETE FROM schema_migrations WHERE version >= 3`,
+		`ALTER TABLE audit DROP COLUMN argv`,
+		`ALTER TABLE audit DROP COLUMN duration`,
+		`ALTER TABLE audit DROP COLUMN revision_after`)
+
+	s, err = NewStorage("sqlite://" + dbfile)
+	assert.Nil(err)
+	defer s.Close()
+	version, err := s.(*dbStorage).schemaVersion()
+	assert.Nil(err)
+	assert.Equal(LatestSchemaVersion(), version)
+
+	id, err := s.InsertAuditLog(AuditLog{Command: "curveadm status", ClusterId: -1})
+	assert.Nil(err)
+	assert.Nil(s.SetAuditLogStatus(id, 1, 0, 0, 10))
+}
+
+func TestMigrate_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+	dbfile := path.Join(t.TempDir(), "curveadm.db")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s, err := NewStorage("sqlite://" + dbfile)
+			if err == nil {
+				s.Close()
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.Nil(err)
+	}
+}
+
+var (
+	REGEX_CREATE_TABLE  = regexp.MustCompile(`(?is)CREATE\s+TABLE\s+IF\s+NOT\s+EXISTS\s+(\w+)\s*\((.*)\)`)
+	REGEX_INDEX_COLUMNS = regexp.MustCompile(`(?i)CREATE\s+INDEX\s+\w+\s+ON\s+(\w+)\s*\(([^)]*)\)`)
+)
+
+// returns the type of indexed columns, e.g. "history.kind" => "VARCHAR(255)"
+func indexedColumns(d driver.IDataBaseDriver) map[string]string {
+	types := map[string]string{}
+	indexed := map[string]string{}
+	for _, m := range MIGRATIONS {
+		for _, statement := range m.Statements {
+			statement = d.Rebind(statement)
+			if mu := REGEX_CREATE_TABLE.FindStringSubmatch(statement); mu != nil {
+				for _, column := range strings.Split(mu[2], ",") {
+					fields := strings.Fields(column)
+					key := mu[1] + "." + fields[0]
+					types[key] = fields[1]
+					if strings.Contains(column, "PRIMARY KEY") || strings.Contains(column, "UNIQUE") {
+						indexed[key] = fields[1]
+					}
+				}
+			} else if mu := REGEX_ADD_COLUMN.FindStringSubmatch(statement); mu != nil {
+				fields := strings.Fields(statement[len(mu[0]):])
+				types[mu[1]+"."+mu[2]] = fields[0]
+			} else if mu := REGEX_INDEX_COLUMNS.FindStringSubmatch(statement); mu != nil {
+				for _, column := range strings.Split(mu[2], ",") {
+					key := mu[1] + "." + strings.TrimSpace(column)
+					indexed[key] = types[key]
+				}
+			}
+		}
+	}
+	return indexed
+}
+
+// MySQL can't index TEXT column without key length,
+// so the indexed column should be declared as ${KEY} or INTEGER
+func TestMigrations_IndexedColumns(t *testing.T) {
+	assert := assert.New(t)
+	for _, d := range []driver.IDataBaseDriver{
+		driver.NewSQLiteDriver(),
+		driver.NewMySQLDriver(),
+		driver.NewPostgresDriver(),
+	} {
+		indexed := indexedColumns(d)
+		assert.Equal(d.Rebind(driver.MACRO_KEY), indexed["history.kind"], d.Name())
+		for column, typ := range indexed {
+			ok := typ == d.Rebind(driver.MACRO_KEY) || typ == "INTEGER" || typ == "SERIAL"
+			assert.True(ok, "%s: %s %s", d.Name(), column, typ)
+		}
+	}
+}
@@ -37,11 +37,17 @@
 
 package storage
 
+/*
+ * NOTE: all statements are written in SQLite flavour, the macros (e.g. ${ID})
+ * and placeholders will be rebinded by database driver, see driver.go.
+ * Don't use SQLite specified functions (e.g. datetime('now')) and double-quoted
+ * string literal, pass the value from go instead.
+ */
 var (
 	// tables (hosts/clusters/containers(service)/clients/playrgound/audit/disk/disks)
 	CREATE_VERSION_TABLE = `
 		CREATE TABLE IF NOT EXISTS version (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			id ${ID},
 			version TEXT NOT NULL,
 			lastconfirm TEXT NOT NULL
 		)
@@ -49,22 +55,22 @@ var (
 
 	CREATE_HOSTS_TABLE = `
 		CREATE TABLE IF NOT EXISTS hosts (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			id ${ID},
 			data TEXT NOT NULL,
-			lastmodified_time DATE NOT NULL
+			lastmodified_time ${DATETIME} NOT NULL
 		)
 	`
 	CREATE_DISKS_TABLE = `
 		CREATE TABLE IF NOT EXISTS disks (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			id ${ID},
 			data TEXT NOT NULL,
-			lastmodified_time DATE NOT NULL
+			lastmodified_time ${DATETIME} NOT NULL
 		)
 	`
 
 	CREATE_DISK_TABLE = `
 		CREATE TABLE IF NOT EXISTS disk (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			id ${ID},
 			host TEXT NOT NULL,
 			device TEXT NOT NULL,
 			size TEXT NOT NULL,
@@ -74,19 +80,19 @@ var (
 			container_image TEXT NOT NULL,
 			chunkserver_id TEXT NOT NULL,
 			service_mount_device INTEGER DEFAULT 0,
-			lastmodified_time DATE NOT NULL
+			lastmodified_time ${DATETIME} NOT NULL
 		)
 	`
 
+	// NOTE: the pool column is added by migration #2
 	CREATE_CLUSTERS_TABLE = `
 		CREATE TABLE IF NOT EXISTS clusters (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			id ${ID},
 			uuid TEXT NOT NULL,
-			name TEXT NOT NULL UNIQUE,
+			name ${KEY} NOT NULL UNIQUE,
 			description TEXT,
 			topology TEXT NULL,
-			pool TEXT NULL,
-			create_time DATE NOT NULL,
+			create_time ${DATETIME} NOT NULL,
 			current INTEGER DEFAULT 0
 		)
 	`
@@ -101,7 +107,7 @@ var (
 	// id: clusterId_role_host_(sequence/name)
 	CREATE_CONTAINERS_TABLE = `
 		CREATE TABLE IF NOT EXISTS containers (
-			id TEXT PRIMARY KEY,
+			id ${KEY} PRIMARY KEY,
 			cluster_id INTEGER NOT NULL,
 			container_id TEXT NOT NULL
 		)
@@ -109,7 +115,7 @@ var (
 
 	CREATE_CLIENTS_TABLE = `
 		CREATE TABLE IF NOT EXISTS clients (
-			id TEXT PRIMARY KEY,
+			id ${KEY} PRIMARY KEY,
 			kind TEXT NOT NULL,
 			host TEXT NOT NULL,
 			container_id TEXT NOT NULL,
@@ -118,62 +124,111 @@ var (
 	`
 
 	CREATE_PLAYGROUND_TABLE = `
-       CREATE TABLE IF NOT EXISTS playgrounds (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL UNIQUE,
-			create_time DATE NOT NULL,
+		CREATE TABLE IF NOT EXISTS playgrounds (
+			id ${ID},
+			name ${KEY} NOT NULL UNIQUE,
+			create_time ${DATETIME} NOT NULL,
 			mount_point TEXT NOT NULL,
-            status TEXT NOT NULL
+			status TEXT NOT NULL
 		)
-    `
+	`
 
 	CREATE_AUDIT_TABLE = `
-        CREATE TABLE IF NOT EXISTS audit (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-            execute_time DATE NOT NULL,
-            work_directory TEXT NOT NULL,
+		CREATE TABLE IF NOT EXISTS audit (
+			id ${ID},
+			execute_time ${DATETIME} NOT NULL,
+			work_directory TEXT NOT NULL,
 			command TEXT NOT NULL,
 			status INTEGER DEFAULT 0,
-            error_code INTEGET DEFAULT 0
+			error_code INTEGER DEFAULT 0
 		)
-    `
+	`
 
-	CHECK_POOl_COLUMN = `
-		SELECT COUNT(*) AS total
-		FROM pragma_table_info('clusters')
-		WHERE name='pool'
+	// append-only, kind: topology/pool/hosts/disks/monitor
+	// NOTE: the indexed column should be ${KEY} instead of TEXT,
+	// for MySQL can't index TEXT column without key length
+	CREATE_HISTORY_TABLE = `
+		CREATE TABLE IF NOT EXISTS history (
+			id ${ID},
+			cluster_id INTEGER NOT NULL,
+			kind ${KEY} NOT NULL,
+			revision INTEGER NOT NULL,
+			data TEXT NOT NULL,
+			author TEXT NOT NULL,
+			audit_id INTEGER DEFAULT -1,
+			create_time ${DATETIME} NOT NULL
+		)
 	`
 
-	RENAME_CLUSTERS_TABLE = `ALTER TABLE clusters RENAME TO clusters_old`
+	CREATE_HISTORY_INDEX = `CREATE INDEX history_cluster_kind ON history (cluster_id, kind, revision)`
 
-	INSERT_CLUSTERS_FROM_OLD_TABLE = `
-		INSERT INTO clusters(id, uuid, name, description, topology, pool, create_time, current)
-		SELECT id, uuid, name, description, topology, "", create_time, current
-		FROM clusters_old
+	// id: service id, digest: sha256:xxx which resolved from image at commit time
+	CREATE_IMAGE_DIGESTS_TABLE = `
+		CREATE TABLE IF NOT EXISTS image_digests (
+			id ${KEY} PRIMARY KEY,
+			cluster_id INTEGER NOT NULL,
+			image TEXT NOT NULL,
+			digest TEXT NOT NULL
+		)
 	`
 
-	DROP_OLD_CLUSTERS_TABLE = `DROP TABLE clusters_old`
+	// schema migrations
+	CREATE_SCHEMA_MIGRATIONS_TABLE = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL,
+			applied_time ${DATETIME} NOT NULL
+		)
+	`
+
+	ADD_CLUSTERS_POOL_COLUMN = `ALTER TABLE clusters ADD COLUMN pool TEXT NULL`
+
+	FILL_CLUSTERS_POOL_COLUMN = `UPDATE clusters SET pool = '' WHERE pool IS NULL`
+
+	// operator of audit log, duration in milliseconds
+	ADD_AUDIT_USERNAME_COLUMN = `ALTER TABLE audit ADD COLUMN username TEXT NULL`
+
+	ADD_AUDIT_CLIENT_COLUMN = `ALTER TABLE audit ADD COLUMN client TEXT NULL`
+
+	ADD_AUDIT_TOKEN_COLUMN = `ALTER TABLE audit ADD COLUMN token TEXT NULL`
+
+	ADD_AUDIT_CLUSTER_ID_COLUMN = `ALTER TABLE audit ADD COLUMN cluster_id INTEGER DEFAULT -1`
+
+	ADD_AUDIT_REVISION_BEFORE_COLUMN = `ALTER TABLE audit ADD COLUMN revision_before INTEGER DEFAULT 0`
+
+	ADD_AUDIT_REVISION_AFTER_COLUMN = `ALTER TABLE audit ADD COLUMN revision_after INTEGER DEFAULT 0`
+
+	ADD_AUDIT_DURATION_COLUMN = `ALTER TABLE audit ADD COLUMN duration INTEGER DEFAULT 0`
+
+	FILL_AUDIT_OPERATOR_COLUMNS = `UPDATE audit SET username = '', client = '', token = '' WHERE username IS NULL`
+
+	// argv of command in JSON array, NULL if the command can't be replayed
+	ADD_AUDIT_ARGV_COLUMN = `ALTER TABLE audit ADD COLUMN argv TEXT NULL`
+
+	INSERT_SCHEMA_MIGRATION = `INSERT INTO schema_migrations(version, description, applied_time) VALUES(?, ?, ?)`
+
+	SELECT_SCHEMA_VERSION = `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
 
 	// version
-	INSERT_VERSION = `INSERT INTO version(version, lastconfirm) VALUES(?, "")`
+	INSERT_VERSION = `INSERT INTO version(version, lastconfirm) VALUES(?, '')`
 
 	SET_VERSION = `UPDATE version SET version = ?, lastconfirm = ? WHERE id = ?`
 
-	SELECT_VERSION = `SELECT * FROM version`
+	SELECT_VERSION = `SELECT id, version, lastconfirm FROM version`
 
 	// hosts
-	INSERT_HOSTS = `INSERT INTO hosts(data, lastmodified_time) VALUES(?, datetime('now','localtime'))`
+	INSERT_HOSTS = `INSERT INTO hosts(data, lastmodified_time) VALUES(?, ?)`
 
-	SET_HOSTS = `UPDATE hosts SET data = ?, lastmodified_time = datetime('now','localtime') WHERE id = ?`
+	SET_HOSTS = `UPDATE hosts SET data = ?, lastmodified_time = ? WHERE id = ?`
 
-	SELECT_HOSTS = `SELECT * FROM hosts`
+	SELECT_HOSTS = `SELECT id, data, lastmodified_time FROM hosts`
 
 	// disks
-	INSERT_DISKS = `INSERT INTO disks(data, lastmodified_time) VALUES(?, datetime('now','localtime'))`
+	INSERT_DISKS = `INSERT INTO disks(data, lastmodified_time) VALUES(?, ?)`
 
-	SET_DISKS = `UPDATE disks SET data = ?, lastmodified_time = datetime('now','localtime') WHERE id = ?`
+	SET_DISKS = `UPDATE disks SET data = ?, lastmodified_time = ? WHERE id = ?`
 
-	SELECT_DISKS = `SELECT * FROM disks`
+	SELECT_DISKS = `SELECT id, data, lastmodified_time FROM disks`
 
 	// disk
 	INSERT_DISK = `INSERT INTO disk(
@@ -187,30 +242,33 @@ var (
 		chunkserver_id,
 		service_mount_device,
 		lastmodified_time
-		) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now','localtime'))`
+		) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	SET_DISK = `UPDATE disk SET disk_format_mount_point = ?, format_percent = ?,
 				container_image = ?, service_mount_device = ?,
-				lastmodified_time = datetime('now','localtime') WHERE id = ?`
+				lastmodified_time = ? WHERE id = ?`
 
 	SET_DISK_URI = `UPDATE disk SET uri = ?,
-		lastmodified_time = datetime('now','localtime') WHERE host = ? AND device = ?`
+		lastmodified_time = ? WHERE host = ? AND device = ?`
 
 	SET_DISK_SIZE = `UPDATE disk SET size = ?,
-		lastmodified_time = datetime('now','localtime') WHERE host = ? AND device = ?`
+		lastmodified_time = ? WHERE host = ? AND device = ?`
 
 	SET_DISK_CHUNKSERVER_ID = `UPDATE disk SET chunkserver_id = ?,
-	lastmodified_time = datetime('now','localtime') WHERE host = ? AND disk_format_mount_point = ?`
+	lastmodified_time = ? WHERE host = ? AND disk_format_mount_point = ?`
 
-	SELECT_DISK_ALL = `SELECT * FROM disk`
+	SELECT_DISK = `SELECT id, host, device, size, uri, disk_format_mount_point, format_percent,
+		container_image, chunkserver_id, service_mount_device, lastmodified_time FROM disk`
 
-	SELECT_DISK_BY_HOST = `SELECT * FROM disk where host = ?`
+	SELECT_DISK_ALL = SELECT_DISK
 
-	SELECT_DISK_BY_CHUNKSERVER_ID = `SELECT * FROM disk where chunkserver_id = ?`
+	SELECT_DISK_BY_HOST = SELECT_DISK + ` WHERE host = ?`
 
-	SELECT_DISK_BY_DEVICE_PATH = `SELECT * from disk WHERE host = ? AND device = ?`
+	SELECT_DISK_BY_CHUNKSERVER_ID = SELECT_DISK + ` WHERE chunkserver_id = ?`
 
-	SELECT_DISK_BY_MOUNTPOINT = `SELECT * from disk WHERE host = ? AND disk_format_mount_point = ?`
+	SELECT_DISK_BY_DEVICE_PATH = SELECT_DISK + ` WHERE host = ? AND device = ?`
+
+	SELECT_DISK_BY_MOUNTPOINT = SELECT_DISK + ` WHERE host = ? AND disk_format_mount_point = ?`
 
 	DELETE_DISK_HOST = `DELETE from disk WHERE host = ?`
 
@@ -218,13 +276,18 @@ var (
 
 	// cluster
 	INSERT_CLUSTER = `INSERT INTO clusters(uuid, name, description, topology, pool, create_time)
-                                  VALUES(hex(randomblob(16)), ?, ?, ?, "", datetime('now','localtime'))`
+                                  VALUES(?, ?, ?, ?, '', ?)`
+
+	IMPORT_CLUSTER = `INSERT INTO clusters(uuid, name, description, topology, pool, create_time)
+                                  VALUES(?, ?, ?, ?, ?, ?)`
 
 	DELETE_CLUSTER = `DELETE from clusters WHERE name = ?`
 
-	SELECT_CLUSTER = `SELECT * FROM clusters WHERE name LIKE ?`
+	SELECT_CLUSTERS = `SELECT id, uuid, name, description, topology, pool, create_time, current FROM clusters`
 
-	GET_CURRENT_CLUSTER = `SELECT * FROM clusters WHERE current = 1`
+	SELECT_CLUSTER = SELECT_CLUSTERS + ` WHERE name LIKE ?`
+
+	GET_CURRENT_CLUSTER = SELECT_CLUSTERS + ` WHERE current = 1`
 
 	CHECKOUT_CLUSTER = `
 		UPDATE clusters
@@ -236,49 +299,76 @@ var (
 
 	SET_CLUSTER_TOPOLOGY = `UPDATE clusters SET topology = ? WHERE id = ?`
 
-	SET_CLUSTER_TOPOLOGY_BY_NAME = `UPDATE clusters SET topology = ? WHERE name = ?`
+	SELECT_CLUSTER_ID_BY_NAME = `SELECT id FROM clusters WHERE name = ?`
 
 	SET_CLUSTER_POOL = `UPDATE clusters SET topology = ?, pool = ? WHERE id = ?`
 
 	// service
 	INSERT_SERVICE = `INSERT INTO containers(id, cluster_id, container_id) VALUES(?, ?, ?)`
 
-	SELECT_SERVICE = `SELECT * FROM containers WHERE id = ?`
+	SELECT_SERVICES = `SELECT id, cluster_id, container_id FROM containers`
+
+	SELECT_SERVICE = SELECT_SERVICES + ` WHERE id = ?`
 
-	SELECT_SERVICE_IN_CLUSTER = `SELECT * FROM containers WHERE cluster_id = ?`
+	SELECT_SERVICE_IN_CLUSTER = SELECT_SERVICES + ` WHERE cluster_id = ?`
 
 	SET_CONTAINER_ID = `UPDATE containers SET container_id = ? WHERE id = ?`
 
+	// image digest
+	INSERT_IMAGE_DIGEST = `INSERT INTO image_digests(id, cluster_id, image, digest) VALUES(?, ?, ?, ?)`
+
+	SELECT_IMAGE_DIGESTS = `SELECT id, cluster_id, image, digest FROM image_digests WHERE cluster_id = ?`
+
+	DELETE_IMAGE_DIGESTS = `DELETE FROM image_digests WHERE cluster_id = ?`
+
 	// client
 	INSERT_CLIENT = `INSERT INTO clients(id, kind, host, container_id, aux_info) VALUES(?, ?, ?, ?, ?)`
 
-	SELECT_CLIENTS = `SELECT * FROM clients`
+	SELECT_CLIENTS = `SELECT id, kind, host, container_id, aux_info FROM clients`
 
-	SELECT_CLIENT_BY_ID = `SELECT * FROM clients WHERE id = ?`
+	SELECT_CLIENT_BY_ID = SELECT_CLIENTS + ` WHERE id = ?`
 
 	DELETE_CLIENT = `DELETE from clients WHERE id = ?`
 
 	// playground
 	INSERT_PLAYGROUND = `INSERT INTO playgrounds(name, create_time, mount_point, status)
-                                     VALUES(?, datetime('now','localtime'), ?, ?)`
+                                     VALUES(?, ?, ?, ?)`
 
 	SET_PLAYGROUND_STATUS = `UPDATE playgrounds SET status = ? WHERE name = ?`
 
-	SELECT_PLAYGROUND = `SELECT * FROM playgrounds WHERE name LIKE ?`
+	SELECT_PLAYGROUNDS = `SELECT id, name, create_time, mount_point, status FROM playgrounds`
 
-	SELECT_PLAYGROUND_BY_ID = `SELECT * FROM playgrounds WHERE id = ?`
+	SELECT_PLAYGROUND = SELECT_PLAYGROUNDS + ` WHERE name LIKE ?`
+
+	SELECT_PLAYGROUND_BY_ID = SELECT_PLAYGROUNDS + ` WHERE id = ?`
 
 	DELETE_PLAYGROUND = `DELETE from playgrounds WHERE name = ?`
 
 	// audit
-	INSERT_AUDIT_LOG = `INSERT INTO audit(execute_time, work_directory, command, status)
-                                    VALUES(?, ?, ?, ?)`
+	INSERT_AUDIT_LOG = `INSERT INTO audit(execute_time, work_directory, command, status,
+                                          username, client, token, cluster_id, revision_before, argv)
+                                    VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	SET_AUDIT_LOG_STATUS = `UPDATE audit SET status = ?, error_code = ?, revision_after = ?, duration = ?
+                                WHERE id = ?`
 
-	SET_AUDIT_LOG_STATUS = `UPDATE audit SET status = ?, error_code = ? WHERE id = ?`
+	SELECT_AUDIT_LOG = `SELECT id, execute_time, work_directory, command, status, error_code,
+                               username, client, token, cluster_id, revision_before, revision_after, duration,
+                               argv
+                               FROM audit`
 
-	SELECT_AUDIT_LOG = `SELECT * FROM audit`
+	SELECT_AUDIT_LOG_BY_ID = SELECT_AUDIT_LOG + ` WHERE id = ?`
 
-	SELECT_AUDIT_LOG_BY_ID = `SELECT * FROM audit WHERE id = ?`
+	// history
+	INSERT_HISTORY = `INSERT INTO history(cluster_id, kind, revision, data, author, audit_id, create_time)
+                                  VALUES(?, ?, ?, ?, ?, ?, ?)`
+
+	SELECT_HISTORIES = `SELECT id, cluster_id, kind, revision, data, author, audit_id, create_time FROM history
+		WHERE cluster_id = ? AND kind = ?`
+
+	SELECT_HISTORY_BY_REVISION = SELECT_HISTORIES + ` AND revision = ?`
+
+	SELECT_LATEST_HISTORY = SELECT_HISTORIES + ` ORDER BY revision DESC LIMIT 1`
 
 	// monitor
 	INTERT_MONITOR = `INSERT INTO monitors(cluster_id, monitor) VALUES(?, ?)`
@@ -288,6 +378,4 @@ var (
 	SELECT_MONITOR = `SELECT monitor FROM monitors WHERE cluster_id = ?`
 
 	DELETE_MONITOR = `DELETE FROM monitors WHERE cluster_id = ?`
-
-	REPLACE_MONITOR = `REPLACE INTO monitors (cluster_id, monitor) VALUES(?, ?)`
 )
@@ -0,0 +1,134 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package storage
+
+import (
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog_Filter(t *testing.T) {
+	assert := assert.New(t)
+	s, err := NewStorage("sqlite://" + path.Join(t.TempDir(), "curveadm.db"))
+	assert.Nil(err)
+	defer s.Close()
+
+	now := time.Now()
+	auditLogs := []AuditLog{
+		{ExecuteTime: now.Add(-2 * time.Hour), Command: "curveadm deploy -k", User: "alice", ClusterId: 1,
+			Args: []string{"deploy", "-k", "--skip", "my check"}},
+		{ExecuteTime: now.Add(-1 * time.Hour), Command: "curveadm stop", User: "bob", ClusterId: 1},
+		{ExecuteTime: now, Command: "curveadm start", User: "alice", ClusterId: 2},
+	}
+	for _, auditLog := range auditLogs {
+		_, err := s.InsertAuditLog(auditLog)
+		assert.Nil(err)
+	}
+	assert.Nil(s.SetAuditLogStatus(1, comm.AUDIT_STATUS_SUCCESS, 0, 2, 1500*time.Millisecond))
+	assert.Nil(s.SetAuditLogStatus(2, comm.AUDIT_STATUS_FAIL, 410010, 0, time.Second))
+
+	ids := func(filter AuditFilter) []int {
+		auditLogs, err := s.GetAuditLogs(filter)
+		assert.Nil(err)
+		ids := []int{}
+		for _, auditLog := range auditLogs {
+			ids = append(ids, auditLog.Id)
+		}
+		return ids
+	}
+	assert.Equal([]int{1, 2, 3}, ids(AuditFilter{}))
+	assert.Equal([]int{1, 2}, ids(AuditFilter{ClusterId: 1}))
+	assert.Equal([]int{1, 3}, ids(AuditFilter{User: "alice"}))
+	assert.Equal([]int{2, 3}, ids(AuditFilter{
+		Statuses: []int{comm.AUDIT_STATUS_FAIL, comm.AUDIT_STATUS_ABORT},
+	}))
+	assert.Equal([]int{2, 3}, ids(AuditFilter{Since: now.Add(-90 * time.Minute)}))
+	assert.Equal([]int{1}, ids(AuditFilter{User: "alice", Until: now.Add(-time.Hour)}))
+
+	auditLog, err := s.GetAuditLog(1)
+	assert.Nil(err)
+	assert.Len(auditLog, 1)
+	assert.Equal("alice", auditLog[0].User)
+	assert.Equal(2, auditLog[0].RevisionAfter)
+	assert.Equal(1500*time.Millisecond, auditLog[0].Duration)
+	assert.Equal([]string{"deploy", "-k", "--skip", "my check"}, auditLog[0].Args)
+
+	auditLog, err = s.GetAuditLog(2)
+	assert.Nil(err)
+	assert.Nil(auditLog[0].Args)
+}
+
+func TestTransaction(t *testing.T) {
+	assert := assert.New(t)
+	s, err := NewStorage("sqlite://" + path.Join(t.TempDir(), "curveadm.db"))
+	assert.Nil(err)
+	defer s.Close()
+
+	// rollback all changes if callback failed
+	err = s.Transaction(func(s Storage) error {
+		assert.Nil(s.InsertCluster("c1", "", "topology"))
+		clusters, err := s.GetClusters("c1")
+		assert.Nil(err)
+		assert.Len(clusters, 1)
+		assert.Nil(s.InsertService(clusters[0].Id, "s1", "container1"))
+		assert.Nil(s.SetHosts("hosts"))
+		return fmt.Errorf("import failed")
+	})
+	assert.Equal("import failed", err.Error())
+	clusters, err := s.GetClusters("c1")
+	assert.Nil(err)
+	assert.Len(clusters, 0)
+	containerId, err := s.GetContainerId("s1")
+	assert.Nil(err)
+	assert.Equal("", containerId)
+	hostses, err := s.GetHostses()
+	assert.Nil(err)
+	assert.Len(hostses, 0)
+
+	// commit all changes if callback succeed
+	err = s.Transaction(func(s Storage) error {
+		if err := s.InsertCluster("c1", "", "topology"); err != nil {
+			return err
+		}
+		clusters, err := s.GetClusters("c1")
+		if err != nil {
+			return err
+		}
+		return s.SetImageDigests(clusters[0].Id, []ImageDigest{{ServiceId: "s1", Image: "curve", Digest: "sha256:1"}})
+	})
+	assert.Nil(err)
+	clusters, err = s.GetClusters("c1")
+	assert.Nil(err)
+	assert.Len(clusters, 1)
+	digests, err := s.GetImageDigests(clusters[0].Id)
+	assert.Nil(err)
+	assert.Len(digests, 1)
+	histories, err := s.GetHistories(clusters[0].Id, comm.HISTORY_KIND_TOPOLOGY)
+	assert.Nil(err)
+	assert.Len(histories, 1)
+}
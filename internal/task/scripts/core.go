@@ -0,0 +1,49 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package scripts
+
+/*
+ * Usage: collect_core BINARY OUTPUT
+ * Example: collect_core /curvebs/chunkserver/sbin/curvebs-chunkserver /curveadm/output
+ * Output:
+ *   OUTPUT/curvebs-chunkserver        # binary
+ *   OUTPUT/curvebs-chunkserver.debug  # debug symbols (if any)
+ *   OUTPUT/sysroot/                   # shared libraries and /usr/lib/debug, for gdb 'set sysroot'
+ */
+var COLLECT_CORE = `
+binary=$1
+output=$2
+sysroot=$output/sysroot
+
+mkdir -p $sysroot
+cp $binary $output/ || exit 1
+[ -f $binary.debug ] && cp $binary.debug $output/
+
+for lib in $(ldd $binary | awk '$2 == "=>" && $3 ~ /^\// {print $3} $1 ~ /^\// {print $1}')
+do
+    cp --parents -L $lib $sysroot
+done
+
+[ -d /usr/lib/debug ] && cp -r --parents /usr/lib/debug $sysroot
+exit 0
+`
@@ -0,0 +1,192 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package scripts
+
+/*
+ * Usage: export ACTION PROTOCOL USER VOLUME CREATE SIZE ENDPOINT [ALLOW_HOSTS]
+ * Example: export add nvmeof curve /test true 10 10.0.0.1:4420 nqn.2014-08.org.nvmexpress:uuid:1b4e28ba
+ *          export add vhost curve /test false 10 /var/run/curve/vhost/test_curve_.sock -
+ *          export delete nvmeof curve /test - - 10.0.0.1:4420
+ * See Also: https://docs.kernel.org/nvme/nvme-target.html
+ *           https://www.qemu.org/docs/master/tools/qemu-storage-daemon.html
+ */
+var EXPORT = `
+#!/usr/bin/env bash
+
+g_action=$1
+g_protocol=$2
+g_user=$3
+g_volume=$4
+g_create=$5
+g_size=$6
+g_endpoint=$7
+g_allow_hosts=$8
+g_image=cbd:pool/${g_volume}_${g_user}_
+g_image_md5=$(echo -n ${g_image} | md5sum | awk '{ print $1 }')
+g_nqn=nqn.2023-10.com.opencurve:curve.${g_image_md5}
+g_nvmet=/sys/kernel/config/nvmet
+g_pidfile=/curvebs/nebd/data/vhost.pid
+g_stderr=/tmp/__curveadm_export__
+
+function get_device() {
+    curve-nbd list-mapped | awk -v image=${g_image} '$2 == image { print $3 }'
+}
+
+function map_volume() {
+    if [ "${g_create}" == "true" ]; then
+        output=$(curve_ops_tool create -userName=${g_user} -fileName=${g_volume} -fileLength=${g_size})
+        if [ $? -ne 0 ] && [ "$output" != "CreateFile fail with errCode: 101" ]; then
+            echo "create volume failed: ${output}"
+            exit 1
+        fi
+    fi
+
+    mkdir -p /curvebs/nebd/data/lock
+    touch /etc/curve/curvetab
+    if [ -z "$(get_device)" ]; then
+        curve-nbd map --nbds_max=16 ${g_image} > ${g_stderr} 2>&1
+        if [ $? -ne 0 ]; then
+            cat ${g_stderr}
+            exit 1
+        fi
+    fi
+    g_device=$(get_device)
+    if [ -z "${g_device}" ]; then
+        echo "get mapped device failed"
+        exit 1
+    fi
+}
+
+function unmap_volume() {
+    if [ -n "$(get_device)" ]; then
+        curve-nbd unmap ${g_image}
+    fi
+}
+
+# nvmet port id is same as the listen port
+function nvmeof_add() {
+    local addr=${g_endpoint%:*} port=${g_endpoint##*:}
+    modprobe nvmet && modprobe nvmet-tcp
+    if [ $? -ne 0 ]; then
+        echo "load nvmet kernel module failed"
+        exit 1
+    fi
+    if [ ! -d ${g_nvmet} ]; then
+        mount -t configfs none /sys/kernel/config
+    fi
+
+    local subsystem=${g_nvmet}/subsystems/${g_nqn}
+    mkdir -p ${subsystem}/namespaces/1 && \
+    echo 0 > ${subsystem}/attr_allow_any_host && \
+    echo -n ${g_device} > ${subsystem}/namespaces/1/device_path && \
+    echo 1 > ${subsystem}/namespaces/1/enable
+    if [ $? -ne 0 ]; then
+        echo "create nvmet subsystem failed"
+        exit 1
+    fi
+
+    # only the allowed hosts can connect to subsystem
+    for nqn in ${g_allow_hosts//,/ }; do
+        if [ "${nqn}" == "-" ] || [ -e ${subsystem}/allowed_hosts/${nqn} ]; then
+            continue
+        fi
+        mkdir -p ${g_nvmet}/hosts/${nqn} && \
+        ln -s ${g_nvmet}/hosts/${nqn} ${subsystem}/allowed_hosts/${nqn}
+        if [ $? -ne 0 ]; then
+            echo "allow nvmet host ${nqn} failed"
+            exit 1
+        fi
+    done
+
+    local portdir=${g_nvmet}/ports/${port}
+    if [ ! -d ${portdir} ]; then
+        mkdir -p ${portdir} && \
+        echo tcp > ${portdir}/addr_trtype && \
+        echo ipv4 > ${portdir}/addr_adrfam && \
+        echo ${addr} > ${portdir}/addr_traddr && \
+        echo ${port} > ${portdir}/addr_trsvcid
+        if [ $? -ne 0 ]; then
+            echo "create nvmet port failed"
+            exit 1
+        fi
+    fi
+    if [ ! -e ${portdir}/subsystems/${g_nqn} ]; then
+        ln -s ${subsystem} ${portdir}/subsystems/${g_nqn}
+    fi
+}
+
+function nvmeof_delete() {
+    local port=${g_endpoint##*:}
+    local subsystem=${g_nvmet}/subsystems/${g_nqn}
+    local portdir=${g_nvmet}/ports/${port}
+    rm -f ${portdir}/subsystems/${g_nqn}
+    if [ -d ${subsystem} ]; then
+        for link in ${subsystem}/allowed_hosts/*; do
+            if [ -L ${link} ]; then
+                rm -f ${link}
+                # the host may be still allowed by other subsystems
+                rmdir ${g_nvmet}/hosts/$(basename ${link}) 2>/dev/null
+            fi
+        done
+        echo 0 > ${subsystem}/namespaces/1/enable
+        rmdir ${subsystem}/namespaces/1 ${subsystem}
+    fi
+    if [ -d ${portdir} ] && [ -z "$(ls ${portdir}/subsystems)" ]; then
+        rmdir ${portdir}
+    fi
+}
+
+function vhost_add() {
+    if [ -f ${g_pidfile} ] && kill -0 $(cat ${g_pidfile}) 2>/dev/null; then
+        return
+    fi
+    mkdir -p $(dirname ${g_endpoint})
+    rm -f ${g_endpoint}
+    qemu-storage-daemon \
+        --blockdev driver=host_device,node-name=disk,filename=${g_device},cache.direct=on \
+        --export type=vhost-user-blk,id=export,node-name=disk,addr.type=unix,addr.path=${g_endpoint},writable=on \
+        --pidfile ${g_pidfile} \
+        --daemonize > ${g_stderr} 2>&1
+    if [ $? -ne 0 ]; then
+        cat ${g_stderr}
+        exit 1
+    fi
+}
+
+function vhost_delete() {
+    if [ -f ${g_pidfile} ]; then
+        kill $(cat ${g_pidfile}) 2>/dev/null
+        rm -f ${g_pidfile}
+    fi
+    rm -f ${g_endpoint}
+}
+
+if [ "${g_action}" == "add" ]; then
+    map_volume
+    ${g_protocol}_add
+    echo "${g_protocol} ${g_image} ${g_device} ${g_endpoint}"
+elif [ "${g_action}" == "delete" ]; then
+    ${g_protocol}_delete
+    unmap_volume
+fi
+`
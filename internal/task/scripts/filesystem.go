@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package scripts
+
+/*
+ * Usage: filesystem CURVEFS_TOOL CURVE_TOOL_V2 ACTION [ARGS...]
+ * Example: filesystem /curvefs/tools/sbin/curvefs_tool /curvefs/tools-v2/sbin/curve list
+ *          filesystem ... create test s3 0 1048576 /tmp/filesystem.s3
+ *          filesystem ... quota test 100 1000000
+ *          filesystem ... delete test
+ *          filesystem ... usage test
+ * NOTE: the S3 info file (ak, sk, endpoint and bucket in lines) will be removed after read
+ * Output:
+ *   list/usage: the raw output of tool
+ *   others: CURVEADM_OK or CURVEADM_FAIL with error message
+ */
+var FILESYSTEM = `
+g_tool=$1
+g_toolsv2=$2
+g_action=$3
+shift 3
+
+function check() {
+    if [ $1 -ne 0 ]; then
+        echo "CURVEADM_FAIL"
+        echo "$2"
+        exit 1
+    fi
+    echo "CURVEADM_OK"
+}
+
+function create_fs() {
+    local name=$1 fstype=$2 capacity=$3 blocksize=$4
+    local options=(-fsName="${name}" -fsType="${fstype}" -blockSize="${blocksize}" -rpcTimeoutMs=10000)
+    if [ ${capacity} -gt 0 ]; then
+        options+=(-capacity="${capacity}")
+    fi
+    if [ "${fstype}" == "s3" ]; then
+        local ak sk endpoint bucket
+        { read -r ak; read -r sk; read -r endpoint; read -r bucket; } < $5
+        rm -f $5
+        options+=(-s3_ak="${ak}" -s3_sk="${sk}" -s3_endpoint="${endpoint}" -s3_bucket_name="${bucket}")
+    fi
+    ${g_tool} create-fs "${options[@]}" 2>&1
+}
+
+case ${g_action} in
+    list)
+        ${g_tool} list-fs 2>&1
+        ;;
+    create)
+        output=$(create_fs "$@")
+        check $? "${output}"
+        ;;
+    quota)
+        output=$(${g_toolsv2} fs quota set --fsname=$1 --capacity=$2 --inodes=$3 2>&1)
+        check $? "${output}"
+        ;;
+    delete)
+        output=$(${g_tool} delete-fs -fsName=$1 -noconfirm 2>&1)
+        check $? "${output}"
+        ;;
+    usage)
+        ${g_toolsv2} fs quota get --fsname=$1 2>&1
+        ;;
+    *)
+        check 1 "unknown action: ${g_action}"
+        ;;
+esac
+`
@@ -22,6 +22,11 @@
 
 package scripts
 
+/*
+ * Usage: format BINARY PERCENT CHUNKFILE_SIZE POOL_DIR META_PATH INCREMENT STATE_FILE CONCURRENCY
+ * State: the state file contains one line "UUID STATUS START_TIME START_PERCENT",
+ *        the STATUS is one of waiting, resuming, formatting and done
+ */
 var FORMAT = `
 binary=$1
 percent=$2
@@ -29,15 +34,46 @@ chunkfile_size=$3
 chunkfile_pool_dir=$4
 chunkfile_pool_meta_path=$5
 increment_format=$6
+state_file=$7
+concurrency=$8
+
+function set_state() {
+    uuid=$(awk '{print $1}' $state_file)
+    echo "$uuid $1 $2 $3" > $state_file
+}
 
 mkdir -p $chunkfile_pool_dir
+rootdir=$(dirname $chunkfile_pool_dir)
+
+# the interrupted formatting will be resumed by incremental formatting
+if [ "$(awk '{print $2}' $state_file)" == "resuming" ]
+then
+    increment_format="true"
+fi
+
+# wait a free slot if the concurrency of formatting in one host is limited
+if [ $concurrency -gt 0 ]
+then
+    statedir=$(dirname $state_file)
+    while true
+    do
+        for ((i=0; i<$concurrency; i++))
+        do
+            exec 9>$statedir/slot.$i
+            flock -n 9 && break 2
+            exec 9>&-
+        done
+        sleep 3s
+    done
+fi
+
+used_percent=$(df $rootdir --output=pcent|tail -n 1|sed 's/%//'|xargs)
+set_state formatting $(date +%s) $used_percent
 
 if [ $increment_format == "true" ]
 then
-    rootdir=$(dirname $chunkfile_pool_dir)
-    used_percent=$(df $rootdir --output=pcent|tail -n 1|sed 's/%//'|xargs)
     let minus=$percent-$used_percent
-    
+
     if [ $minus -gt 0 ]
     then
       $binary \
@@ -46,7 +82,7 @@ then
         -filePoolDir=$chunkfile_pool_dir \
         -filePoolMetaPath=$chunkfile_pool_meta_path \
         -fileSystemPath=$chunkfile_pool_dir
-    fi  
+    fi
 else
     $binary \
       -allocatePercent=$percent \
@@ -56,5 +92,8 @@ else
       -fileSystemPath=$chunkfile_pool_dir
 fi
 
-
+if [ $? -eq 0 ]
+then
+    set_state done $(date +%s) $used_percent
+fi
 `
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package scripts
+
+/*
+ * Usage: retire_chunkserver CURVEBS_TOOL IP PORT TIMEOUT
+ * Example: retire_chunkserver /curvebs/tools/sbin/curvebs-tool 10.0.10.1 8200 86400
+ * Output: the id of retired chunkserver
+ */
+var RETIRE_CHUNKSERVER = `
+tool=$1
+ip=$2
+port=$3
+timeout=$4
+
+function list_chunkserver() {
+    curve_ops_tool chunkserver-list -checkHealth=false 2>/dev/null \
+        | grep "hostIP = ${ip}, port = ${port},"
+}
+
+# 1) the chunkserver maybe already retired
+line=$(list_chunkserver | grep -v "rwStatus = RETIRED" | head -n 1)
+if [ -z "${line}" ]; then
+    echo "CURVEADM_OK"
+    exit 0
+fi
+id=$(echo "${line}" | sed -n 's/^chunkServerID = \([0-9]*\),.*/\1/p')
+
+# 2) mark chunkserver as pendding, mds will migrate all copysets out of it
+if [ -z "$(echo ${line} | grep 'rwStatus = PENDDING')" ]; then
+    ${tool} -op=set_chunkserver -chunkserver_id=${id} -chunkserver_status=pendding
+    if [ $? -ne 0 ]; then
+        echo "CURVEADM_FAIL"
+        exit 1
+    fi
+fi
+
+# 3) wait all copysets recovered, mds will retire it when it has no copyset
+start=$(date +%s)
+while (( $(date +%s) - start < timeout ))
+do
+    line=$(list_chunkserver | grep "chunkServerID = ${id},")
+    if [ -n "$(echo ${line} | grep 'rwStatus = RETIRED')" ]; then
+        echo ${id}
+        exit 0
+    fi
+    sleep 5s
+done
+echo "CURVEADM_TIMEOUT"
+exit 1
+`
+
+/*
+ * Usage: wait_chunkserver_registered IP PORT
+ * Example: wait_chunkserver_registered 10.0.10.1 8200
+ * Output: the id of new registered chunkserver
+ */
+var WAIT_CHUNKSERVER_REGISTERED = `
+ip=$1
+port=$2
+
+wait=0
+while ((wait<120))
+do
+    id=$(curve_ops_tool chunkserver-list -checkHealth=false 2>/dev/null \
+        | grep "hostIP = ${ip}, port = ${port}," \
+        | grep "rwStatus = READWRITE" \
+        | grep "onlineState = ONLINE" \
+        | sed -n 's/^chunkServerID = \([0-9]*\),.*/\1/p' \
+        | head -n 1)
+    if [ -n "${id}" ]; then
+        echo ${id}
+        exit 0
+    fi
+    sleep 1s
+    wait=$(expr ${wait} + 1)
+done
+echo "CURVEADM_TIMEOUT"
+exit 1
+`
@@ -23,8 +23,17 @@
 package scripts
 
 /*
- * Usage: report KIND UUID ROLE
- * Example: report curvebs abcdef01234567890 metaserver
+ * Usage: report KIND UUID ROLE URL
+ * Example: report curvebs abcdef01234567890 metaserver http://127.0.0.1:19302/
+ *
+ * The report is sent by HTTP POST with JSON body, e.g.
+ *   {
+ *     "kind": "curvebs",            // cluster kind: curvebs/curvefs
+ *     "uuid": "abcdef01234567890",  // cluster uuid
+ *     "role": "metaserver",         // service role
+ *     "usage": 1073741824,          // cluster used bytes
+ *     "timestamp": 1697629097       // unix timestamp in seconds
+ *   }
  */
 var REPORT = `
 function rematch() {
@@ -51,17 +60,17 @@ function fs_usage() {
 function bs_usage() {
     local message=$(curve_ops_tool space | grep physical)
     local used=$(rematch "$message" "used = ([0-9]+)GB")
-    echo $(($used*1024*1024*1024))
+    echo $((${used:-0}*1024*1024*1024))
 }
 
-[[ -z $(which curl) ]] && apt-get install -y curl
+# never install anything in service container, just skip the report
+[[ -z $(which curl) ]] && exit 0
 g_kind=$1
 g_uuid=$2
 g_role=$3
+g_url=$4
 g_usage=$(([[ $g_kind = "curvebs" ]] && bs_usage) || fs_usage)
-curl -XPOST http://curveadm.aspirer.wang:19302/ \
-    -d "kind=$g_kind" \
-    -d "uuid=$g_uuid" \
-    -d "role=$g_role" \
-    -d "usage=$g_usage"
+curl -s -XPOST "$g_url" \
+    -H "Content-Type: application/json" \
+    -d "{\"kind\":\"$g_kind\",\"uuid\":\"$g_uuid\",\"role\":\"$g_role\",\"usage\":${g_usage:-0},\"timestamp\":$(date +%s)}"
 `
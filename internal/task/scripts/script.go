@@ -40,4 +40,12 @@ var (
 	SCRIPT_CREATE_VOLUME     string = CREATE_VOLUME
 	SCRIPT_WAIT_CHUNKSERVERS string = WAIT_CHUNKSERVERS
 	SCRIPT_START_NGINX       string = START_NGINX
+	SCRIPT_COLLECT_CORE      string = COLLECT_CORE
+	SCRIPT_TRACE             string = TRACE
+
+	SCRIPT_RETIRE_CHUNKSERVER          string = RETIRE_CHUNKSERVER
+	SCRIPT_WAIT_CHUNKSERVER_REGISTERED string = WAIT_CHUNKSERVER_REGISTERED
+	SCRIPT_VOLUME                      string = VOLUME
+	SCRIPT_FILESYSTEM                  string = FILESYSTEM
+	SCRIPT_EXPORT                      string = EXPORT
 )
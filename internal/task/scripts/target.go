@@ -23,8 +23,15 @@
 package scripts
 
 /*
- * Usage: target USER VOLUME CREATE SIZE
- * Example: target curve test true 10
+ * Usage: target USER VOLUME CREATE SIZE BLOCKSIZE [AUTH_FILE]
+ * Example: target curve test true 10 4096 /etc/curve/target_test_curve_.auth
+ * Auth File:
+ *   INCOMING_USER=user1
+ *   INCOMING_PASSWORD=password1
+ *   OUTGOING_USER=user2
+ *   OUTGOING_PASSWORD=password2
+ *   INITIATOR_ADDRESSES=10.0.0.1,10.0.0.0/24
+ *   INITIATOR_NAMES=iqn.2023-10.com.example:host1
  * See Also: https://linux.die.net/man/8/tgtadm
  */
 
@@ -36,6 +43,7 @@ g_volume=$2
 g_create=$3
 g_size=$4
 g_blocksize=$5
+g_authfile=$6
 g_tid=1
 g_image=cbd:pool/${g_volume}_${g_user}_
 g_image_md5=$(echo -n ${g_image} | md5sum | awk '{ print $1 }')
@@ -94,13 +102,75 @@ if [ $? -ne 0 ]; then
    exit 1
 fi
 
-tgtadm --lld iscsi \
-    --mode target \
-    --op bind \
-    --tid ${g_tid} \
-    -I ALL
-if [ $? -ne 0 ]; then
-   echo "tgtadm target bind failed"
-   exit 1
+if [ -n "${g_authfile}" ] && [ -f "${g_authfile}" ]; then
+    source ${g_authfile}
 fi
+
+# print "TID [--outgoing]" for each target which the account bound to
+function account_bindings() {
+    tgtadm --lld iscsi --mode target --op show | awk -v user="$1" '
+        /^Target [0-9]+:/ { tid = substr($2, 1, length($2) - 1) }
+        /^    [A-Za-z]/ { account = ($0 ~ /^    Account information:/); next }
+        account && $1 == user { print tid, ($2 == "(outgoing)" ? "--outgoing" : "") }'
+}
+
+# tgtadm can't update password of account, so we delete the existing account,
+# create it with new password and bind it to the targets again
+function create_account() {
+    local user=$1 password=$2 bindings=""
+    tgtadm --lld iscsi --mode account --op show | grep -qw "${user}"
+    if [ $? -eq 0 ]; then
+        bindings=$(account_bindings ${user})
+        tgtadm --lld iscsi --mode account --op delete --user ${user}
+        if [ $? -ne 0 ]; then
+            echo "tgtadm account delete failed"
+            exit 1
+        fi
+    fi
+    tgtadm --lld iscsi --mode account --op new --user ${user} --password ${password}
+    if [ $? -ne 0 ]; then
+        echo "tgtadm account new failed"
+        exit 1
+    fi
+    while read -r tid outgoing; do
+        if [ -n "${tid}" ]; then
+            tgtadm --lld iscsi --mode account --op bind --tid ${tid} --user ${user} ${outgoing}
+        fi
+    done <<< "${bindings}"
+}
+
+function bind_account() {
+    local user=$1 password=$2 outgoing=$3
+    create_account ${user} ${password}
+    tgtadm --lld iscsi --mode account --op bind --tid ${g_tid} --user ${user} ${outgoing}
+    if [ $? -ne 0 ]; then
+        echo "tgtadm account bind failed"
+        exit 1
+    fi
+}
+
+function bind_initiator() {
+    tgtadm --lld iscsi --mode target --op bind --tid ${g_tid} $1 $2
+    if [ $? -ne 0 ]; then
+        echo "tgtadm target bind failed"
+        exit 1
+    fi
+}
+
+if [ -n "${INCOMING_USER}" ]; then
+    bind_account ${INCOMING_USER} ${INCOMING_PASSWORD}
+fi
+if [ -n "${OUTGOING_USER}" ]; then
+    bind_account ${OUTGOING_USER} ${OUTGOING_PASSWORD} --outgoing
+fi
+
+if [ -z "${INITIATOR_ADDRESSES}" ] && [ -z "${INITIATOR_NAMES}" ]; then
+    bind_initiator -I ALL
+fi
+for address in ${INITIATOR_ADDRESSES//,/ }; do
+    bind_initiator --initiator-address ${address}
+done
+for name in ${INITIATOR_NAMES//,/ }; do
+    bind_initiator --initiator-name ${name}
+done
 `
@@ -1,19 +1,60 @@
-#!/usr/bin/env bash
+/*
+ *  Copyright (c) 2023 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2023-10-22
+ * Author: Jingli Chen (Wine93)
+ */
+
+package scripts
+
+/*
+ * Usage: trace TOOL BINARY DURATION OUTPUT
+ * Example: trace perf /curvebs/chunkserver/sbin/curvebs-chunkserver 30 /curveadm/output
+ * Tools:
+ *   bt      trace fuse operations of curve-fuse by bpftrace (OUTPUT/bt.txt)
+ *   perf    sample on-CPU stacks by perf (OUTPUT/perf.{data,stacks,folded,svg})
+ *   offcpu  sample off-CPU stacks by bcc offcputime (OUTPUT/offcpu.{folded,svg})
+ * The flame graph is generated only if FlameGraph scripts are in PATH.
+ */
+var TRACE = `
+tool=$1
+binary=$2
+duration=$3
+output=$4
 
-# Copyright (C) Jingli Chen (Wine93)
-
-############################  GLOBAL VARIABLES
-pid=0
-dump_src=0
+die() {
+    echo "$1" >&2
+    exit 1
+}
 
-############################  FUNCTIONS
-msg() {
-    printf '%b' "$1" >&2
+# the tracer shares pid namespace with traced container
+get_pid() {
+    local name=$(basename $binary | cut -c1-15)
+    pgrep -o -x $name
 }
 
-die() {
-    msg "\33[31m[✘]\33[0m ${1}${2}"
-    exit 1
+flamegraph() {
+    local folded=$1
+    local svg=$2
+    shift 2
+    if command -v flamegraph.pl >/dev/null 2>&1; then
+        flamegraph.pl "$@" $folded > $svg
+    fi
 }
 
 gen_bpftrace_src() {
@@ -204,65 +245,39 @@ uprobe:$exec_path:FuseOpBmap
 EOF
 }
 
-get_options() {
-    while getopts "dhp:" opts
-    do
-        case $opts in
-            d)
-                dump_src=1
-                ;;
-            h)
-                usage
-                exit 0
-                ;;
-            p)
-                pid=$OPTARG
-                ;;
-            \?)
-                usage
-                exit 1
-                ;;
-        esac
-    done
-}
-
-usage () {
-    cat << _EOC_
-Usage:
-    curvefs-fuse-bt [options]
-
-Options:
-    -d                  Dump out the bpftrace script source.
-    -h                  Print this usage.
-    -p <pid>            Specify the user process pid.
-
-Examples:
-    curvefs-fuse-bt -p 12345
-    curvefs-fuse-bt -p 12345 -d
-_EOC_
-}
-
-main() {
-    get_options "$@"
-
-    if [ $pid -eq 0 ]; then
-        die "No process pid specified by the -p option.\n";
-    fi
-
-    local exec_path="/proc/${pid}/root/curvefs/client/sbin/curve-fuse"
-    if [ ! -f "$exec_path" ]; then
-        die "Process $pid is not running or " \
-             "you do not have enough permissions.\n"
-    fi
+# timeout exits with 124 when the tracing reaches duration
+trace_bt() {
+    local exec_path=/proc/$pid/root$binary
+    [ -f $exec_path ] || die "binary $binary not found in process $pid"
+    gen_bpftrace_src "$exec_path" > $output/bt.bt
+    timeout -s INT $duration bpftrace $output/bt.bt > $output/bt.txt
+    local rc=$?
+    [ $rc -eq 0 -o $rc -eq 124 ] || die "run bpftrace failed"
+}
 
-    local bpftrace_src=`gen_bpftrace_src "$exec_path"`
-    if [ $dump_src -eq  1 ]; then
-        echo "$bpftrace_src"
-        exit 0
+trace_perf() {
+    perf record -F 99 -g -p $pid -o $output/perf.data -- sleep $duration || die "run perf record failed"
+    perf script -i $output/perf.data > $output/perf.stacks || die "run perf script failed"
+    if command -v stackcollapse-perf.pl >/dev/null 2>&1; then
+        stackcollapse-perf.pl $output/perf.stacks > $output/perf.folded
+        flamegraph $output/perf.folded $output/perf.svg --title "On-CPU Flame Graph"
     fi
+}
 
-    echo "$bpftrace_src" | bpftrace -
+trace_offcpu() {
+    local offcputime=$(command -v offcputime-bpfcc || command -v offcputime || echo /usr/share/bcc/tools/offcputime)
+    $offcputime -df -p $pid $duration > $output/offcpu.folded || die "run offcputime failed"
+    flamegraph $output/offcpu.folded $output/offcpu.svg --color io --countname us --title "Off-CPU Flame Graph"
 }
 
-############################  MAIN()
-main "$@"
+pid=$(get_pid)
+[ -n "$pid" ] || die "process $(basename $binary) not found"
+mkdir -p $output
+case $tool in
+    bt) trace_bt ;;
+    perf) trace_perf ;;
+    offcpu) trace_offcpu ;;
+    *) die "unknown tool $tool" ;;
+esac
+exit 0
+`
@@ -0,0 +1,91 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package scripts
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fake perf which records nothing but the sub-command
+const FAKE_PERF = `#!/bin/bash
+case $1 in
+    record) shift; while [ "$1" != "-o" ]; do shift; done; echo record > $2 ;;
+    script) echo "sleep 1 [000] cycles: ffff do_nanosleep" ;;
+esac
+`
+
+func runTrace(t *testing.T, binDir string, args ...string) (string, error) {
+	cmd := exec.Command("bash", append([]string{"-c", TRACE, "trace"}, args...)...)
+	cmd.Env = []string{"PATH=" + binDir + ":" + os.Getenv("PATH")}
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// start a process which can be found by its binary name
+func startSleep(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Skip("start sleep failed:", err)
+	}
+	t.Cleanup(func() { cmd.Process.Kill(); cmd.Wait() })
+}
+
+func TestTrace_ProcessNotFound(t *testing.T) {
+	assert := assert.New(t)
+	output := path.Join(t.TempDir(), "output")
+	out, err := runTrace(t, t.TempDir(), "perf", "/curvebs/sbin/not-running-binary", "1", output)
+	assert.NotNil(err)
+	assert.Contains(out, "process not-running-binary not found")
+	assert.NoDirExists(output)
+}
+
+func TestTrace_UnknownTool(t *testing.T) {
+	assert := assert.New(t)
+	startSleep(t)
+	out, err := runTrace(t, t.TempDir(), "strace", "/usr/bin/sleep", "1", path.Join(t.TempDir(), "output"))
+	assert.NotNil(err)
+	assert.Contains(out, "unknown tool strace")
+}
+
+func TestTrace_Perf(t *testing.T) {
+	assert := assert.New(t)
+	startSleep(t)
+	binDir := t.TempDir()
+	assert.Nil(os.WriteFile(path.Join(binDir, "perf"), []byte(FAKE_PERF), 0755))
+
+	output := path.Join(t.TempDir(), "output")
+	out, err := runTrace(t, binDir, "perf", "/usr/bin/sleep", "1", output)
+	assert.Nil(err, out)
+	data, err := os.ReadFile(path.Join(output, "perf.data"))
+	assert.Nil(err)
+	assert.Equal("record", strings.TrimSpace(string(data)))
+	data, err = os.ReadFile(path.Join(output, "perf.stacks"))
+	assert.Nil(err)
+	assert.Contains(string(data), "do_nanosleep")
+}
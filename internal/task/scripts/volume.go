@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package scripts
+
+/*
+ * Usage: volume ACTION USER PASSWORD_FILE [ARGS...]
+ * Example: volume list root /tmp/volume.password /
+ *          volume create curve /tmp/volume.password /test 10 default
+ *          volume extend curve /tmp/volume.password /test 20
+ *          volume delete curve /tmp/volume.password /test false
+ *          volume restore curve /tmp/volume.password /test
+ * NOTE: the password file will be removed after read
+ * Output:
+ *   list: VOLUME NAME OWNER LENGTH POOLSET STATUS (one volume per line)
+ *   others: CURVEADM_OK or CURVEADM_FAIL with error message
+ */
+var VOLUME = `
+g_action=$1
+g_user=$2
+g_password=$(cat $3)
+rm -f $3
+shift 3
+
+g_toolsv2=/curvebs/tools-v2/sbin/curve
+
+function ops_tool() {
+    curve_ops_tool "$@" -userName="${g_user}" -password="${g_password}" 2>&1
+}
+
+function field() {
+    echo "$1" | sed -n "s/^ *$2: \"\{0,1\}\([^\"]*\)\"\{0,1\}$/\1/p" | head -n 1
+}
+
+# list output: /dir/file1, /dir/file2, ...
+function list_dir() {
+    ops_tool list -fileName=$1 | grep -v "Total file number" | tr ',' '\n' \
+        | sed 's/^ *//;s/ *$//' | grep -v '^$'
+}
+
+function print_volumes() {
+    local dir=$1
+    for name in $(list_dir ${dir}); do
+        if [ "${name:0:1}" != "/" ]; then
+            name=${dir%/}/${name}
+        fi
+        info=$(ops_tool get -fileName=${name})
+        if [ $? -ne 0 ]; then
+            continue
+        fi
+
+        filetype=$(field "${info}" filetype)
+        if [ "${filetype}" == "INODE_DIRECTORY" ]; then
+            print_volumes ${name}
+            continue
+        fi
+        owner=$(field "${info}" owner)
+        length=$(field "${info}" length)
+        poolset=$(field "${info}" poolset)
+        status=$(field "${info}" filestatus)
+        echo "VOLUME ${name} ${owner:--} ${length:-0} ${poolset:--} ${status:--}"
+    done
+}
+
+function check() {
+    if [ $1 -ne 0 ]; then
+        echo "CURVEADM_FAIL"
+        echo "$2"
+        exit 1
+    fi
+    echo "CURVEADM_OK"
+}
+
+case ${g_action} in
+    list)
+        print_volumes $1
+        ;;
+    create)
+        output=$(ops_tool create -fileName=$1 -fileLength=$2 ${3:+-poolset=$3})
+        check $? "${output}"
+        ;;
+    extend)
+        output=$(ops_tool extend -fileName=$1 -newSize=$2)
+        check $? "${output}"
+        ;;
+    delete)
+        output=$(ops_tool delete -fileName=$1 -forcedelete=$2)
+        check $? "${output}"
+        ;;
+    restore)
+        output=$(${g_toolsv2} bs recover volume --path=$1 --user="${g_user}" --password="${g_password}" 2>&1)
+        check $? "${output}"
+        ;;
+    *)
+        check 1 "unknown action: ${g_action}"
+        ;;
+esac
+`
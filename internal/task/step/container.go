@@ -25,8 +25,11 @@
 package step
 
 import (
+	"io"
+
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/opencurve/curveadm/pkg/module"
 )
 
@@ -38,8 +41,39 @@ type (
 	}
 
 	PullImage struct {
-		Image string
-		Out   *string
+		Image  string
+		Mirror string // pull image from private registry mirror and tag it as Image
+		Digest string // pull image by pinned digest (e.g. sha256:xxx) and tag it as Image
+		Out    *string
+		module.ExecOptions
+	}
+
+	TagImage struct {
+		Source string
+		Target string
+		Out    *string
+		module.ExecOptions
+	}
+
+	SaveImages struct {
+		Images []string
+		Output string
+		Out    *string
+		module.ExecOptions
+	}
+
+	LoadImage struct {
+		Input  string
+		Reader io.Reader // load image from reader instead of input file if set
+		Out    *string
+		module.ExecOptions
+	}
+
+	InspectImage struct {
+		Image   string
+		Format  string
+		Out     *string
+		Success *bool
 		module.ExecOptions
 	}
 
@@ -53,6 +87,7 @@ type (
 		Command           string
 		AddHost           []string
 		Devices           []string
+		DeviceWriteBps    []string
 		Entrypoint        string
 		Envs              []string
 		Hostname          string
@@ -74,6 +109,20 @@ type (
 		module.ExecOptions
 	}
 
+	// run a one-off container, e.g. analyze core file with gdb in service image
+	RunContainer struct {
+		Image      string
+		Command    string
+		Entrypoint string
+		Pid        string
+		Privileged bool
+		Volumes    []Volume
+		Remove     bool
+		Success    *bool
+		Out        *string
+		module.ExecOptions
+	}
+
 	StartContainer struct {
 		ContainerId *string
 		Success     *bool
@@ -171,9 +220,56 @@ func (s *EngineInfo) Execute(ctx *context.Context) error {
 }
 
 func (s *PullImage) Execute(ctx *context.Context) error {
-	cli := ctx.Module().DockerCli().PullImage(s.Image)
+	image := s.Image
+	if len(s.Digest) > 0 {
+		image = utils.DigestImage(s.Image, s.Digest)
+	}
+	image = utils.MirrorImage(image, s.Mirror)
+	cli := ctx.Module().DockerCli().PullImage(image)
 	out, err := cli.Execute(s.ExecOptions)
-	return PostHandle(nil, s.Out, out, err, errno.ERR_PULL_IMAGE_FAILED.FD("(%s pull IMAGE)", s.ExecWithEngine))
+	if err != nil || image == s.Image {
+		return PostHandle(nil, s.Out, out, err, errno.ERR_PULL_IMAGE_FAILED.FD("(%s pull IMAGE)", s.ExecWithEngine))
+	}
+
+	// tag the mirror or digest image, so that the container can be created by original image name
+	step := &TagImage{Source: image, Target: s.Image, Out: s.Out, ExecOptions: s.ExecOptions}
+	return step.Execute(ctx)
+}
+
+func (s *TagImage) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().TagImage(s.Source, s.Target)
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_TAG_IMAGE_FAILED.FD("(%s tag SOURCE TARGET)", s.ExecWithEngine))
+}
+
+func (s *SaveImages) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().SaveImages(s.Images...)
+	cli.AddOption("--output %s", s.Output)
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_SAVE_IMAGES_FAILED.FD("(%s save IMAGE...)", s.ExecWithEngine))
+}
+
+func (s *LoadImage) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().LoadImage()
+	var out string
+	var err error
+	if s.Reader != nil {
+		out, err = cli.ExecuteWithInput(s.ExecOptions, s.Reader)
+	} else {
+		cli.AddOption("--input %s", s.Input)
+		out, err = cli.Execute(s.ExecOptions)
+	}
+	return PostHandle(nil, s.Out, out, err, errno.ERR_LOAD_IMAGE_FAILED.FD("(%s load)", s.ExecWithEngine))
+}
+
+func (s *InspectImage) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().InspectImage(s.Image)
+	if len(s.Format) > 0 {
+		cli.AddOption("--format=%s", s.Format)
+	}
+
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_INSPECT_IMAGE_FAILED.FD("(%s image inspect IMAGE)", s.ExecWithEngine))
 }
 
 func (s *CreateContainer) Execute(ctx *context.Context) error {
@@ -184,6 +280,9 @@ func (s *CreateContainer) Execute(ctx *context.Context) error {
 	for _, device := range s.Devices {
 		cli.AddOption("--device %s", device)
 	}
+	for _, bps := range s.DeviceWriteBps {
+		cli.AddOption("--device-write-bps %s", bps)
+	}
 	if len(s.Entrypoint) > 0 {
 		cli.AddOption("--entrypoint %s", s.Entrypoint)
 	}
@@ -242,6 +341,28 @@ func (s *CreateContainer) Execute(ctx *context.Context) error {
 	return PostHandle(nil, s.Out, out, err, errno.ERR_CREATE_CONTAINER_FAILED.FD("(%s create IMAGE)", s.ExecWithEngine))
 }
 
+func (s *RunContainer) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().RunContainer(s.Image, s.Command)
+	if len(s.Entrypoint) > 0 {
+		cli.AddOption("--entrypoint %s", s.Entrypoint)
+	}
+	if len(s.Pid) > 0 {
+		cli.AddOption("--pid %s", s.Pid)
+	}
+	if s.Privileged {
+		cli.AddOption("--privileged")
+	}
+	if s.Remove {
+		cli.AddOption("--rm")
+	}
+	for _, volume := range s.Volumes {
+		cli.AddOption("--volume %s:%s", volume.HostPath, volume.ContainerPath)
+	}
+
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_RUN_CONTAINER_FAILED.FD("(%s run IMAGE)", s.ExecWithEngine))
+}
+
 func (s *StartContainer) Execute(ctx *context.Context) error {
 	cli := ctx.Module().DockerCli().StartContainer(*s.ContainerId)
 	out, err := cli.Execute(s.ExecOptions)
@@ -133,11 +133,13 @@ func (s *AddDaemonTask) Execute(ctx *context.Context) error {
 }
 
 type Target struct {
-	Host   string
-	Tid    string
-	Name   string
-	Store  string
-	Portal string
+	Host     string
+	Tid      string
+	Name     string
+	Store    string
+	Portal   string
+	Accounts []string
+	ACL      []string
 }
 
 func (s *DelDaemonTask) Execute(ctx *context.Context) error {
@@ -58,6 +58,9 @@ type (
 		HostDestPath      string
 		ContainerId       *string
 		ContainerDestPath string
+		// the content is secret (e.g. password), the installed file is only
+		// readable by its owner and no temporary file will be left
+		Secret bool
 		module.ExecOptions
 	}
 
@@ -155,7 +158,11 @@ func (s *ReadFile) Execute(ctx *context.Context) error {
 func (s *InstallFile) Execute(ctx *context.Context) error {
 	localPath := utils.RandFilename(TEMP_DIR)
 	defer os.Remove(localPath)
-	err := utils.WriteFile(localPath, *s.Content, 0644)
+	mode := 0644
+	if s.Secret {
+		mode = 0600
+	}
+	err := utils.WriteFile(localPath, *s.Content, mode)
 	if err != nil {
 		return errno.ERR_WRITE_FILE_FAILED.E(err)
 	}
@@ -167,6 +174,13 @@ func (s *InstallFile) Execute(ctx *context.Context) error {
 		if err != nil {
 			return errno.ERR_UPLOAD_FILE_TO_REMOTE_BY_SSH_FAILED.E(err)
 		}
+		if s.Secret {
+			options := module.ExecOptions{ExecTimeoutSec: s.ExecTimeoutSec, ExecSudoAlias: s.ExecSudoAlias}
+			defer ctx.Module().Shell().Remove(remotePath).Execute(options)
+			if _, err := ctx.Module().Shell().Chmod("600", remotePath).Execute(options); err != nil {
+				return errno.ERR_CHANGE_FILE_MODE_FAILED.E(err)
+			}
+		}
 	} else {
 		cmd := ctx.Module().Shell().Rename(localPath, remotePath)
 		_, err := cmd.Execute(module.ExecOptions{
@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package step
+
+import (
+	"time"
+
+	"github.com/opencurve/curveadm/internal/task/task"
+)
+
+// default policies of steps which transfer data over network,
+// they can be overridden by the playbook step (ExecOptions.Policy)
+var (
+	POLICY_PULL_IMAGE = task.Policy{
+		Retries:    3,
+		Backoff:    5 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+
+	POLICY_CURL = task.Policy{
+		Retries:    2,
+		Backoff:    3 * time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+)
+
+func (s *PullImage) Policy() task.Policy {
+	return POLICY_PULL_IMAGE
+}
+
+func (s *Curl) Policy() task.Policy {
+	return POLICY_CURL
+}
@@ -132,6 +132,9 @@ type (
 		Device     []string
 		Format     string
 		NoHeadings bool
+		Pairs      bool
+		Bytes      bool
+		Paths      bool
 		Success    *bool
 		Out        *string
 		module.ExecOptions
@@ -157,13 +160,17 @@ type (
 	}
 
 	Curl struct {
-		Url      string
-		Form     string
-		Insecure bool
-		Output   string
-		Silent   bool
-		Success  *bool
-		Out      *string
+		Url        string
+		Form       string
+		Insecure   bool
+		Output     string
+		Silent     bool
+		Fail       bool   // fail on HTTP errors
+		UploadFile string // -T
+		AwsSigv4   string // e.g. aws:amz:us-east-1:s3
+		Config     string // read options (e.g. user) from file instead of command line
+		Success    *bool
+		Out        *string
 		module.ExecOptions
 	}
 
@@ -248,6 +255,16 @@ type (
 		module.ExecOptions
 	}
 
+	// systemd
+	Systemctl struct {
+		Action  string
+		Units   []string
+		Now     bool // --now
+		Success *bool
+		Out     *string
+		module.ExecOptions
+	}
+
 	Command struct {
 		Command string
 		Success *bool
@@ -400,6 +417,15 @@ func (s *ListBlockDevice) Execute(ctx *context.Context) error {
 	if s.NoHeadings {
 		cmd.AddOption("--noheadings")
 	}
+	if s.Pairs {
+		cmd.AddOption("--pairs")
+	}
+	if s.Bytes {
+		cmd.AddOption("--bytes")
+	}
+	if s.Paths {
+		cmd.AddOption("--paths")
+	}
 
 	out, err := cmd.Execute(s.ExecOptions)
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_LIST_BLOCK_DEVICES_FAILED)
@@ -446,6 +472,18 @@ func (s *Curl) Execute(ctx *context.Context) error {
 	if s.Silent {
 		cmd.AddOption("--silent")
 	}
+	if s.Fail {
+		cmd.AddOption("--fail")
+	}
+	if len(s.UploadFile) > 0 {
+		cmd.AddOption("--upload-file %s", s.UploadFile)
+	}
+	if len(s.AwsSigv4) > 0 {
+		cmd.AddOption("--aws-sigv4 %s", s.AwsSigv4)
+	}
+	if len(s.Config) > 0 {
+		cmd.AddOption("--config %s", s.Config)
+	}
 
 	out, err := cmd.Execute(s.ExecOptions)
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_TRANSFERRING_DATA_FROM_OR_TO_SERVER_FAILED)
@@ -581,6 +619,17 @@ func (s *Scp) Execute(ctx *context.Context) error {
 	return PostHandle(nil, nil, out, err, errno.ERR_SECURE_COPY_FILE_TO_REMOTE_FAILED)
 }
 
+// systemd
+func (s *Systemctl) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl(s.Action, s.Units...)
+	if s.Now {
+		cmd.AddOption("--now")
+	}
+
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_CONTROL_SYSTEMD_UNIT_FAILED)
+}
+
 func (s *Command) Execute(ctx *context.Context) error {
 	cmd := ctx.Module().Shell().Command(s.Command)
 	out, err := cmd.Execute(s.ExecOptions)
@@ -0,0 +1,142 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package step
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SYSTEMD_UNIT_DIR = "/etc/systemd/system"
+
+	// NOTE: the container will re-map volume or re-mount filesystem by its
+	// daemon tasks or entrypoint once it started, so the unit only need to
+	// start the container after container engine and network are ready.
+	FORMAT_CONTAINER_UNIT = `[Unit]
+Description=%s
+Requires=%s.service
+After=%s.service network-online.target
+Wants=network-online.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/usr/bin/env %s start %s
+
+[Install]
+WantedBy=multi-user.target
+`
+)
+
+type (
+	// InstallContainerUnit installs a systemd unit on host which starts
+	// the specified container at boot.
+	InstallContainerUnit struct {
+		ContainerName string
+		Description   string
+		module.ExecOptions
+	}
+
+	RemoveContainerUnit struct {
+		ContainerName string
+		module.ExecOptions
+	}
+)
+
+func ContainerUnitName(containerName string) string {
+	return fmt.Sprintf("curveadm-%s.service", containerName)
+}
+
+func containerUnitPath(containerName string) string {
+	return fmt.Sprintf("%s/%s", SYSTEMD_UNIT_DIR, ContainerUnitName(containerName))
+}
+
+func (s *InstallContainerUnit) Execute(ctx *context.Context) error {
+	engine := s.ExecWithEngine
+	unit := ContainerUnitName(s.ContainerName)
+	content := fmt.Sprintf(FORMAT_CONTAINER_UNIT,
+		s.Description, engine, engine, engine, s.ContainerName)
+	steps := []interface {
+		Execute(ctx *context.Context) error
+	}{
+		&InstallFile{
+			Content:      &content,
+			HostDestPath: containerUnitPath(s.ContainerName),
+			ExecOptions:  s.ExecOptions,
+		},
+		&Systemctl{
+			Action:      "daemon-reload",
+			ExecOptions: s.ExecOptions,
+		},
+		&Systemctl{
+			Action:      "enable",
+			Units:       []string{unit},
+			ExecOptions: s.ExecOptions,
+		},
+	}
+	for _, step := range steps {
+		err := step.Execute(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RemoveContainerUnit) Execute(ctx *context.Context) error {
+	path := containerUnitPath(s.ContainerName)
+	_, err := ctx.Module().Shell().Stat(path).Execute(s.ExecOptions)
+	if err != nil { // unit not installed
+		return nil
+	}
+
+	var success bool
+	steps := []interface {
+		Execute(ctx *context.Context) error
+	}{
+		&Systemctl{
+			Action:      "disable",
+			Units:       []string{ContainerUnitName(s.ContainerName)},
+			Success:     &success, // ignore error
+			ExecOptions: s.ExecOptions,
+		},
+		&RemoveFile{
+			Files:       []string{path},
+			ExecOptions: s.ExecOptions,
+		},
+		&Systemctl{
+			Action:      "daemon-reload",
+			ExecOptions: s.ExecOptions,
+		},
+	}
+	for _, step := range steps {
+		err := step.Execute(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
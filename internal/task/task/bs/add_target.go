@@ -23,26 +23,180 @@
 package bs
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/scripts"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
-type TargetOption struct {
-	Host      string
-	User      string
-	Volume    string
-	Create    bool
-	Size      int
-	Tid       string
-	Blocksize uint64
+const (
+	KIND_CURVEBS_TARGET = "curvebs-target"
+	TARGET_AUTH_DIR     = "/etc/curve"
+)
+
+type (
+	TargetOption struct {
+		Host       string
+		User       string
+		Volume     string
+		Create     bool
+		Size       int
+		Tid        string
+		Blocksize  uint64
+		Persistent bool
+		TargetAuth
+	}
+
+	TargetAuth struct {
+		IncomingUser       string   `json:"incoming_user,omitempty"`
+		IncomingPassword   string   `json:"incoming_password,omitempty"`
+		OutgoingUser       string   `json:"outgoing_user,omitempty"`
+		OutgoingPassword   string   `json:"outgoing_password,omitempty"`
+		InitiatorAddresses []string `json:"initiator_addresses,omitempty"`
+		InitiatorNames     []string `json:"initiator_names,omitempty"`
+	}
+
+	// NOTE: CHAP credentials are encrypted by curveadm secret key
+	TargetAuxInfo struct {
+		User               string   `json:"user"`
+		Volume             string   `json:"volume"`
+		Credentials        string   `json:"credentials,omitempty"`
+		InitiatorAddresses []string `json:"initiator_addresses,omitempty"`
+		InitiatorNames     []string `json:"initiator_names,omitempty"`
+	}
+
+	step2InsertTarget struct {
+		curveadm *cli.CurveAdm
+		options  TargetOption
+		output   *string
+	}
+)
+
+func (auth TargetAuth) Empty() bool {
+	return len(auth.IncomingUser) == 0 && len(auth.OutgoingUser) == 0 &&
+		len(auth.InitiatorAddresses) == 0 && len(auth.InitiatorNames) == 0
+}
+
+func (auth TargetAuth) hasCHAP() bool {
+	return len(auth.IncomingUser) > 0 || len(auth.OutgoingUser) > 0
+}
+
+/*
+ * Output Example:
+ *   INCOMING_USER=user1
+ *   INCOMING_PASSWORD=password1
+ *   INITIATOR_ADDRESSES=10.0.0.1,10.0.0.0/24
+ */
+func (auth TargetAuth) content() string {
+	lines := []string{}
+	add := func(key, value string) {
+		if len(value) > 0 {
+			lines = append(lines, fmt.Sprintf("%s='%s'", key, value))
+		}
+	}
+	add("INCOMING_USER", auth.IncomingUser)
+	add("INCOMING_PASSWORD", auth.IncomingPassword)
+	add("OUTGOING_USER", auth.OutgoingUser)
+	add("OUTGOING_PASSWORD", auth.OutgoingPassword)
+	add("INITIATOR_ADDRESSES", strings.Join(auth.InitiatorAddresses, ","))
+	add("INITIATOR_NAMES", strings.Join(auth.InitiatorNames, ","))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func getTargetAuthPath(user, volume string) string {
+	return fmt.Sprintf("%s/target%s.auth", TARGET_AUTH_DIR, TranslateVolumeName(volume, user))
+}
+
+func encodeTargetAuxInfo(curveadm *cli.CurveAdm, options TargetOption) (string, error) {
+	auth := options.TargetAuth
+	auxInfo := &TargetAuxInfo{
+		User:               options.User,
+		Volume:             options.Volume,
+		InitiatorAddresses: auth.InitiatorAddresses,
+		InitiatorNames:     auth.InitiatorNames,
+	}
+	if auth.hasCHAP() {
+		secret, err := curveadm.SecretKey()
+		if err != nil {
+			return "", err
+		}
+		credentials, err := json.Marshal(TargetAuth{
+			IncomingUser:     auth.IncomingUser,
+			IncomingPassword: auth.IncomingPassword,
+			OutgoingUser:     auth.OutgoingUser,
+			OutgoingPassword: auth.OutgoingPassword,
+		})
+		if err != nil {
+			return "", errno.ERR_ENCRYPT_TARGET_CREDENTIALS_FAILED.E(err)
+		}
+		auxInfo.Credentials, err = utils.EncryptText(string(credentials), secret)
+		if err != nil {
+			return "", errno.ERR_ENCRYPT_TARGET_CREDENTIALS_FAILED.E(err)
+		}
+	}
+
+	bytes, err := json.Marshal(auxInfo)
+	if err != nil {
+		return "", errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+	}
+	return string(bytes), nil
+}
+
+// decode CHAP credentials which encrypted by encodeTargetAuxInfo
+func decodeTargetCredentials(auxInfo string, secretKey func() (string, error)) (TargetAuth, error) {
+	info := TargetAuxInfo{}
+	auth := TargetAuth{}
+	if err := json.Unmarshal([]byte(auxInfo), &info); err != nil {
+		return auth, errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	} else if len(info.Credentials) == 0 {
+		return auth, nil
+	}
+
+	secret, err := secretKey()
+	if err != nil {
+		return auth, err
+	}
+	credentials, err := utils.DecryptText(info.Credentials, secret)
+	if err != nil {
+		return auth, errno.ERR_DECRYPT_TARGET_CREDENTIALS_FAILED.E(err)
+	} else if err = json.Unmarshal([]byte(credentials), &auth); err != nil {
+		return auth, errno.ERR_DECRYPT_TARGET_CREDENTIALS_FAILED.E(err)
+	}
+	return auth, nil
+}
+
+// the record will be replaced if the target added again
+func (s *step2InsertTarget) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	options := s.options
+	auxInfo, err := encodeTargetAuxInfo(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	containerId := strings.Split(*s.output, " ")[0]
+	targetId := curveadm.GetTargetId(options.Host, options.User, options.Volume)
+	err = curveadm.Storage().DeleteClient(targetId)
+	if err != nil {
+		return errno.ERR_DELETE_CLIENT_FAILED.E(err)
+	}
+	err = curveadm.Storage().InsertClient(targetId, KIND_CURVEBS_TARGET,
+		options.Host, containerId, auxInfo)
+	if err != nil {
+		return errno.ERR_INSERT_CLIENT_FAILED.E(err)
+	}
+	return nil
 }
 
 func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
@@ -61,7 +215,14 @@ func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task
 	containerId := DEFAULT_TGTD_CONTAINER_NAME
 	targetScriptPath := "/curvebs/tools/sbin/target.sh"
 	targetScript := scripts.TARGET
-	cmd := fmt.Sprintf("/bin/bash %s %s %s %v %d %d", targetScriptPath, user, volume, options.Create, options.Size, options.Blocksize)
+	authPath := getTargetAuthPath(user, volume)
+	authContent := options.TargetAuth.content()
+	args := []string{user, volume, strconv.FormatBool(options.Create),
+		strconv.Itoa(options.Size), strconv.FormatUint(options.Blocksize, 10)}
+	if !options.TargetAuth.Empty() {
+		args = append(args, authPath)
+	}
+	cmd := fmt.Sprintf("/bin/bash %s %s", targetScriptPath, strings.Join(args, " "))
 	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
 
 	t.AddStep(&step.ListContainers{
@@ -86,19 +247,42 @@ func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task
 		ContainerDestPath: targetScriptPath,
 		ExecOptions:       curveadm.ExecOptions(),
 	})
+	if !options.TargetAuth.Empty() {
+		t.AddStep(&step.InstallFile{ // install target auth file
+			Content:           &authContent,
+			ContainerId:       &containerId,
+			ContainerDestPath: authPath,
+			Secret:            true,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step.ContainerExec{
 		ContainerId: &containerId,
 		Command:     cmd,
 		ExecOptions: curveadm.ExecOptions(),
 	})
 
+	// NOTE: the auth file is passed to daemon task,
+	// so the CHAP and ACL will be re-applied after tgtd restart
 	t.AddStep(&step.AddDaemonTask{ // install addTarget.task
 		ContainerId: &containerId,
 		Cmd:         "/bin/bash",
-		Args:        []string{targetScriptPath, user, volume, strconv.FormatBool(options.Create), strconv.Itoa(options.Size), strconv.FormatUint(options.Blocksize, 10)},
+		Args:        append([]string{targetScriptPath}, args...),
 		TaskName:    "addTarget" + TranslateVolumeName(volume, user),
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step2InsertTarget{
+		curveadm: curveadm,
+		options:  options,
+		output:   &output,
+	})
+	if options.Persistent {
+		t.AddStep(&step.InstallContainerUnit{
+			ContainerName: DEFAULT_TGTD_CONTAINER_NAME,
+			Description:   "CurveAdm target daemon",
+			ExecOptions:   curveadm.ExecOptions(),
+		})
+	}
 
 	return t, nil
 }
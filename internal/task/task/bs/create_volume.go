@@ -27,7 +27,6 @@ import (
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
-	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
@@ -67,7 +66,7 @@ func checkCreateStatus(out *string) step.LambdaType {
 }
 
 func NewCreateVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	options := loadMapOptions(curveadm, cc)
 	hc, err := curveadm.GetHost(options.Host)
 	if err != nil {
 		return nil, err
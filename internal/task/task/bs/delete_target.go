@@ -37,6 +37,13 @@ import (
 
 type (
 	step2CheckTgtdStatus struct{ output *string }
+
+	step2DeleteTargetAuth struct {
+		curveadm    *cli.CurveAdm
+		host        string
+		tid         string
+		containerId *string
+	}
 )
 
 // check target daemon status
@@ -50,6 +57,100 @@ func (s *step2CheckTgtdStatus) Execute(ctx *context.Context) error {
 	return nil
 }
 
+// store format: cbd:pool//volume_user_
+func parseTargetStore(store string) (user, volume string, ok bool) {
+	image := strings.TrimSuffix(strings.TrimPrefix(store, "cbd:pool/"), "_")
+	items := strings.SplitN(image, "_", 2)
+	if len(items) != 2 || !strings.HasPrefix(items[0], "/") {
+		return "", "", false
+	}
+	return items[1], items[0], true
+}
+
+// the CHAP accounts which created by target add and not bound to other targets
+func getUnusedAccounts(auth TargetAuth, targets map[string]*step.Target, tid string) []string {
+	used := map[string]bool{}
+	for id, target := range targets {
+		if id == tid {
+			continue
+		}
+		for _, account := range target.Accounts {
+			used[strings.Fields(account)[0]] = true
+		}
+	}
+
+	accounts := []string{}
+	for _, user := range []string{auth.IncomingUser, auth.OutgoingUser} {
+		if len(user) > 0 && !used[user] {
+			accounts = append(accounts, user)
+		}
+	}
+	return accounts
+}
+
+func (s *step2DeleteTargetAuth) deleteAccounts(ctx *context.Context,
+	targetId string, targets map[string]*step.Target) error {
+	clients, err := s.curveadm.Storage().GetClient(targetId)
+	if err != nil {
+		return errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 {
+		return nil
+	}
+
+	auth, err := decodeTargetCredentials(clients[0].AuxInfo, s.curveadm.SecretKey)
+	if err != nil {
+		return err
+	}
+	for _, user := range getUnusedAccounts(auth, targets, s.tid) {
+		deleteAccount := &step.ContainerExec{
+			ContainerId: s.containerId,
+			Command:     fmt.Sprintf("tgtadm --lld iscsi --mode account --op delete --user %s", user),
+			ExecOptions: s.curveadm.ExecOptions(),
+		}
+		if err := deleteAccount.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove CHAP accounts, auth file and client record which inserted by target add
+func (s *step2DeleteTargetAuth) Execute(ctx *context.Context) error {
+	v := s.curveadm.MemStorage().Get(common.KEY_ALL_TARGETS)
+	if v == nil {
+		return nil
+	}
+	targets := v.(map[string]*step.Target)
+	target := targets[s.tid]
+	if target == nil {
+		return nil
+	}
+	user, volume, ok := parseTargetStore(target.Store)
+	if !ok {
+		return nil
+	}
+
+	targetId := s.curveadm.GetTargetId(s.host, user, volume)
+	if err := s.deleteAccounts(ctx, targetId, targets); err != nil {
+		return err
+	}
+
+	removeAuth := &step.ContainerExec{
+		ContainerId: s.containerId,
+		Command:     "rm -f " + getTargetAuthPath(user, volume),
+		ExecOptions: s.curveadm.ExecOptions(),
+	}
+	if err := removeAuth.Execute(ctx); err != nil {
+		return err
+	}
+
+	err := s.curveadm.Storage().DeleteClient(targetId)
+	if err != nil {
+		return errno.ERR_DELETE_CLIENT_FAILED.E(err)
+	}
+	return nil
+}
+
 func NewDeleteTargetTask(curveadm *cli.CurveAdm, cc *client.ClientConfig) (*task.Task, error) {
 	options := curveadm.MemStorage().Get(common.KEY_TARGET_OPTIONS).(TargetOption)
 	hc, err := curveadm.GetHost(options.Host)
@@ -92,6 +193,12 @@ func NewDeleteTargetTask(curveadm *cli.CurveAdm, cc *client.ClientConfig) (*task
 		MemStorage:  curveadm.MemStorage(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step2DeleteTargetAuth{
+		curveadm:    curveadm,
+		host:        options.Host,
+		tid:         tid,
+		containerId: &containerId,
+	})
 	t.AddStep(&step.ContainerExec{
 		ContainerId: &containerId,
 		Command:     fmt.Sprintf("tgtadm --lld iscsi --mode target --op delete --tid %s", tid),
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/disks"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+type step2ParseBlockDevices struct {
+	host       string
+	lsblk      *string
+	byId       *string
+	memStorage *utils.SafeMap
+}
+
+func setBlockDevices(memStorage *utils.SafeMap, host string, devices []*disks.BlockDevice) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string][]*disks.BlockDevice{}
+		v := kv.Get(comm.KEY_ALL_BLOCK_DEVICES)
+		if v != nil {
+			m = v.(map[string][]*disks.BlockDevice)
+		}
+		m[host] = devices
+		kv.Set(comm.KEY_ALL_BLOCK_DEVICES, m)
+		return nil
+	})
+}
+
+func (s *step2ParseBlockDevices) Execute(ctx *context.Context) error {
+	devices, err := disks.ParseBlockDevices(s.host, *s.lsblk, *s.byId)
+	if err != nil {
+		return err
+	}
+	setBlockDevices(s.memStorage, s.host, devices)
+	return nil
+}
+
+func NewDiscoverDisksTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	host := hc.GetHost()
+	subname := fmt.Sprintf("host=%s", host)
+	t := task.NewTask("Discover Disks", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var lsblk, byId string
+	var success bool
+	t.AddStep(&step.ListBlockDevice{
+		Format:      disks.LSBLK_COLUMNS,
+		Pairs:       true,
+		Bytes:       true,
+		Paths:       true,
+		Out:         &lsblk,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Command{ // the directory may not exist on some hosts
+		Command:     fmt.Sprintf("find %s -type l -printf '%%f %%l\\n'", disks.DEV_DISK_BY_ID_DIR),
+		Success:     &success,
+		Out:         &byId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2ParseBlockDevices{
+		host:       host,
+		lsblk:      &lsblk,
+		byId:       &byId,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
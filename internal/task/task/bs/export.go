@@ -0,0 +1,374 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	KIND_CURVEBS_EXPORT = "curvebs-export"
+
+	EXPORT_PROTOCOL_NVMEOF = "nvmeof"
+	EXPORT_PROTOCOL_VHOST  = "vhost"
+
+	DEFAULT_NVMEOF_LISTEN_PORT = 4420
+	DEFAULT_VHOST_SOCKET_DIR   = "/var/run/curve/vhost"
+
+	EXPORT_SCRIPT_PATH = "/curvebs/nebd/sbin/export.sh"
+)
+
+type (
+	ExportOptions struct {
+		Host       string
+		User       string
+		Volume     string
+		Protocol   string
+		Create     bool
+		Size       int
+		Address    string   // nvmeof: listen address
+		Port       int      // nvmeof: listen port
+		AllowHosts []string // nvmeof: host NQNs which allowed to connect
+	}
+
+	ExportAuxInfo struct {
+		User       string   `json:"user"`
+		Volume     string   `json:"volume"`
+		Protocol   string   `json:"protocol"`
+		Endpoint   string   `json:"endpoint"`
+		AllowHosts []string `json:"allow_hosts,omitempty"`
+	}
+
+	step2InsertExport struct {
+		curveadm    *cli.CurveAdm
+		options     ExportOptions
+		containerId *string
+	}
+
+	step2UnexportVolume struct {
+		output      *string
+		auxInfo     *ExportAuxInfo
+		execOptions module.ExecOptions
+	}
+)
+
+// nvmeof: ADDRESS:PORT, vhost: socket path in host
+func (options ExportOptions) Endpoint() string {
+	if options.Protocol == EXPORT_PROTOCOL_VHOST {
+		return path.Join(DEFAULT_VHOST_SOCKET_DIR, TranslateVolumeName(options.Volume, options.User)+".sock")
+	}
+	return fmt.Sprintf("%s:%d", options.Address, options.Port)
+}
+
+// nvmeof: HOST_NQN1,HOST_NQN2, vhost: -
+func (options ExportOptions) allowHosts() string {
+	if len(options.AllowHosts) == 0 {
+		return "-"
+	}
+	return strings.Join(options.AllowHosts, ",")
+}
+
+func export2ContainerName(user, volume string) string {
+	return fmt.Sprintf("curvebs-export-%s", utils.MD5Sum(FormatImage(user, volume)))
+}
+
+func ParseExportAuxInfo(auxInfo string) (*ExportAuxInfo, error) {
+	info := &ExportAuxInfo{}
+	err := json.Unmarshal([]byte(auxInfo), info)
+	if err != nil {
+		return nil, errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	return info, nil
+}
+
+func checkExportExist(volume string, containerId *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if len(*containerId) > 0 {
+			return errno.ERR_VOLUME_ALREADY_EXPORTED.
+				F("volume: %s", volume)
+		}
+		return nil
+	}
+}
+
+func checkExportStatus(success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if *success {
+			return nil
+		}
+		return errno.ERR_EXPORT_VOLUME_FAILED.S(*out)
+	}
+}
+
+func (s *step2InsertExport) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	options := s.options
+	bytes, err := json.Marshal(&ExportAuxInfo{
+		User:       options.User,
+		Volume:     options.Volume,
+		Protocol:   options.Protocol,
+		Endpoint:   options.Endpoint(),
+		AllowHosts: options.AllowHosts,
+	})
+	if err != nil {
+		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+	}
+
+	exportId := curveadm.GetExportId(options.Host, options.User, options.Volume)
+	err = curveadm.Storage().InsertClient(exportId, KIND_CURVEBS_EXPORT,
+		options.Host, *s.containerId, string(bytes))
+	if err != nil {
+		return errno.ERR_INSERT_CLIENT_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *step2UnexportVolume) Execute(ctx *context.Context) error {
+	items := strings.Split(*s.output, " ")
+	if len(items) < 2 || !strings.HasPrefix(items[1], "Up") {
+		return nil
+	}
+
+	auxInfo := s.auxInfo
+	command := fmt.Sprintf("/bin/bash %s delete %s %s %s - - %s", EXPORT_SCRIPT_PATH,
+		auxInfo.Protocol, auxInfo.User, auxInfo.Volume, auxInfo.Endpoint)
+	dockerCli := ctx.Module().DockerCli().ContainerExec(items[0], command)
+	out, err := dockerCli.Execute(s.execOptions)
+	if err != nil {
+		return errno.ERR_UNEXPORT_VOLUME_FAILED.S(out)
+	}
+	return nil
+}
+
+func NewAddExportTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_EXPORT_OPTIONS).(ExportOptions)
+	hc, err := curveadm.GetHost(options.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("hostname=%s volume=%s:%s protocol=%s",
+		hc.GetHostname(), options.User, options.Volume, options.Protocol)
+	t := task.NewTask("Export Volume", subname, hc.GetSSHConfig())
+
+	// add step
+	var containerId, out string
+	var success bool
+	volume := fmt.Sprintf("%s:%s", options.User, options.Volume)
+	containerName := export2ContainerName(options.User, options.Volume)
+	hostname := containerName
+	host2addr := fmt.Sprintf("%s:%s", hostname, hc.GetHostname())
+	script := scripts.EXPORT
+	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
+	args := []string{EXPORT_SCRIPT_PATH, "add", options.Protocol, options.User, options.Volume,
+		strconv.FormatBool(options.Create), strconv.Itoa(options.Size), options.Endpoint(),
+		options.allowHosts()}
+	volumes := append(getVolumes(cc), step.Volume{
+		HostPath:      DEFAULT_VHOST_SOCKET_DIR,
+		ContainerPath: DEFAULT_VHOST_SOCKET_DIR,
+	})
+
+	t.AddStep(&step.EngineInfo{
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checker.CheckEngineInfo(options.Host, curveadm.ExecOptions().ExecWithEngine, &success, &out),
+	})
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.ID}}'",
+		Filter:      fmt.Sprintf("name=%s", containerName),
+		Out:         &containerId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkExportExist(volume, &containerId),
+	})
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{cc.GetLogDir(), cc.GetDataDir(), DEFAULT_VHOST_SOCKET_DIR},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ModProbe{
+		Name:        comm.KERNERL_MODULE_NBD,
+		Args:        []string{"nbds_max=64"},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.PullImage{
+		Image:       cc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.CreateContainer{
+		Image:       cc.GetContainerImage(),
+		AddHost:     []string{host2addr},
+		Envs:        []string{"LD_PRELOAD=/usr/local/lib/libjemalloc.so"},
+		Hostname:    hostname,
+		Command:     "--role nebd",
+		Name:        containerName,
+		Pid:         "host",
+		Privileged:  true,
+		Volumes:     volumes,
+		Out:         &containerId,
+		Restart:     comm.POLICY_UNLESS_STOPPED,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2InsertExport{
+		curveadm:    curveadm,
+		options:     options,
+		containerId: &containerId,
+	})
+	for _, filename := range []string{"client.conf", "nebd-server.conf"} {
+		t.AddStep(&step.SyncFile{
+			ContainerSrcId:    &containerId,
+			ContainerSrcPath:  "/curvebs/conf/" + filename,
+			ContainerDestId:   &containerId,
+			ContainerDestPath: "/curvebs/nebd/conf/" + filename,
+			KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
+			Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step.SyncFile{ // sync nebd-client config
+		ContainerSrcId:    &containerId,
+		ContainerSrcPath:  "/curvebs/conf/nebd-client.conf",
+		ContainerDestId:   &containerId,
+		ContainerDestPath: "/etc/nebd/nebd-client.conf",
+		KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
+		Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallFile{ // install tools.conf
+		Content:           &toolsConf,
+		ContainerId:       &containerId,
+		ContainerDestPath: "/etc/curve/tools.conf",
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallFile{ // install export.sh
+		Content:           &script,
+		ContainerId:       &containerId,
+		ContainerDestPath: EXPORT_SCRIPT_PATH,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.StartContainer{
+		ContainerId: &containerId,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     "/bin/bash " + strings.Join(args, " "),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkExportStatus(&success, &out),
+	})
+	// NOTE: the volume will be re-exported after container restart
+	t.AddStep(&step.AddDaemonTask{ // install modprobe.task
+		ContainerId: &containerId,
+		Cmd:         "modprobe",
+		Args:        []string{comm.KERNERL_MODULE_NBD, "nbds_max=64"},
+		TaskName:    "modProbe",
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.AddDaemonTask{ // install export.task
+		ContainerId: &containerId,
+		Cmd:         "/bin/bash",
+		Args:        args,
+		TaskName:    "export",
+		ExecOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+func NewDeleteExportTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_EXPORT_OPTIONS).(ExportOptions)
+	exportId := curveadm.GetExportId(options.Host, options.User, options.Volume)
+	client, err := curveadm.Storage().GetClient(exportId)
+	if err != nil {
+		return nil, errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(client) == 0 {
+		return nil, errno.ERR_VOLUME_NOT_EXPORTED.
+			F("host=%s volume=%s:%s", options.Host, options.User, options.Volume)
+	}
+	auxInfo, err := ParseExportAuxInfo(client[0].AuxInfo)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(options.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	containerId := client[0].ContainerId
+	subname := fmt.Sprintf("hostname=%s volume=%s:%s containerId=%s",
+		hc.GetHostname(), options.User, options.Volume, tui.TrimContainerId(containerId))
+	t := task.NewTask("Unexport Volume", subname, hc.GetSSHConfig())
+
+	// add step
+	var output string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.ID}} {{.Status}}'",
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2UnexportVolume{
+		output:      &output,
+		auxInfo:     auxInfo,
+		execOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2RemoveContainer{
+		curveadm:    curveadm,
+		status:      &output,
+		containerId: containerId,
+	})
+	t.AddStep(&step2DeleteClient{
+		curveadm: curveadm,
+		volumeId: exportId,
+	})
+
+	return t, nil
+}
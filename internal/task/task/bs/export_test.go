@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportEndpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	options := ExportOptions{
+		User:     "curve",
+		Volume:   "/test",
+		Protocol: EXPORT_PROTOCOL_NVMEOF,
+		Address:  "10.0.0.1",
+		Port:     DEFAULT_NVMEOF_LISTEN_PORT,
+	}
+	assert.Equal("10.0.0.1:4420", options.Endpoint())
+
+	options.Protocol = EXPORT_PROTOCOL_VHOST
+	assert.Equal("/var/run/curve/vhost/_test_curve_.sock", options.Endpoint())
+}
+
+func TestExportAllowHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	options := ExportOptions{Protocol: EXPORT_PROTOCOL_VHOST}
+	assert.Equal("-", options.allowHosts())
+
+	options = ExportOptions{
+		Protocol:   EXPORT_PROTOCOL_NVMEOF,
+		AllowHosts: []string{"nqn.2014-08.org.nvmexpress:uuid:1b4e28ba", "nqn.2023-10.com.example:host1"},
+	}
+	assert.Equal("nqn.2014-08.org.nvmexpress:uuid:1b4e28ba,nqn.2023-10.com.example:host1", options.allowHosts())
+}
+
+func TestParseExportAuxInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	auxInfo, err := ParseExportAuxInfo(`{"user":"curve","volume":"/test",` +
+		`"protocol":"nvmeof","endpoint":"10.0.0.1:4420","allow_hosts":["nqn.2023-10.com.example:host1"]}`)
+	assert.Nil(err)
+	assert.Equal(ExportAuxInfo{
+		User:       "curve",
+		Volume:     "/test",
+		Protocol:   EXPORT_PROTOCOL_NVMEOF,
+		Endpoint:   "10.0.0.1:4420",
+		AllowHosts: []string{"nqn.2023-10.com.example:host1"},
+	}, *auxInfo)
+
+	_, err = ParseExportAuxInfo("-")
+	assert.NotNil(err)
+}
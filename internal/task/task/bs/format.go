@@ -26,6 +26,7 @@ import (
 	"fmt"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +53,14 @@ const (
 
 	// 82511eb8-e4e3-4a50-a736-d584fbf533fa
 	REEGX_DEVICE_UUID = "^.{8}-.{4}-.{4}-.{4}-.{12}$"
+
+	// the state of formatting is saved in host, which shared by all format containers
+	FORMAT_STATE_DIR        = "/var/lib/curveadm/format"
+	FORMAT_STATE_WAITING    = "waiting"
+	FORMAT_STATE_RESUMING   = "resuming"
+	FORMAT_STATE_FORMATTING = "formatting"
+	FORMAT_STATE_DONE       = "done"
+	FORMAT_STATE_UNKNOWN    = "-"
 )
 
 type (
@@ -71,8 +80,78 @@ type (
 		diskId   string
 		curveadm *cli.CurveAdm
 	}
+	step2CreateFilesystem struct {
+		device   string
+		resume   *bool
+		curveadm *cli.CurveAdm
+	}
+
+	// state line: "UUID STATUS START_TIME START_PERCENT"
+	formatState struct {
+		uuid         string
+		status       string
+		startTime    int64
+		startPercent int
+	}
 )
 
+func parseFormatState(content string) formatState {
+	state := formatState{}
+	items := strings.Fields(content)
+	if len(items) != 4 {
+		return state
+	}
+	state.uuid, state.status = items[0], items[1]
+	state.startTime, _ = strconv.ParseInt(items[2], 10, 64)
+	state.startPercent, _ = strconv.Atoi(items[3])
+	return state
+}
+
+func formatStatePath(device string) string {
+	return fmt.Sprintf("%s/%s.state", FORMAT_STATE_DIR, utils.MD5Sum(device))
+}
+
+func getFormatOption(curveadm *cli.CurveAdm, key string) int {
+	v := curveadm.MemStorage().Get(key)
+	if v == nil {
+		return 0
+	}
+	return v.(int)
+}
+
+// the formatting which interrupted (e.g. host rebooted) will be resumed
+// instead of creating filesystem and formatting from scratch
+func checkInterruptedFormat(success *bool, state, uuid *string, resume *bool) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success { // state not exist
+			return nil
+		}
+		s := parseFormatState(*state)
+		*resume = len(*uuid) > 0 && s.uuid == strings.TrimSpace(*uuid) &&
+			s.status != FORMAT_STATE_DONE
+		return nil
+	}
+}
+
+func genFormatState(uuid *string, resume *bool, content *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		status := utils.Choose(*resume, FORMAT_STATE_RESUMING, FORMAT_STATE_WAITING)
+		*content = fmt.Sprintf("%s %s %s %s", strings.TrimSpace(*uuid), status,
+			FORMAT_STATE_UNKNOWN, FORMAT_STATE_UNKNOWN)
+		return nil
+	}
+}
+
+func (s *step2CreateFilesystem) Execute(ctx *context.Context) error {
+	if *s.resume {
+		return nil
+	}
+	return (&step.CreateFilesystem{
+		Device:      s.device,
+		ExecOptions: s.curveadm.ExecOptions(),
+	}).Execute(ctx)
+}
+
 func skipFormat(containerId *string) step.LambdaType {
 	return func(ctx *context.Context) error {
 		if len(*containerId) > 0 {
@@ -240,16 +319,26 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 	t := task.NewTask("Start Format Chunkfile Pool", subname, hc.GetSSHConfig())
 
 	// add step to task
-	var oldContainerId, containerId, oldUUID string
+	var oldContainerId, containerId, oldUUID, uuid, state, stateContent string
+	var success, resume bool
 	containerName := device2ContainerName(device)
 	layout := topology.GetCurveBSProjectLayout()
 	chunkfilePoolRootDir := layout.ChunkfilePoolRootDir
 	formatScript := scripts.SCRIPT_FORMAT
 	formatScriptPath := fmt.Sprintf("%s/format.sh", layout.ToolsBinDir)
+	statePath := formatStatePath(device)
 	increment := curveadm.MemStorage().Get(comm.FORMAT_INCREMENTAL).(bool)
-	formatCommand := fmt.Sprintf("%s %s %d %d %s %s %t", formatScriptPath, layout.FormatBinaryPath,
-		usagePercent, DEFAULT_CHUNKFILE_SIZE, layout.ChunkfilePoolDir, layout.ChunkfilePoolMetaPath, increment)
+	concurrency := getFormatOption(curveadm, comm.FORMAT_CONCURRENCY)
+	hostConcurrency := getFormatOption(curveadm, comm.FORMAT_HOST_CONCURRENCY)
+	bandwidth := getFormatOption(curveadm, comm.FORMAT_BANDWIDTH)
+	formatCommand := fmt.Sprintf("%s %s %d %d %s %s %t %s %d", formatScriptPath, layout.FormatBinaryPath,
+		usagePercent, DEFAULT_CHUNKFILE_SIZE, layout.ChunkfilePoolDir, layout.ChunkfilePoolMetaPath, increment,
+		statePath, hostConcurrency)
 	debug := curveadm.MemStorage().Get(comm.DEBUG_MODE).(bool)
+	deviceWriteBps := []string{}
+	if bandwidth > 0 {
+		deviceWriteBps = append(deviceWriteBps, fmt.Sprintf("%s:%d", device, bandwidth))
+	}
 
 	// 1: skip if formating container exist
 	t.AddStep(&step.ListContainers{
@@ -262,7 +351,7 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 	t.AddStep(&step.Lambda{
 		Lambda: skipFormat(&oldContainerId),
 	})
-	// 2: mkfs, mount device, edit fstab
+	// 2: detect interrupted formatting
 	t.AddStep(&step.ListBlockDevice{
 		Device:      []string{device},
 		Format:      "UUID",
@@ -270,6 +359,22 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 		Out:         &oldUUID,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{FORMAT_STATE_DIR},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("cat %s", statePath),
+		Success:     &success, // ignore error if state not exist
+		Out:         &state,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	if !increment && !debug {
+		t.AddStep(&step.Lambda{
+			Lambda: checkInterruptedFormat(&success, &state, &oldUUID, &resume),
+		})
+	}
+	// 3: mkfs, mount device, edit fstab
 	t.AddStep(&step.UmountFilesystem{
 		Directorys:     []string{device},
 		IgnoreUmounted: true,
@@ -281,9 +386,10 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	if !increment {
-		t.AddStep(&step.CreateFilesystem{ // mkfs.ext4 MOUNT_POINT
-			Device:      device,
-			ExecOptions: curveadm.ExecOptions(),
+		t.AddStep(&step2CreateFilesystem{ // mkfs.ext4 MOUNT_POINT
+			device:   device,
+			resume:   &resume,
+			curveadm: curveadm,
 		})
 	}
 	t.AddStep(&step.MountFilesystem{
@@ -313,18 +419,39 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 			curveadm: curveadm,
 		})
 	}
-	// 3: run container to format chunkfile pool
+	// 4: save format state
+	t.AddStep(&step.ListBlockDevice{
+		Device:      []string{device},
+		Format:      "UUID",
+		NoHeadings:  true,
+		Out:         &uuid,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: genFormatState(&uuid, &resume, &stateContent),
+	})
+	t.AddStep(&step.InstallFile{
+		Content:      &stateContent,
+		HostDestPath: statePath,
+		ExecOptions:  curveadm.ExecOptions(),
+	})
+	// 5: run container to format chunkfile pool
 	t.AddStep(&step.PullImage{
 		Image:       fc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.CreateContainer{
-		Image:       fc.GetContainerImage(),
-		Command:     formatCommand,
-		Entrypoint:  "/bin/bash",
-		Name:        containerName,
-		Remove:      !debug,
-		Volumes:     []step.Volume{{HostPath: mountPoint, ContainerPath: chunkfilePoolRootDir}},
+		Image:          fc.GetContainerImage(),
+		Command:        formatCommand,
+		DeviceWriteBps: deviceWriteBps,
+		Entrypoint:     "/bin/bash",
+		Name:           containerName,
+		Remove:         !debug,
+		Volumes: []step.Volume{
+			{HostPath: mountPoint, ContainerPath: chunkfilePoolRootDir},
+			{HostPath: FORMAT_STATE_DIR, ContainerPath: FORMAT_STATE_DIR},
+		},
 		Out:         &containerId,
 		ExecOptions: curveadm.ExecOptions(),
 	})
@@ -338,6 +465,15 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 		ContainerId: &containerId,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	// 6: wait formatting done if the global concurrency is limited
+	if concurrency > 0 && !debug {
+		t.AddStep(&step2WaitFormatDone{
+			containerName: containerName,
+			statePath:     statePath,
+			timeout:       getWaitFormatTimeout(curveadm),
+			curveadm:      curveadm,
+		})
+	}
 
 	return t, nil
 }
@@ -24,7 +24,9 @@ package bs
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -42,6 +44,8 @@ type (
 		deviceUsage     *string
 		containerStatus *string
 		containerName   string
+		state           *string
+		now             *string
 		memStorage      *utils.SafeMap
 	}
 
@@ -50,7 +54,8 @@ type (
 		Device     string
 		MountPoint string
 		Formatted  string // 85/90
-		Status     string // Done, Mounting, Pulling image, Formating
+		Status     string // Done, Mounting, Pulling image, Waiting, Formating, Interrupted
+		ETA        string // 1h2m3s
 	}
 )
 
@@ -67,6 +72,19 @@ func setFormatStatus(memStorage *utils.SafeMap, id string, status FormatStatus)
 	})
 }
 
+// estimate the remaining time by the progress since formatting started
+func estimateFormatTime(state formatState, now string, usage, percent int) string {
+	current, err := strconv.ParseInt(strings.TrimSpace(now), 10, 64)
+	if err != nil || state.startTime <= 0 ||
+		usage <= state.startPercent || usage >= percent {
+		return "-"
+	}
+
+	elapsed := current - state.startTime
+	remain := elapsed * int64(percent-usage) / int64(usage-state.startPercent)
+	return (time.Duration(remain) * time.Second).String()
+}
+
 /* deviceUsgae:
  *   Use%
  *     1%
@@ -87,7 +105,9 @@ func (s *step2FormatStatus) Execute(ctx *context.Context) error {
 	formated := fmt.Sprintf("%s/%d", deviceUsage, s.config.GetFormatPercent())
 
 	// status
-	status := "Done"
+	status, eta := "Done", "-"
+	state := parseFormatState(*s.state)
+	running := len(*s.containerStatus) > 1 && !strings.Contains(*s.containerStatus, "Exited")
 	usage, ok := utils.Str2Int(strings.TrimPrefix(deviceUsage, " "))
 	if !ok {
 		return errno.ERR_INVALID_DEVICE_USAGE.
@@ -95,8 +115,13 @@ func (s *step2FormatStatus) Execute(ctx *context.Context) error {
 	}
 	if usage == 0 {
 		status = "Mounting"
-	} else if len(*s.containerStatus) > 1 && !strings.Contains(*s.containerStatus, "Exited") {
+	} else if running && state.status == FORMAT_STATE_FORMATTING {
 		status = "Formatting"
+		eta = estimateFormatTime(state, *s.now, usage, s.config.GetFormatPercent())
+	} else if running {
+		status = "Waiting"
+	} else if state.status == FORMAT_STATE_FORMATTING {
+		status = "Interrupted"
 	} else if usage < s.config.GetFormatPercent() {
 		status = "Pulling image"
 	}
@@ -108,6 +133,7 @@ func (s *step2FormatStatus) Execute(ctx *context.Context) error {
 		MountPoint: mountPoint,
 		Formatted:  formated,
 		Status:     status,
+		ETA:        eta,
 	})
 	return nil
 }
@@ -125,7 +151,8 @@ func NewGetFormatStatusTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig)
 	t := task.NewTask("Get Format Status", subname, hc.GetSSHConfig())
 
 	// add step to task
-	var deviceUsage, containerStatus string
+	var success bool
+	var deviceUsage, containerStatus, state, now string
 	containerName := device2ContainerName(device)
 	t.AddStep(&step.ShowDiskFree{
 		Files:       []string{device},
@@ -140,11 +167,24 @@ func NewGetFormatStatusTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig)
 		Out:         &containerStatus,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("cat %s", formatStatePath(device)),
+		Success:     &success, // ignore error if state not exist
+		Out:         &state,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Command{
+		Command:     "date +%s",
+		Out:         &now,
+		ExecOptions: curveadm.ExecOptions(),
+	})
 	t.AddStep(&step2FormatStatus{
 		config:          fc,
 		deviceUsage:     &deviceUsage,
 		containerStatus: &containerStatus,
 		containerName:   containerName,
+		state:           &state,
+		now:             &now,
 		memStorage:      curveadm.MemStorage(),
 	})
 
@@ -37,6 +37,10 @@ import (
 
 const (
 	DEFAULT_TGTD_LISTEN_PORT = 3260
+
+	TARGET_SECTION_ACCOUNT = "Account information:"
+	TARGET_SECTION_ACL     = "ACL information:"
+	TARGET_SECTION_IQN     = "IQN information:"
 )
 
 type (
@@ -72,34 +76,67 @@ Target 3: iqn.2022-02.com.opencurve:curve.wine93/test03
 	    LUN: 1
 	        ...
 	        Backing store path: cbd:pool//test03_wine93_
+	Account information:
+	    user1
+	    user2 (outgoing)
+	ACL information:
+	    10.0.0.0/24
+	IQN information:
+	    iqn.2023-10.com.example:host1
 */
-func (s *step2FormatTarget) Execute(ctx *context.Context) error {
-	output := *s.output
-	lines := strings.Split(output, "\n")
-
+func ParseTargets(output, host, hostname string) []*step.Target {
+	targets := []*step.Target{}
 	var target *step.Target
+	var section string
 	titlePattern := regexp.MustCompile("^Target ([0-9]+): (.+)$")
+	sectionPattern := regexp.MustCompile("^ {4}([^ ].*:)$")
 	storePattern := regexp.MustCompile("Backing store path: (cbd:pool//.+)$")
-	for _, line := range lines {
+	for _, line := range strings.Split(output, "\n") {
 		mu := titlePattern.FindStringSubmatch(line)
 		if len(mu) > 0 {
 			target = &step.Target{
-				Host:   s.host,
-				Tid:    mu[1],
-				Name:   mu[2],
-				Store:  "-",
-				Portal: fmt.Sprintf("%s:%d", s.hostname, DEFAULT_TGTD_LISTEN_PORT),
+				Host:     host,
+				Tid:      mu[1],
+				Name:     mu[2],
+				Store:    "-",
+				Portal:   fmt.Sprintf("%s:%d", hostname, DEFAULT_TGTD_LISTEN_PORT),
+				Accounts: []string{},
+				ACL:      []string{},
 			}
-			addTarget(s.memStorage, mu[1], target)
+			targets = append(targets, target)
+			section = ""
+			continue
+		} else if target == nil {
 			continue
 		}
 
-		mu = storePattern.FindStringSubmatch(line)
+		mu = sectionPattern.FindStringSubmatch(line)
 		if len(mu) > 0 {
-			target.Store = mu[1]
+			section = mu[1]
+			continue
+		}
+
+		item := strings.TrimSpace(line)
+		switch {
+		case len(item) == 0:
+		case section == TARGET_SECTION_ACCOUNT:
+			target.Accounts = append(target.Accounts, item)
+		case section == TARGET_SECTION_ACL, section == TARGET_SECTION_IQN:
+			target.ACL = append(target.ACL, item)
+		default:
+			mu = storePattern.FindStringSubmatch(line)
+			if len(mu) > 0 {
+				target.Store = mu[1]
+			}
 		}
 	}
+	return targets
+}
 
+func (s *step2FormatTarget) Execute(ctx *context.Context) error {
+	for _, target := range ParseTargets(*s.output, s.host, s.hostname) {
+		addTarget(s.memStorage, target.Tid, target)
+	}
 	return nil
 }
 
@@ -34,6 +34,7 @@ import (
 	"github.com/opencurve/curveadm/internal/task/scripts"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
@@ -51,9 +52,32 @@ type (
 		Size        int
 		NoExclusive bool
 		Poolset     string
+		Persistent  bool
+		// replace the stale client record which has the same id
+		Replace bool
+	}
+
+	// MapOptionsSet holds map options for each client configure,
+	// which used to map multiple volumes in one playbook
+	MapOptionsSet map[*configure.ClientConfig]MapOptions
+
+	step2FixMapDevice struct {
+		containerId *string
+		scriptPath  string
+		device      *string
+		options     MapOptions
+		execOptions module.ExecOptions
 	}
 )
 
+func loadMapOptions(curveadm *cli.CurveAdm, cc *configure.ClientConfig) MapOptions {
+	v := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS)
+	if set, ok := v.(MapOptionsSet); ok {
+		return set[cc]
+	}
+	return v.(MapOptions)
+}
+
 func checkMapStatus(success *bool, out *string) step.LambdaType {
 	return func(ctx *context.Context) error {
 		if *success {
@@ -63,6 +87,61 @@ func checkMapStatus(success *bool, out *string) step.LambdaType {
 	}
 }
 
+// output line: ID IMAGE DEVICE
+func getMappedDevice(user, volume string, device *string, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		image := FormatImage(user, volume)
+		for _, line := range strings.Split(*out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 3 && fields[1] == image {
+				*device = fields[2]
+				return nil
+			}
+		}
+		return errno.ERR_MAP_VOLUME_FAILED.S(*out)
+	}
+}
+
+func (s *step2FixMapDevice) Execute(ctx *context.Context) error {
+	options := s.options
+	mapOptions := strings.TrimSpace(fmt.Sprintf("%s --device %s", getMapOptions(options), *s.device))
+	step := &step.AddDaemonTask{
+		ContainerId: s.containerId,
+		Cmd:         "/bin/bash",
+		Args:        []string{s.scriptPath, options.User, options.Volume, mapOptions},
+		TaskName:    "map",
+		ExecOptions: s.execOptions,
+	}
+	return step.Execute(ctx)
+}
+
+// persistMap makes the volume mapped to the same nbd device after host reboot
+func persistMap(curveadm *cli.CurveAdm, containerId *string, scriptPath string,
+	options MapOptions, t *task.Task) {
+	var out, device string
+	t.AddStep(&step.ContainerExec{
+		ContainerId: containerId,
+		Command:     "curve-nbd list-mapped",
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: getMappedDevice(options.User, options.Volume, &device, &out),
+	})
+	t.AddStep(&step2FixMapDevice{ // re-install map.task with fixed device
+		containerId: containerId,
+		scriptPath:  scriptPath,
+		device:      &device,
+		options:     options,
+		execOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallContainerUnit{
+		ContainerName: *containerId,
+		Description:   fmt.Sprintf("CurveAdm map volume %s:%s", options.User, options.Volume),
+		ExecOptions:   curveadm.ExecOptions(),
+	})
+}
+
 func getMapOptions(options MapOptions) string {
 	mapOptions := []string{}
 	if options.NoExclusive {
@@ -72,7 +151,7 @@ func getMapOptions(options MapOptions) string {
 }
 
 func NewMapTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	options := loadMapOptions(curveadm, cc)
 	hc, err := curveadm.GetHost(options.Host)
 	if err != nil {
 		return nil, err
@@ -154,6 +233,9 @@ func NewMapTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task,
 	t.AddStep(&step.Lambda{
 		Lambda: checkMapStatus(&success, &out),
 	})
+	if options.Persistent {
+		persistMap(curveadm, &containerId, scriptPath, options, t)
+	}
 
 	return t, nil
 }
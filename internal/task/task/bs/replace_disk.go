@@ -0,0 +1,278 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	os "github.com/opencurve/curveadm/internal/configure/os"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	WAIT_FORMAT_DONE_INTERVAL   = 3 * time.Second
+	DEFAULT_WAIT_FORMAT_TIMEOUT = 24 * time.Hour
+)
+
+type step2WaitFormatDone struct {
+	containerName string
+	statePath     string
+	timeout       time.Duration
+	curveadm      *cli.CurveAdm
+}
+
+func checkRetireChunkServerStatus(success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if *success {
+			return nil
+		} else if strings.Contains(*out, scripts.STATUS_TIMEOUT) {
+			return errno.ERR_WAIT_CHUNKSERVER_RETIRED_TIMEOUT
+		}
+		return errno.ERR_RETIRE_CHUNKSERVER_FAILED.S(*out)
+	}
+}
+
+func checkChunkServerRegistered(curveadm *cli.CurveAdm, success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_WAIT_CHUNKSERVER_REGISTERED_TIMEOUT.S(*out)
+		}
+		curveadm.MemStorage().Set(comm.KEY_REPLACE_NEW_CHUNKSERVER_ID, strings.TrimSpace(*out))
+		return nil
+	}
+}
+
+func getWaitFormatTimeout(curveadm *cli.CurveAdm) time.Duration {
+	v := curveadm.MemStorage().Get(comm.KEY_REPLACE_FORMAT_TIMEOUT)
+	if v == nil || v.(int) <= 0 {
+		return DEFAULT_WAIT_FORMAT_TIMEOUT
+	}
+	return time.Duration(v.(int)) * time.Second
+}
+
+// the formatting container will be removed after it exited, so we
+// check the state file which marked as done only if formatting succeed
+func (s *step2WaitFormatDone) checkFormatState(ctx *context.Context) error {
+	var success bool
+	var out string
+	err := (&step.Command{
+		Command:     fmt.Sprintf("cat %s", s.statePath),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.curveadm.ExecOptions(),
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	} else if !success {
+		return errno.ERR_FORMAT_CHUNKFILE_POOL_FAILED.
+			F("format state %s not found", s.statePath)
+	} else if state := parseFormatState(out); state.status != FORMAT_STATE_DONE {
+		return errno.ERR_FORMAT_CHUNKFILE_POOL_FAILED.
+			F("container %s exited with format state '%s'", s.containerName, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+func (s *step2WaitFormatDone) Execute(ctx *context.Context) error {
+	deadline := time.Now().Add(s.timeout)
+	for {
+		var status string
+		err := (&step.ListContainers{
+			ShowAll:     true,
+			Format:      "'{{.Status}}'",
+			Filter:      fmt.Sprintf("name=%s", s.containerName),
+			Out:         &status,
+			ExecOptions: s.curveadm.ExecOptions(),
+		}).Execute(ctx)
+		if err != nil {
+			return err
+		} else if len(status) == 0 || strings.Contains(status, "Exited") {
+			return s.checkFormatState(ctx)
+		} else if time.Now().After(deadline) {
+			return errno.ERR_WAIT_FORMAT_DONE_TIMEOUT.
+				F("container %s is still running after %s", s.containerName, s.timeout)
+		}
+		time.Sleep(WAIT_FORMAT_DONE_INTERVAL)
+	}
+}
+
+func newReplaceToolTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig,
+	name string) (*task.Task, string, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, containerId, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, containerId, err
+	}
+
+	cs := curveadm.MemStorage().Get(comm.KEY_REPLACE_CHUNKSERVER).(*topology.DeployConfig)
+	subname := fmt.Sprintf("host=%s chunkserver=%s:%d containerId=%s",
+		dc.GetHost(), cs.GetListenIp(), cs.GetListenPort(), tui.TrimContainerId(containerId))
+	return task.NewTask(name, subname, hc.GetSSHConfig()), containerId, nil
+}
+
+// NOTE: the tool commands run in the mds container, because the
+// container of the replaced chunkserver maybe exited with the failed disk
+func NewRetireChunkServerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	t, containerId, err := newReplaceToolTask(curveadm, dc, "Retire ChunkServer")
+	if err != nil {
+		return nil, err
+	}
+
+	// add step to task
+	var success bool
+	var out string
+	cs := curveadm.MemStorage().Get(comm.KEY_REPLACE_CHUNKSERVER).(*topology.DeployConfig)
+	timeout := curveadm.MemStorage().Get(comm.KEY_REPLACE_RETIRE_TIMEOUT).(int)
+	layout := dc.GetProjectLayout()
+	script := scripts.SCRIPT_RETIRE_CHUNKSERVER
+	scriptPath := fmt.Sprintf("%s/retire_chunkserver.sh", layout.ToolsBinDir)
+	command := fmt.Sprintf("bash %s %s %s %d %d", scriptPath, layout.ToolsBinaryPath,
+		cs.GetListenIp(), cs.GetListenPort(), timeout)
+	// waiting copysets recovered lasts for the retire timeout, so extend the timeout
+	execOptions := curveadm.ExecOptions()
+	if execOptions.ExecTimeoutSec > 0 {
+		execOptions.ExecTimeoutSec += timeout
+	}
+	t.AddStep(&step.InstallFile{ // install retire_chunkserver script
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{ // mark pendding and wait copysets recovered
+		ContainerId: &containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: execOptions,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkRetireChunkServerStatus(&success, &out),
+	})
+
+	return t, nil
+}
+
+func NewWaitChunkServerRegisteredTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	t, containerId, err := newReplaceToolTask(curveadm, dc, "Wait ChunkServer Registered")
+	if err != nil {
+		return nil, err
+	}
+
+	// add step to task
+	var success bool
+	var out string
+	cs := curveadm.MemStorage().Get(comm.KEY_REPLACE_CHUNKSERVER).(*topology.DeployConfig)
+	layout := dc.GetProjectLayout()
+	script := scripts.SCRIPT_WAIT_CHUNKSERVER_REGISTERED
+	scriptPath := fmt.Sprintf("%s/wait_chunkserver_registered.sh", layout.ToolsBinDir)
+	command := fmt.Sprintf("bash %s %s %d", scriptPath, cs.GetListenIp(), cs.GetListenPort())
+	t.AddStep(&step.InstallFile{ // install wait_chunkserver_registered script
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkChunkServerRegistered(curveadm, &success, &out),
+	})
+
+	return t, nil
+}
+
+// umount the failed disk and remove its record in fstab which added by format
+func NewUmountDiskTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(fc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	mountPoint := fc.GetMountPoint()
+	subname := fmt.Sprintf("host=%s device=%s mountPoint=%s",
+		fc.GetHost(), fc.GetDevice(), mountPoint)
+	t := task.NewTask("Umount Disk", subname, hc.GetSSHConfig())
+
+	// add step to task
+	express2del := fmt.Sprintf(`\|\s%s\s.*# %s|d`, mountPoint, WARNING_EDIT)
+	t.AddStep(&step.UmountFilesystem{
+		Directorys:     []string{mountPoint},
+		IgnoreUmounted: true,
+		IgnoreNotFound: true,
+		ExecOptions:    curveadm.ExecOptions(),
+	})
+	if !fc.ServiceMountDevice {
+		t.AddStep(&step.Sed{
+			Files:       []string{os.GetFSTabPath()},
+			Expression:  &express2del,
+			InPlace:     true,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
+
+func NewWaitFormatDoneTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(fc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s device=%s mountPoint=%s",
+		fc.GetHost(), fc.GetDevice(), fc.GetMountPoint())
+	t := task.NewTask("Wait Format Done", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2WaitFormatDone{
+		containerName: device2ContainerName(fc.GetDevice()),
+		statePath:     formatStatePath(fc.GetDevice()),
+		timeout:       getWaitFormatTimeout(curveadm),
+		curveadm:      curveadm,
+	})
+
+	return t, nil
+}
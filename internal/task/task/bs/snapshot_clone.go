@@ -0,0 +1,232 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	SNAPSHOT_CLONE_SERVICE = "SnapshotCloneService"
+	SNAPSHOT_CLONE_VERSION = "0.0.6"
+	SNAPSHOT_CLONE_CODE_OK = "0"
+
+	SNAPSHOT_CLONE_ACTION_CREATE_SNAPSHOT = "CreateSnapshot"
+	SNAPSHOT_CLONE_ACTION_DELETE_SNAPSHOT = "DeleteSnapshot"
+	SNAPSHOT_CLONE_ACTION_LIST_SNAPSHOTS  = "GetFileSnapshotInfo"
+	SNAPSHOT_CLONE_ACTION_CLONE           = "Clone"
+	SNAPSHOT_CLONE_ACTION_FLATTEN         = "Flatten"
+	SNAPSHOT_CLONE_ACTION_LIST_CLONES     = "GetCloneTasks"
+
+	SNAPSHOT_CLONE_LIST_LIMIT = 1000
+)
+
+var (
+	SNAPSHOT_STATUS = map[int]string{
+		0: "done",
+		1: "pending",
+		2: "deleting",
+		3: "errorDeleting",
+		4: "canceling",
+		5: "error",
+	}
+
+	CLONE_STATUS = map[int]string{
+		0: "done",
+		1: "cloning",
+		2: "recovering",
+		3: "cleaning",
+		4: "errorCleaning",
+		5: "error",
+		6: "retrying",
+		7: "metaInstalled",
+	}
+)
+
+type (
+	SnapshotCloneOptions struct {
+		Action      string
+		User        string
+		Volume      string
+		Name        string // snapshot name
+		UUID        string // snapshot or clone task uuid
+		Source      string // volume or snapshot uuid
+		Destination string
+		Lazy        bool
+	}
+
+	Snapshot struct {
+		UUID       string `json:"UUID"`
+		User       string `json:"User"`
+		File       string `json:"File"`
+		Name       string `json:"Name"`
+		FileLength uint64 `json:"FileLength"`
+		SeqNum     uint64 `json:"SeqNum"`
+		Status     int    `json:"Status"`
+		Progress   int    `json:"Progress"`
+		Time       uint64 `json:"Time"`
+	}
+
+	CloneTask struct {
+		UUID       string `json:"UUID"`
+		User       string `json:"User"`
+		File       string `json:"File"`
+		Src        string `json:"Src"`
+		IsLazy     bool   `json:"IsLazy"`
+		TaskType   int    `json:"TaskType"`
+		TaskStatus int    `json:"TaskStatus"`
+		Progress   int    `json:"Progress"`
+		Time       uint64 `json:"Time"`
+	}
+
+	SnapshotCloneResponse struct {
+		Code       string      `json:"Code"`
+		Message    string      `json:"Message"`
+		RequestId  string      `json:"RequestId"`
+		UUID       string      `json:"UUID"`
+		TotalCount int         `json:"TotalCount"`
+		Snapshots  []Snapshot  `json:"Snapshots"`
+		TaskInfos  []CloneTask `json:"TaskInfos"`
+	}
+
+	step2ParseSnapshotCloneResponse struct {
+		output     *string
+		memStorage *utils.SafeMap
+	}
+)
+
+func getSnapshotCloneURL(dc *topology.DeployConfig, options SnapshotCloneOptions) string {
+	query := url.Values{}
+	query.Set("Action", options.Action)
+	query.Set("Version", SNAPSHOT_CLONE_VERSION)
+	query.Set("User", options.User)
+	switch options.Action {
+	case SNAPSHOT_CLONE_ACTION_CREATE_SNAPSHOT:
+		query.Set("File", options.Volume)
+		query.Set("Name", options.Name)
+	case SNAPSHOT_CLONE_ACTION_DELETE_SNAPSHOT:
+		query.Set("File", options.Volume)
+		query.Set("UUID", options.UUID)
+	case SNAPSHOT_CLONE_ACTION_LIST_SNAPSHOTS:
+		if len(options.Volume) > 0 {
+			query.Set("File", options.Volume)
+		}
+		query.Set("Limit", strconv.Itoa(SNAPSHOT_CLONE_LIST_LIMIT))
+	case SNAPSHOT_CLONE_ACTION_CLONE:
+		query.Set("Source", options.Source)
+		query.Set("Destination", options.Destination)
+		query.Set("Lazy", strconv.FormatBool(options.Lazy))
+	case SNAPSHOT_CLONE_ACTION_FLATTEN:
+		query.Set("UUID", options.UUID)
+	case SNAPSHOT_CLONE_ACTION_LIST_CLONES:
+		query.Set("Limit", strconv.Itoa(SNAPSHOT_CLONE_LIST_LIMIT))
+	}
+
+	return fmt.Sprintf("http://%s:%d/%s?%s",
+		dc.GetListenIp(), dc.GetListenProxyPort(), SNAPSHOT_CLONE_SERVICE, query.Encode())
+}
+
+func ParseSnapshotCloneResponse(output string) (*SnapshotCloneResponse, error) {
+	response := &SnapshotCloneResponse{}
+	err := json.Unmarshal([]byte(output), response)
+	if err != nil {
+		return nil, errno.ERR_DECODE_SNAPSHOT_CLONE_RESPONSE.
+			E(err).
+			F("response: %s", output)
+	} else if response.Code != SNAPSHOT_CLONE_CODE_OK {
+		return nil, errno.ERR_SNAPSHOT_CLONE_REQUEST_FAILED.
+			F("code: %s, message: %s", response.Code, response.Message)
+	}
+	return response, nil
+}
+
+func (s *step2ParseSnapshotCloneResponse) Execute(ctx *context.Context) error {
+	response, err := ParseSnapshotCloneResponse(*s.output)
+	if err != nil {
+		return err
+	}
+	s.memStorage.Set(comm.KEY_SNAPSHOT_CLONE_RESPONSE, response)
+	return nil
+}
+
+func checkSnapshotCloneRequestStatus(success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_SNAPSHOT_CLONE_REQUEST_FAILED.S(*out)
+		}
+		return nil
+	}
+}
+
+// NOTE: the request is sent from the snapshotclone container to its nginx proxy
+func NewSnapshotCloneTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_SNAPSHOT_CLONE_OPTIONS).(SnapshotCloneOptions)
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s action=%s containerId=%s",
+		dc.GetHost(), options.Action, tui.TrimContainerId(containerId))
+	t := task.NewTask("Request SnapshotClone Service", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	command := fmt.Sprintf("curl --silent --max-time 30 '%s'", getSnapshotCloneURL(dc, options))
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkSnapshotCloneRequestStatus(&success, &out),
+	})
+	t.AddStep(&step2ParseSnapshotCloneResponse{
+		output:     &out,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
@@ -31,6 +31,7 @@ import (
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
@@ -50,19 +51,24 @@ type (
 		containerId *string
 	}
 
+	// the map options are saved for re-mapping the stale client
 	AuxInfo struct {
-		User   string `json:"user"`
-		Volume string `json:"volume,"`
-		Config string `json:"config,omitempty"` // TODO(P1)
+		User        string `json:"user"`
+		Volume      string `json:"volume,"`
+		Size        int    `json:"size,omitempty"`
+		NoExclusive bool   `json:"no_exclusive,omitempty"`
+		Poolset     string `json:"poolset,omitempty"`
+		Persistent  bool   `json:"persistent,omitempty"`
+		Config      string `json:"config,omitempty"` // TODO(P1)
 	}
 )
 
-func formatImage(user, volume string) string {
+func FormatImage(user, volume string) string {
 	return fmt.Sprintf("cbd:pool/%s_%s_", volume, user)
 }
 
 func volume2ContainerName(user, volume string) string {
-	return fmt.Sprintf("curvebs-volume-%s", utils.MD5Sum(formatImage(user, volume)))
+	return fmt.Sprintf("curvebs-volume-%s", utils.MD5Sum(FormatImage(user, volume)))
 }
 
 func checkVolumeExist(volume string, containerId *string) step.LambdaType {
@@ -122,16 +128,27 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 	volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
 
 	auxInfo := &AuxInfo{
-		User:   options.User,
-		Volume: options.Volume,
+		User:        options.User,
+		Volume:      options.Volume,
+		Size:        options.Size,
+		NoExclusive: options.NoExclusive,
+		Poolset:     options.Poolset,
+		Persistent:  options.Persistent,
 	}
 	bytes, err := json.Marshal(auxInfo)
 	if err != nil {
 		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
 	}
 
-	err = curveadm.Storage().InsertClient(volumeId, config.GetKind(),
-		options.Host, *s.containerId, string(bytes))
+	err = curveadm.Storage().Transaction(func(tx storage.Storage) error {
+		if options.Replace {
+			if err := tx.DeleteClient(volumeId); err != nil {
+				return err
+			}
+		}
+		return tx.InsertClient(volumeId, config.GetKind(),
+			options.Host, *s.containerId, string(bytes))
+	})
 	if err != nil {
 		return errno.ERR_INSERT_CLIENT_FAILED.E(err)
 	}
@@ -139,7 +156,7 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 }
 
 func NewStartNEBDServiceTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	options := loadMapOptions(curveadm, cc)
 	hc, err := curveadm.GetHost(options.Host)
 	if err != nil {
 		return nil, err
@@ -180,6 +197,7 @@ func NewStartNEBDServiceTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig)
 	})
 	t.AddStep(&step.PullImage{
 		Image:       cc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.CreateContainer{
@@ -86,6 +86,7 @@ func NewStartTargetDaemonTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig
 	})
 	t.AddStep(&step.PullImage{
 		Image:       cc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.CreateContainer{
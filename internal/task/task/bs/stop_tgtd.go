@@ -54,6 +54,10 @@ func NewStopTargetDaemonTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task,
 
 	// add step
 	var containerId string
+	t.AddStep(&step.RemoveContainerUnit{
+		ContainerName: DEFAULT_TGTD_CONTAINER_NAME,
+		ExecOptions:   curveadm.ExecOptions(),
+	})
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      "'{{.ID}}'",
@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TGTADM_SHOW_OUTPUT = `Target 1: iqn.2023-10.com.opencurve:curve.curve/test
+    System information:
+        Driver: iscsi
+        State: ready
+    I_T nexus information:
+    LUN information:
+        LUN: 0
+            Type: controller
+            Backing store path: None
+        LUN: 1
+            Type: disk
+            Backing store path: cbd:pool//test_curve_
+    Account information:
+        user1
+        user2 (outgoing)
+    ACL information:
+        10.0.0.0/24
+    IQN information:
+        iqn.2023-10.com.example:host1
+Target 2: iqn.2023-10.com.opencurve:curve.curve/test2
+    LUN information:
+        LUN: 1
+            Backing store path: cbd:pool//test2_curve_
+    Account information:
+    ACL information:
+        ALL
+`
+)
+
+func TestParseTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	targets := ParseTargets(TGTADM_SHOW_OUTPUT, "host1", "10.0.0.10")
+	assert.Len(targets, 2)
+	assert.Equal("1", targets[0].Tid)
+	assert.Equal("cbd:pool//test_curve_", targets[0].Store)
+	assert.Equal("10.0.0.10:3260", targets[0].Portal)
+	assert.Equal([]string{"user1", "user2 (outgoing)"}, targets[0].Accounts)
+	assert.Equal([]string{"10.0.0.0/24", "iqn.2023-10.com.example:host1"}, targets[0].ACL)
+	assert.Equal("cbd:pool//test2_curve_", targets[1].Store)
+	assert.Len(targets[1].Accounts, 0)
+	assert.Equal([]string{"ALL"}, targets[1].ACL)
+}
+
+func TestParseTargetStore(t *testing.T) {
+	assert := assert.New(t)
+
+	user, volume, ok := parseTargetStore("cbd:pool//dir/test_curve_")
+	assert.True(ok)
+	assert.Equal("curve", user)
+	assert.Equal("/dir/test", volume)
+
+	_, _, ok = parseTargetStore("-")
+	assert.False(ok)
+}
+
+func TestTargetAuthContent(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := TargetAuth{
+		IncomingUser:       "user1",
+		IncomingPassword:   "password1234",
+		InitiatorAddresses: []string{"10.0.0.1", "10.0.1.0/24"},
+	}
+	assert.False(auth.Empty())
+	assert.Equal("INCOMING_USER='user1'\n"+
+		"INCOMING_PASSWORD='password1234'\n"+
+		"INITIATOR_ADDRESSES='10.0.0.1,10.0.1.0/24'\n", auth.content())
+	assert.True(TargetAuth{}.Empty())
+}
+
+func TestDecodeTargetCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	secretKey := func() (string, error) { return "secret", nil }
+	credentials, err := utils.EncryptText(`{"incoming_user":"user1","incoming_password":"password1234"}`, "secret")
+	assert.Nil(err)
+	auxInfo, err := json.Marshal(TargetAuxInfo{User: "curve", Volume: "/test", Credentials: credentials})
+	assert.Nil(err)
+
+	auth, err := decodeTargetCredentials(string(auxInfo), secretKey)
+	assert.Nil(err)
+	assert.Equal(TargetAuth{IncomingUser: "user1", IncomingPassword: "password1234"}, auth)
+
+	// wrong secret key
+	_, err = decodeTargetCredentials(string(auxInfo), func() (string, error) { return "other", nil })
+	assert.Equal(errno.ERR_DECRYPT_TARGET_CREDENTIALS_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// without CHAP
+	auth, err = decodeTargetCredentials(`{"user":"curve","volume":"/test"}`, secretKey)
+	assert.Nil(err)
+	assert.False(auth.hasCHAP())
+}
+
+func TestGetUnusedAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	targets := map[string]*step.Target{}
+	for _, target := range ParseTargets(TGTADM_SHOW_OUTPUT, "host1", "10.0.0.10") {
+		targets[target.Tid] = target
+	}
+	auth := TargetAuth{IncomingUser: "user1", OutgoingUser: "user2"}
+	assert.Equal([]string{"user1", "user2"}, getUnusedAccounts(auth, targets, "1"))
+
+	// user2 is bound to target 2 as well
+	targets["2"].Accounts = []string{"user2"}
+	assert.Equal([]string{"user1"}, getUnusedAccounts(auth, targets, "1"))
+	assert.Len(getUnusedAccounts(TargetAuth{}, targets, "1"), 0)
+}
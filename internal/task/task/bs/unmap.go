@@ -125,7 +125,10 @@ func (s *step2DeleteClient) Execute(ctx *context.Context) error {
 }
 
 func NewUnmapTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	options, ok := v.(MapOptions)
+	if !ok {
+		options = curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	}
 	volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
 	containerId, err := curveadm.Storage().GetClientContainerId(volumeId)
 	if err != nil {
@@ -142,6 +145,10 @@ func NewUnmapTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 
 	// add step
 	var output string
+	t.AddStep(&step.RemoveContainerUnit{
+		ContainerName: volume2ContainerName(options.User, options.Volume),
+		ExecOptions:   curveadm.ExecOptions(),
+	})
 	t.AddStep(&step.Lambda{
 		Lambda: checkContainerId(containerId),
 	})
@@ -0,0 +1,197 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	VOLUME_ACTION_LIST    = "list"
+	VOLUME_ACTION_CREATE  = "create"
+	VOLUME_ACTION_EXTEND  = "extend"
+	VOLUME_ACTION_DELETE  = "delete"
+	VOLUME_ACTION_RESTORE = "restore"
+
+	RECYCLE_BIN_DIR = "/RecycleBin"
+)
+
+type (
+	VolumeOptions struct {
+		Action   string
+		User     string
+		Password string
+		Volume   string // volume name or directory for list
+		Size     int    // GiB
+		Poolset  string
+		Force    bool
+	}
+
+	Volume struct {
+		Name    string
+		Owner   string
+		Length  uint64
+		Poolset string
+		Status  string
+	}
+
+	step2ParseVolumes struct {
+		output     *string
+		memStorage *utils.SafeMap
+	}
+)
+
+// the password is passed by file, which will be removed by script after read
+func getVolumeArgs(options VolumeOptions, passwordPath string) []string {
+	args := []string{options.Action, options.User, passwordPath}
+	switch options.Action {
+	case VOLUME_ACTION_LIST:
+		args = append(args, options.Volume)
+	case VOLUME_ACTION_CREATE:
+		args = append(args, options.Volume, strconv.Itoa(options.Size), options.Poolset)
+	case VOLUME_ACTION_EXTEND:
+		args = append(args, options.Volume, strconv.Itoa(options.Size))
+	case VOLUME_ACTION_DELETE:
+		args = append(args, options.Volume, strconv.FormatBool(options.Force))
+	case VOLUME_ACTION_RESTORE:
+		args = append(args, options.Volume)
+	}
+	return args
+}
+
+// output line: VOLUME NAME OWNER LENGTH POOLSET STATUS
+func ParseVolumes(output string) []Volume {
+	volumes := []Volume{}
+	for _, line := range strings.Split(output, "\n") {
+		items := strings.Fields(line)
+		if len(items) != 6 || items[0] != "VOLUME" {
+			continue
+		}
+		length, _ := strconv.ParseUint(items[3], 10, 64)
+		volumes = append(volumes, Volume{
+			Name:    items[1],
+			Owner:   items[2],
+			Length:  length,
+			Poolset: items[4],
+			Status:  items[5],
+		})
+	}
+	return volumes
+}
+
+func (s *step2ParseVolumes) Execute(ctx *context.Context) error {
+	s.memStorage.Set(comm.KEY_ALL_VOLUMES, ParseVolumes(*s.output))
+	return nil
+}
+
+func checkManageVolumeStatus(action string, success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_MANAGE_VOLUME_FAILED.S(*out)
+		} else if action == VOLUME_ACTION_LIST {
+			return nil
+		}
+
+		lines := strings.SplitN(*out, "\n", 2)
+		if lines[0] == scripts.STATUS_OK {
+			return nil
+		}
+		return errno.ERR_MANAGE_VOLUME_FAILED.S(*out)
+	}
+}
+
+// NOTE: the tool commands run in the mds container
+func NewManageVolumeTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_VOLUME_OPTIONS).(VolumeOptions)
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	name := utils.Choose(options.Action == VOLUME_ACTION_LIST, "List Volumes",
+		fmt.Sprintf("%s Volume", strings.Title(options.Action)))
+	subname := fmt.Sprintf("host=%s volume=%s containerId=%s",
+		dc.GetHost(), options.Volume, tui.TrimContainerId(containerId))
+	t := task.NewTask(name, subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	layout := dc.GetProjectLayout()
+	script := scripts.SCRIPT_VOLUME
+	scriptPath := fmt.Sprintf("%s/volume.sh", layout.ToolsBinDir)
+	password := options.Password
+	passwordPath := fmt.Sprintf("%s.password", utils.RandFilename(layout.ToolsBinDir))
+	command := fmt.Sprintf("bash %s %s", scriptPath,
+		strings.Join(getVolumeArgs(options, passwordPath), " "))
+	t.AddStep(&step.InstallFile{ // install volume script
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallFile{ // install password of volume user
+		ContainerId:       &containerId,
+		ContainerDestPath: passwordPath,
+		Content:           &password,
+		Secret:            true,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkManageVolumeStatus(options.Action, &success, &out),
+	})
+	if options.Action == VOLUME_ACTION_LIST {
+		t.AddStep(&step2ParseVolumes{
+			output:     &out,
+			memStorage: curveadm.MemStorage(),
+		})
+	}
+
+	return t, nil
+}
@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVolumes(t *testing.T) {
+	assert := assert.New(t)
+
+	volumes := ParseVolumes("VOLUME /test curve 10737418240 default kFileCreated\n" +
+		"some warning message\n" +
+		"VOLUME /dir/test2 curve 21474836480 - kFileCreated\n")
+	assert.Equal([]Volume{
+		{Name: "/test", Owner: "curve", Length: 10737418240, Poolset: "default", Status: "kFileCreated"},
+		{Name: "/dir/test2", Owner: "curve", Length: 21474836480, Poolset: "-", Status: "kFileCreated"},
+	}, volumes)
+
+	assert.Len(ParseVolumes(""), 0)
+}
+
+func TestGetVolumeArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	// password is passed by file instead of command line
+	args := getVolumeArgs(VolumeOptions{
+		Action:   VOLUME_ACTION_CREATE,
+		User:     "curve",
+		Password: "pass'; rm -rf /; '",
+		Volume:   "/test",
+		Size:     10,
+		Poolset:  "ssd",
+	}, "/tmp/volume.password")
+	assert.Equal([]string{"create", "curve", "/tmp/volume.password", "/test", "10", "ssd"}, args)
+
+	args = getVolumeArgs(VolumeOptions{
+		Action: VOLUME_ACTION_DELETE,
+		User:   "curve",
+		Volume: "/test",
+		Force:  true,
+	}, "/tmp/volume.password")
+	assert.Equal([]string{"delete", "curve", "/tmp/volume.password", "/test", "true"}, args)
+}
+
+func TestParseSnapshotCloneResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	response, err := ParseSnapshotCloneResponse(`{"Code":"0","Message":"Exec success.",` +
+		`"RequestId":"abc","TotalCount":1,"Snapshots":[{"File":"/test","FileLength":10737418240,` +
+		`"Name":"snap1","Progress":100,"SeqNum":1,"Status":0,"Time":1697097600000000,"UUID":"uuid1","User":"curve"}]}`)
+	assert.Nil(err)
+	assert.Equal(1, response.TotalCount)
+	assert.Len(response.Snapshots, 1)
+	assert.Equal("uuid1", response.Snapshots[0].UUID)
+	assert.Equal("snap1", response.Snapshots[0].Name)
+
+	_, err = ParseSnapshotCloneResponse(`{"Code":"-8","Message":"File not exist."}`)
+	assert.NotNil(err)
+
+	_, err = ParseSnapshotCloneResponse("<html>502 Bad Gateway</html>")
+	assert.NotNil(err)
+}
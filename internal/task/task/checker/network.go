@@ -134,6 +134,7 @@ func NewCheckPortInUseTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*
 	var success bool
 	t.AddStep(&step.PullImage{
 		Image:       dc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.CreateContainer{
@@ -262,6 +263,7 @@ func NewStartHTTPServerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 	command := fmt.Sprintf("%s '%s'", scriptPath, getNginxListens(dc))
 	t.AddStep(&step.PullImage{
 		Image:       dc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.CreateContainer{
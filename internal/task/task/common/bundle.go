@@ -0,0 +1,278 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+/*
+ * curve-bundle-20231018194017.tar.gz
+ *   manifest.json
+ *   images.tar  # docker save IMAGE...
+ *   curveadm    # curveadm binary
+ */
+const (
+	BUNDLE_MANIFEST_FILE = "manifest.json"
+	BUNDLE_IMAGES_FILE   = "images.tar"
+	BUNDLE_BINARY_FILE   = "curveadm"
+)
+
+type (
+	BundleOptions struct {
+		Dir     string   // the directory which bundle created in or unpacked to
+		Archive string   // /path/to/curve-bundle-20231018194017.tar.gz
+		Images  []string // images required by topology, monitor, website and clients
+		Binary  string   // curveadm binary, only for create
+	}
+
+	BundleManifest struct {
+		Version string   `json:"version"`
+		Created string   `json:"created"`
+		Images  []string `json:"images"`
+	}
+
+	step2WriteBundleManifest struct {
+		options BundleOptions
+	}
+
+	step2CheckBundleManifest struct {
+		options BundleOptions
+	}
+
+	step2LoadBundleImages struct {
+		options     BundleOptions
+		execOptions module.ExecOptions
+	}
+)
+
+func getBundleOptions(curveadm *cli.CurveAdm) BundleOptions {
+	return curveadm.MemStorage().Get(comm.KEY_BUNDLE_OPTIONS).(BundleOptions)
+}
+
+func (s *step2WriteBundleManifest) Execute(ctx *context.Context) error {
+	manifest := BundleManifest{
+		Version: cli.Version,
+		Created: time.Now().Format(time.RFC3339),
+		Images:  s.options.Images,
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errno.ERR_ENCODE_BUNDLE_MANIFEST_FAILED.E(err)
+	}
+	err = utils.WriteFile(path.Join(s.options.Dir, BUNDLE_MANIFEST_FILE), string(data), 0644)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	return nil
+}
+
+// make sure all images required by current topology are in bundle
+func (s *step2CheckBundleManifest) Execute(ctx *context.Context) error {
+	data, err := utils.ReadFile(path.Join(s.options.Dir, BUNDLE_MANIFEST_FILE))
+	if err != nil {
+		return errno.ERR_READ_FILE_FAILED.E(err)
+	}
+	manifest := BundleManifest{}
+	err = json.Unmarshal([]byte(data), &manifest)
+	if err != nil {
+		return errno.ERR_DECODE_BUNDLE_MANIFEST_FAILED.E(err)
+	}
+
+	images := utils.Slice2Map(manifest.Images)
+	for _, image := range s.options.Images {
+		if !images[image] {
+			return errno.ERR_IMAGE_NOT_FOUND_IN_BUNDLE.
+				F("image: %s", image)
+		}
+	}
+	return nil
+}
+
+// load images only if any of them not exist in host
+func (s *step2LoadBundleImages) Execute(ctx *context.Context) error {
+	missing := []string{}
+	for _, image := range s.options.Images {
+		var success bool
+		inspect := &step.InspectImage{
+			Image:       image,
+			Format:      "'{{.Id}}'",
+			Success:     &success,
+			ExecOptions: s.execOptions,
+		}
+		if err := inspect.Execute(ctx); err != nil {
+			return err
+		} else if !success {
+			missing = append(missing, image)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// stream images archive into engine, so it won't be saved in remote host
+	f, err := os.Open(path.Join(s.options.Dir, BUNDLE_IMAGES_FILE))
+	if err != nil {
+		return errno.ERR_READ_FILE_FAILED.E(err)
+	}
+	defer f.Close()
+
+	execOptions := s.execOptions
+	execOptions.ExecTimeoutSec = 0 // loading large archive may take a long time
+	load := &step.LoadImage{
+		Reader:      f,
+		ExecOptions: execOptions,
+	}
+	return load.Execute(ctx)
+}
+
+func NewCreateBundleTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := getBundleOptions(curveadm)
+
+	// new task
+	subname := fmt.Sprintf("images=%d archive=%s", len(options.Images), options.Archive)
+	t := task.NewTask("Create Bundle", subname, nil)
+
+	// add step to task
+	localOptions := localExecOptions(curveadm)
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{options.Dir},
+		ExecOptions: localOptions,
+	})
+	for _, image := range options.Images {
+		t.AddStep(&step.PullImage{
+			Image:       image,
+			Mirror:      curveadm.Config().GetRegistryMirror(),
+			ExecOptions: localOptions,
+		})
+	}
+	t.AddStep(&step.SaveImages{
+		Images:      options.Images,
+		Output:      path.Join(options.Dir, BUNDLE_IMAGES_FILE),
+		ExecOptions: localOptions,
+	})
+	t.AddStep(&step.CopyFile{
+		Source:      options.Binary,
+		Dest:        path.Join(options.Dir, BUNDLE_BINARY_FILE),
+		ExecOptions: localOptions,
+	})
+	t.AddStep(&step2WriteBundleManifest{
+		options: options,
+	})
+	t.AddStep(&step.Tar{
+		File:        strings.Join([]string{BUNDLE_MANIFEST_FILE, BUNDLE_IMAGES_FILE, BUNDLE_BINARY_FILE}, " "),
+		Archive:     options.Archive,
+		Directory:   options.Dir,
+		Create:      true,
+		Gzip:        true,
+		ExecOptions: localOptions,
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{options.Dir},
+		ExecOptions: localOptions,
+	})
+
+	return t, nil
+}
+
+func NewUnpackBundleTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	options := getBundleOptions(curveadm)
+
+	// new task
+	subname := fmt.Sprintf("archive=%s", options.Archive)
+	t := task.NewTask("Unpack Bundle", subname, nil)
+
+	// add step to task
+	localOptions := localExecOptions(curveadm)
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{options.Dir},
+		ExecOptions: localOptions,
+	})
+	t.AddStep(&step.Tar{
+		Archive:     options.Archive,
+		Directory:   options.Dir,
+		Extract:     true,
+		UnGzip:      true,
+		ExecOptions: localOptions,
+	})
+	t.AddStep(&step2CheckBundleManifest{
+		options: options,
+	})
+
+	return t, nil
+}
+
+// NewLoadBundleImagesTask loads images into the host, the caller should
+// make sure each host appears only once
+func NewLoadBundleImagesTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	// new task
+	options := getBundleOptions(curveadm)
+	subname := fmt.Sprintf("host=%s images=%d", hc.GetHost(), len(options.Images))
+	t := task.NewTask("Load Images", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2LoadBundleImages{
+		options:     options,
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+// TopologyImages returns the unique images of services in order
+func TopologyImages(dcs []*topology.DeployConfig) []string {
+	images := []string{}
+	for _, dc := range dcs {
+		images = append(images, dc.GetContainerImage())
+	}
+	return UniqueImages(images)
+}
+
+func UniqueImages(images []string) []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, image := range images {
+		if len(image) == 0 || seen[image] {
+			continue
+		}
+		seen[image] = true
+		out = append(out, image)
+	}
+	return out
+}
@@ -64,13 +64,13 @@ type (
 	Step2CleanContainer struct {
 		ServiceId   string
 		ContainerId string
-		Storage     *storage.Storage
+		Storage     storage.Storage
 		ExecOptions module.ExecOptions
 	}
 
 	step2CleanDiskChunkServerId struct {
 		serviceId   string
-		storage     *storage.Storage
+		storage     storage.Storage
 		execOptions module.ExecOptions
 	}
 )
@@ -24,18 +24,91 @@ package common
 
 import (
 	"fmt"
+	"os"
 	"path"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
-	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
-	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/task"
 	"github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
 )
 
+type (
+	step2SaveCurveAdmData struct {
+		curveadm *cli.CurveAdm
+		saveDir  string
+	}
+)
+
+// only the log lines of current cluster's operations are collected,
+// because the log is shared by all clusters
+func (s *step2SaveCurveAdmData) clusterLogs() (string, error) {
+	curveadm := s.curveadm
+	auditLogs, err := curveadm.Storage().GetAuditLogs(storage.AuditFilter{
+		ClusterId: curveadm.ClusterId(),
+	})
+	if err != nil {
+		return "", errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
+	}
+	ids := map[int64]bool{}
+	for _, auditLog := range auditLogs {
+		ids[int64(auditLog.Id)] = true
+	}
+
+	files, err := log.ListLogFiles(curveadm.LogDir())
+	if err != nil {
+		return "", errno.ERR_READ_FILE_FAILED.E(err)
+	}
+	lines := []string{}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return "", errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		records, err := log.FilterRecordsFunc(f, func(record log.Record) bool {
+			return ids[record.CorrelationId()]
+		})
+		f.Close()
+		if err != nil {
+			return "", errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		for _, record := range records {
+			lines = append(lines, record.String())
+		}
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func (s *step2SaveCurveAdmData) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	logs, err := s.clusterLogs()
+	if err != nil {
+		return err
+	}
+	files := map[string]string{
+		"topology.yaml": curveadm.ClusterTopologyData(),
+		"hosts.yaml":    curveadm.Hosts(),
+		"pool.json":     curveadm.ClusterPoolData(),
+		"curveadm.log":  logs,
+	}
+
+	for name, data := range files {
+		err := utils.WriteFile(path.Join(s.saveDir, name), data, 0644)
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
 func NewCollectCurveAdmTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
-	// NOTE: we think it's not a good idae to collect curveadm's datbase file...
+	// NOTE: we don't collect curveadm's database file, because it contains
+	// secrets which can't be redacted, the topology and hosts are enough.
 	// new task
 	kind := dc.GetKind()
 	subname := fmt.Sprintf("cluster=%s kind=%s",
@@ -43,48 +116,10 @@ func NewCollectCurveAdmTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 	t := task.NewTask("Collect CurveAdm", subname, nil)
 
 	// add step to task
-	secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
-	urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-	baseDir := TEMP_DIR
-	vname := utils.NewVariantName(fmt.Sprintf("curveadm_%s", utils.RandString(5)))
-	localPath := path.Join(baseDir, vname.Name)                // /tmp/curveadm_is90x
-	localTarballPath := path.Join(baseDir, vname.CompressName) // /tmp/curveadm_is90x.tar.gz
-	localEncryptdTarballPath := path.Join(baseDir, vname.EncryptCompressName)
-	httpSavePath := path.Join("/", encodeSecret(secret), "data")
-	options := curveadm.ExecOptions()
-	options.ExecWithSudo = false
-	options.ExecInLocal = true
-
-	t.AddStep(&step.CreateDirectory{
-		Paths:       []string{localPath /*, hostLogDir, hostConfDir*/},
-		ExecOptions: options,
-	})
-	t.AddStep(&step.CopyFile{
-		Source:      curveadm.DBPath(),
-		Dest:        localPath,
-		ExecOptions: options,
-	})
-	t.AddStep(&step.Tar{
-		File:        localPath,
-		Archive:     localTarballPath,
-		Create:      true,
-		Gzip:        true,
-		Verbose:     true,
-		ExecOptions: options,
-	})
-	t.AddStep(&step2EncryptFile{
-		source: localTarballPath,
-		dest:   localEncryptdTarballPath,
-		secret: secret,
-	})
-	t.AddStep(&step.Curl{ // upload to curve team
-		Url:         fmt.Sprintf(urlFormat, httpSavePath),
-		Form:        fmt.Sprintf("path=@%s", localEncryptdTarballPath),
-		ExecOptions: options,
-	})
-	t.AddPostStep(&step.RemoveFile{
-		Files:       []string{localPath, localTarballPath, localEncryptdTarballPath},
-		ExecOptions: options,
+	options := getSupportOptions(curveadm)
+	t.AddStep(&step2SaveCurveAdmData{
+		curveadm: curveadm,
+		saveDir:  path.Join(options.BundleDir, "curveadm"),
 	})
 
 	return t, nil
@@ -24,6 +24,8 @@ package common
 
 import (
 	"fmt"
+	"path"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
@@ -32,7 +34,6 @@ import (
 	"github.com/opencurve/curveadm/internal/task/task"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
-	"path"
 )
 
 const (
@@ -46,11 +47,17 @@ type (
 		hostDestDir string
 		curveadm    *cli.CurveAdm
 	}
-)
 
-func encodeSecret(secret string) string {
-	return utils.MD5Sum(secret)
-}
+	// the container which we collect logs, configs and cores from
+	collectItem struct {
+		id          string // service id or client id
+		containerId string
+		logDir      string // in container
+		confDir     string // in container
+		coreDir     string // in host, empty means skip
+		localDir    string // the directory in bundle which save collected data
+	}
+)
 
 func (s *step2CopyFilesFromContainer) Execute(ctx *context.Context) error {
 	steps := []task.Step{}
@@ -72,103 +79,134 @@ func (s *step2CopyFilesFromContainer) Execute(ctx *context.Context) error {
 	return nil
 }
 
-func NewCollectServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
-	serviceId := curveadm.GetServiceId(dc.GetId())
-	containerId, err := curveadm.Storage().GetContainerId(serviceId)
-	if curveadm.IsSkip(dc) {
-		return nil, nil
-	} else if err != nil {
-		return nil, err
-	} else if len(containerId) == 0 {
-		return nil, nil
-	} else if containerId == comm.CLEANED_CONTAINER_ID {
-		return nil, nil
-	}
-	hc, err := curveadm.GetHost(dc.GetHost())
-	if err != nil {
-		return nil, err
-	}
-
-	// new task
-	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
-		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
-	t := task.NewTask("Collect Service", subname, hc.GetSSHConfig())
-
-	// add step to task
+/*
+ * remote: /tmp/<random>/<id>/{logs,conf,cores} -> /tmp/<random>/<id>.tar.gz
+ * local:  <bundle>/service/<role>/<id>.tar.gz -> <bundle>/service/<role>/<id>/
+ */
+func addCollectSteps(t *task.Task, curveadm *cli.CurveAdm, item collectItem) {
 	var out string
-	//var files []string
-	secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
-	urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-	baseDir := TEMP_DIR
-	vname := utils.NewVariantName(fmt.Sprintf("%s_%s", serviceId, utils.RandString(5)))
-	remoteSaveDir := fmt.Sprintf("%s/%s", baseDir, vname.Name)                // /tmp/7b510fb63730_ox1fe
-	remoteTarbllPath := path.Join(baseDir, vname.CompressName)                // /tmp/7b510fb63730_ox1fe.tar.gz
-	localTarballPath := path.Join(baseDir, vname.CompressName)                // /tmp/7b510fb63730_ox1fe.tar.gz
-	localEncryptdTarballPath := path.Join(baseDir, vname.EncryptCompressName) // // /tmp/7b510fb63730_ox1fe-encrypted.tar.gz
-	httpSavePath := path.Join("/", encodeSecret(secret), "service", dc.GetRole())
-	layout := dc.GetProjectLayout()
-	containerLogDir := layout.ServiceLogDir   // /curvebs/etcd/logs
-	containerConfDir := layout.ServiceConfDir // /curvebs/etcd/conf
-	localOptions := curveadm.ExecOptions()
-	localOptions.ExecInLocal = true
+	options := getSupportOptions(curveadm)
+	remoteBaseDir := path.Join(TEMP_DIR, fmt.Sprintf("curve-support-%s", utils.RandString(5)))
+	remoteSaveDir := path.Join(remoteBaseDir, item.id)
+	remoteTarballPath := path.Join(remoteBaseDir, item.id+".tar.gz")
+	localTarballPath := path.Join(item.localDir, item.id+".tar.gz")
+	localOptions := localExecOptions(curveadm)
 
 	t.AddStep(&step.CreateDirectory{
-		Paths:       []string{remoteSaveDir /*, hostLogDir, hostConfDir*/},
+		Paths:       []string{remoteSaveDir},
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step2CopyFilesFromContainer{ // copy logs directory
-		containerId: containerId,
-		files:       &[]string{containerLogDir},
-		hostDestDir: remoteSaveDir,
-		curveadm:    curveadm,
-	})
-	t.AddStep(&step2CopyFilesFromContainer{ // copy conf directory
-		containerId: containerId,
-		files:       &[]string{containerConfDir},
+		containerId: item.containerId,
+		files:       &[]string{item.logDir},
 		hostDestDir: remoteSaveDir,
 		curveadm:    curveadm,
 	})
+	if options.Since > 0 { // only keep logs in time window
+		t.AddStep(&step.Command{
+			Command: fmt.Sprintf("find %s -type f ! %s -delete",
+				path.Join(remoteSaveDir, path.Base(item.logDir)), options.findNewer()),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	if options.IncludeConfigs {
+		t.AddStep(&step2CopyFilesFromContainer{ // copy conf directory
+			containerId: item.containerId,
+			files:       &[]string{item.confDir},
+			hostDestDir: remoteSaveDir,
+			curveadm:    curveadm,
+		})
+	}
+	if options.IncludeCores && len(item.coreDir) > 0 {
+		coreDir := path.Join(remoteSaveDir, "cores")
+		t.AddStep(&step.CreateDirectory{
+			Paths:       []string{coreDir},
+			ExecOptions: curveadm.ExecOptions(),
+		})
+		t.AddStep(&step.Command{
+			Command: fmt.Sprintf("find %s -maxdepth 1 -type f %s -exec cp {} %s \\;",
+				item.coreDir, options.findNewer(), coreDir),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step.ContainerLogs{
-		ContainerId: containerId,
+		ContainerId: item.containerId,
 		Out:         &out,
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.InstallFile{
 		Content:      &out,
-		HostDestPath: fmt.Sprintf("%s/docker.log", path.Join(remoteSaveDir, "logs")),
+		HostDestPath: path.Join(remoteSaveDir, path.Base(item.logDir), "docker.log"),
 		ExecOptions:  curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.Tar{
-		File:        remoteSaveDir,
-		Archive:     remoteTarbllPath,
+		File:        item.id,
+		Archive:     remoteTarballPath,
+		Directory:   remoteBaseDir,
 		Create:      true,
 		Gzip:        true,
-		Verbose:     true,
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.DownloadFile{
-		RemotePath:  remoteTarbllPath,
+		RemotePath:  remoteTarballPath,
 		LocalPath:   localTarballPath,
 		ExecOptions: curveadm.ExecOptions(),
 	})
-	t.AddStep(&step2EncryptFile{
-		source: localTarballPath,
-		dest:   localEncryptdTarballPath,
-		secret: secret,
-	})
-	t.AddStep(&step.Curl{ // upload to curve team // curl -F "path=@$FILE" http://localhost:8080/upload\?path\=/
-		Url:         fmt.Sprintf(urlFormat, httpSavePath),
-		Form:        fmt.Sprintf("path=@%s", localEncryptdTarballPath),
+	t.AddStep(&step.Tar{
+		Archive:     localTarballPath,
+		Directory:   item.localDir,
+		Extract:     true,
+		UnGzip:      true,
 		ExecOptions: localOptions,
 	})
 	t.AddPostStep(&step.RemoveFile{
-		Files:       []string{remoteSaveDir, remoteTarbllPath},
+		Files:       []string{remoteBaseDir},
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddPostStep(&step.RemoveFile{
-		Files:       []string{localTarballPath, localEncryptdTarballPath},
+		Files:       []string{localTarballPath},
 		ExecOptions: localOptions,
 	})
+}
+
+func NewCollectServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.Storage().GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(containerId) == 0 {
+		return nil, nil
+	} else if containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Collect Service", subname, hc.GetSSHConfig())
+
+	// add step to task
+	options := getSupportOptions(curveadm)
+	layout := dc.GetProjectLayout()
+	item := collectItem{
+		id:          dc.GetId(),
+		containerId: containerId,
+		logDir:      layout.ServiceLogDir,  // /curvebs/etcd/logs
+		confDir:     layout.ServiceConfDir, // /curvebs/etcd/conf
+		localDir:    path.Join(options.BundleDir, "service", dc.GetRole()),
+	}
+	// the core directory is shared by services in the same host,
+	// so we only collect cores once for each host
+	if options.CoreCollectors[dc.GetId()] {
+		item.coreDir = dc.GetCoreDir()
+	}
+	addCollectSteps(t, curveadm, item)
 
 	return t, nil
 }
@@ -0,0 +1,414 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	CORE_COMM_LEN      = 15 // the executable name (%e) in core_pattern is truncated to 15 characters
+	CORE_CONTAINER_DIR = "/core"
+	CORE_WORK_DIR      = "/curveadm"
+	CORE_GDB_COMMAND   = "-batch -ex 'info sharedlibrary' -ex 'thread apply all bt'"
+)
+
+var (
+	// file /core/core.curvebs-mds.1234
+	// ... core file, ..., from '/curvebs/mds/sbin/curvebs-mds -confPath=...', ..., execfn: '/curvebs/mds/sbin/curvebs-mds', ...
+	regexCoreExecfn = regexp.MustCompile(`execfn: '([^']+)'`)
+	regexCoreFrom   = regexp.MustCompile(`from '([^' ]+)`)
+)
+
+type (
+	CoresOptions struct {
+		Since  time.Duration
+		Core   CoreFile // core file which to analyze or fetch
+		Output string   // local directory which fetched core saved in
+	}
+
+	CoreFile struct {
+		Id        string
+		Host      string
+		Path      string // in host
+		Size      uint64
+		Time      time.Time
+		Binary    string // executable path in container, empty means unknown
+		Role      string
+		ServiceId string
+		Config    *topology.DeployConfig // service which core file belongs to
+	}
+
+	step2ListCores struct {
+		dc          *topology.DeployConfig
+		dcs         []*topology.DeployConfig // services which share the core directory
+		options     CoresOptions
+		curveadm    *cli.CurveAdm
+		execOptions module.ExecOptions
+	}
+)
+
+func getCoresOptions(curveadm *cli.CurveAdm) CoresOptions {
+	return curveadm.MemStorage().Get(comm.KEY_CORES_OPTIONS).(CoresOptions)
+}
+
+func addCoreFile(curveadm *cli.CurveAdm, core CoreFile) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]CoreFile{}
+		v := kv.Get(comm.KEY_ALL_CORE_FILES)
+		if v != nil {
+			m = v.(map[string]CoreFile)
+		}
+		m[core.Id] = core
+		kv.Set(comm.KEY_ALL_CORE_FILES, m)
+		return nil
+	})
+}
+
+// CoreCollectors returns one service for each core directory, the core
+// directory is shared by services in the same host usually
+func CoreCollectors(dcs []*topology.DeployConfig) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	seen := map[string]bool{}
+	for _, dc := range dcs {
+		key := fmt.Sprintf("%s:%s", dc.GetHost(), dc.GetCoreDir())
+		if len(dc.GetCoreDir()) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, dc)
+	}
+	return out
+}
+
+// the binary name which service running, see entrypoint.sh in curve image
+func serviceBinaryName(dc *topology.DeployConfig) string {
+	if dc.GetRole() == topology.ROLE_ETCD {
+		return "etcd"
+	}
+	return fmt.Sprintf("%s-%s", dc.GetKind(), dc.GetRole())
+}
+
+// ServiceBinary returns the executable path of service in container
+func ServiceBinary(dc *topology.DeployConfig) string {
+	return path.Join(dc.GetProjectLayout().ServiceBinDir, serviceBinaryName(dc))
+}
+
+/*
+ * ParseCoreFiles parses output of `find DIR -printf '%T@ %s %p\n'`:
+ *
+ *   1697875084.1234567890 1048576 /data/core/core.curvebs-mds.1234
+ */
+func ParseCoreFiles(host, output string) []CoreFile {
+	cores := []CoreFile{}
+	for _, line := range strings.Split(output, "\n") {
+		items := strings.SplitN(strings.TrimSpace(line), " ", 3)
+		if len(items) != 3 {
+			continue
+		}
+		mtime, err := strconv.ParseFloat(items[0], 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseUint(items[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		sec, frac := math.Modf(mtime)
+		cores = append(cores, CoreFile{
+			Id:   utils.MD5Sum(fmt.Sprintf("%s:%s", host, items[2]))[:12],
+			Host: host,
+			Path: items[2],
+			Size: size,
+			Time: time.Unix(int64(sec), int64(frac*1e9)),
+		})
+	}
+	return cores
+}
+
+// ParseCoreBinaries parses output of `file CORE...` and returns the executable of each core
+func ParseCoreBinaries(output string) map[string]string {
+	binaries := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		items := strings.SplitN(line, ": ", 2)
+		if len(items) != 2 {
+			continue
+		}
+		if mu := regexCoreExecfn.FindStringSubmatch(items[1]); len(mu) > 0 {
+			binaries[items[0]] = mu[1]
+		} else if mu := regexCoreFrom.FindStringSubmatch(items[1]); len(mu) > 0 {
+			binaries[items[0]] = mu[1]
+		}
+	}
+	return binaries
+}
+
+// match the service by executable of core, or by core filename if executable unknown,
+// the core_pattern usually contains executable name, e.g. core-%e-%p-%t
+func matchCoreServices(core CoreFile, dcs []*topology.DeployConfig) []*topology.DeployConfig {
+	matched := []*topology.DeployConfig{}
+	filename := path.Base(core.Path)
+	for _, dc := range dcs {
+		name := serviceBinaryName(dc)
+		short := name
+		if len(short) > CORE_COMM_LEN {
+			short = short[:CORE_COMM_LEN]
+		}
+		if len(core.Binary) > 0 {
+			if path.Base(core.Binary) == name {
+				matched = append(matched, dc)
+			}
+		} else if strings.Contains(filename, short) {
+			matched = append(matched, dc)
+		}
+	}
+	return matched
+}
+
+func (s *step2ListCores) Execute(ctx *context.Context) error {
+	command := fmt.Sprintf("find %s -maxdepth 1 -type f", s.dc.GetCoreDir())
+	if s.options.Since > 0 {
+		command = fmt.Sprintf("%s -mmin -%d", command, int(math.Ceil(s.options.Since.Minutes())))
+	}
+	command = fmt.Sprintf("%s -printf '%%T@ %%s %%p\\n'", command)
+	out, err := ctx.Module().Shell().Command(command).Execute(s.execOptions)
+	if err != nil {
+		return errno.ERR_RUN_A_BASH_COMMAND_FAILED.E(err)
+	}
+
+	host := s.dc.GetHost()
+	cores := ParseCoreFiles(host, out)
+	if len(cores) == 0 {
+		return nil
+	}
+
+	// ignore error if file(1) not installed, then we match service by core filename
+	paths := []string{}
+	for _, core := range cores {
+		paths = append(paths, core.Path)
+	}
+	out, _ = ctx.Module().Shell().Command("file " + strings.Join(paths, " ")).Execute(s.execOptions)
+	binaries := ParseCoreBinaries(out)
+
+	for _, core := range cores {
+		core.Binary = binaries[core.Path]
+		core.Role = "-"
+		core.ServiceId = "-"
+		matched := matchCoreServices(core, s.dcs)
+		if len(matched) > 0 {
+			core.Config = matched[0]
+			core.Role = matched[0].GetRole()
+		}
+		if len(matched) == 1 {
+			core.ServiceId = s.curveadm.GetServiceId(matched[0].GetId())
+		}
+		addCoreFile(s.curveadm, core)
+	}
+	return nil
+}
+
+func NewListCoresTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s coreDir=%s", dc.GetHost(), dc.GetCoreDir())
+	t := task.NewTask("List Cores", subname, hc.GetSSHConfig())
+
+	// add step to task
+	dcs := []*topology.DeployConfig{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS)
+	for _, sdc := range v.([]*topology.DeployConfig) {
+		if sdc.GetHost() == dc.GetHost() && sdc.GetCoreDir() == dc.GetCoreDir() {
+			dcs = append(dcs, sdc)
+		}
+	}
+	t.AddStep(&step2ListCores{
+		dc:          dc,
+		dcs:         dcs,
+		options:     getCoresOptions(curveadm),
+		curveadm:    curveadm,
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+// CoreBinary returns the executable path in container which generated the core
+func CoreBinary(core CoreFile) (string, error) {
+	if path.IsAbs(core.Binary) {
+		return core.Binary, nil
+	} else if core.Config == nil {
+		return "", errno.ERR_UNKNOWN_SERVICE_OF_CORE_FILE.
+			F("host=%s core=%s", core.Host, core.Path)
+	}
+	return ServiceBinary(core.Config), nil
+}
+
+// run gdb in service image to produce backtrace of all threads
+func NewAnalyzeCoreTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	core := getCoresOptions(curveadm).Core
+	binary, err := CoreBinary(core)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s core=%s image=%s",
+		core.Host, path.Base(core.Path), dc.GetContainerImage())
+	t := task.NewTask("Analyze Core", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.RunContainer{
+		Image:      dc.GetContainerImage(),
+		Command:    fmt.Sprintf("%s %s %s", CORE_GDB_COMMAND, binary, path.Join(CORE_CONTAINER_DIR, path.Base(core.Path))),
+		Entrypoint: "gdb",
+		Volumes: []step.Volume{
+			{HostPath: path.Dir(core.Path), ContainerPath: CORE_CONTAINER_DIR},
+		},
+		Remove:      true,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			curveadm.MemStorage().Set(comm.KEY_CORE_BACKTRACE, out)
+			return nil
+		},
+	})
+
+	return t, nil
+}
+
+/*
+ * remote: /tmp/<random>/core-<id>/{core,binary,sysroot} -> /tmp/<random>/core-<id>.tar.gz
+ * local:  <output>/core-<id>.tar.gz -> <output>/core-<id>/
+ */
+func NewFetchCoreTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	options := getCoresOptions(curveadm)
+	core := options.Core
+	binary, err := CoreBinary(core)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	name := fmt.Sprintf("core-%s", core.Id)
+	subname := fmt.Sprintf("host=%s core=%s output=%s",
+		core.Host, path.Base(core.Path), path.Join(options.Output, name))
+	t := task.NewTask("Fetch Core", subname, hc.GetSSHConfig())
+
+	// add step to task
+	script := scripts.SCRIPT_COLLECT_CORE
+	remoteBaseDir := path.Join(TEMP_DIR, fmt.Sprintf("curve-core-%s", utils.RandString(5)))
+	remoteSaveDir := path.Join(remoteBaseDir, name)
+	remoteScriptPath := path.Join(remoteBaseDir, "collect_core.sh")
+	remoteTarballPath := path.Join(remoteBaseDir, name+".tar.gz")
+	localTarballPath := path.Join(options.Output, name+".tar.gz")
+	localOptions := localExecOptions(curveadm)
+
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{remoteSaveDir},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallFile{
+		Content:      &script,
+		HostDestPath: remoteScriptPath,
+		ExecOptions:  curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.RunContainer{ // copy binary, shared libraries and debug symbols from image
+		Image: dc.GetContainerImage(),
+		Command: fmt.Sprintf("%s %s %s",
+			path.Join(CORE_WORK_DIR, path.Base(remoteScriptPath)), binary, path.Join(CORE_WORK_DIR, name)),
+		Entrypoint: "/bin/bash",
+		Volumes: []step.Volume{
+			{HostPath: remoteBaseDir, ContainerPath: CORE_WORK_DIR},
+		},
+		Remove:      true,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.CopyFile{
+		Source:      core.Path,
+		Dest:        remoteSaveDir,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Tar{
+		File:        name,
+		Archive:     remoteTarballPath,
+		Directory:   remoteBaseDir,
+		Create:      true,
+		Gzip:        true,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.DownloadFile{
+		RemotePath:  remoteTarballPath,
+		LocalPath:   localTarballPath,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Tar{
+		Archive:     localTarballPath,
+		Directory:   options.Output,
+		Extract:     true,
+		UnGzip:      true,
+		ExecOptions: localOptions,
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{remoteBaseDir},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{localTarballPath},
+		ExecOptions: localOptions,
+	})
+
+	return t, nil
+}
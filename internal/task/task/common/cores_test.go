@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoreFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	output := "1697875084.5000000000 1048576 /data/core/core.curvebs-mds.1234\n" +
+		"bad line\n" +
+		"1697875090.0000000000 2048 /data/core/core file with space\n"
+	cores := ParseCoreFiles("host1", output)
+	assert.Len(cores, 2)
+
+	assert.Equal("host1", cores[0].Host)
+	assert.Equal("/data/core/core.curvebs-mds.1234", cores[0].Path)
+	assert.Equal(uint64(1048576), cores[0].Size)
+	assert.Equal(int64(1697875084), cores[0].Time.Unix())
+	assert.Len(cores[0].Id, 12)
+	assert.Equal("/data/core/core file with space", cores[1].Path)
+
+	// id is stable for the same core on the same host
+	assert.Equal(cores[0].Id, ParseCoreFiles("host1", output)[0].Id)
+	assert.NotEqual(cores[0].Id, ParseCoreFiles("host2", output)[0].Id)
+
+	assert.Len(ParseCoreFiles("host1", ""), 0)
+}
+
+func TestParseCoreBinaries(t *testing.T) {
+	assert := assert.New(t)
+
+	output := "/data/core/core.1: ELF 64-bit LSB core file, x86-64, version 1 (SYSV), SVR4-style, " +
+		"from '/curvebs/mds/sbin/curvebs-mds -confPath=/curvebs/mds/conf/mds.conf', real uid: 0, " +
+		"execfn: '/curvebs/mds/sbin/curvebs-mds', platform: 'x86_64'\n" +
+		"/data/core/core.2: ELF 64-bit LSB core file, x86-64, version 1 (SYSV), SVR4-style, " +
+		"from '/usr/bin/etcd --config-file /curvebs/etcd/conf/etcd.conf'\n" +
+		"/data/core/core.3: data\n"
+	binaries := ParseCoreBinaries(output)
+	assert.Len(binaries, 2)
+	assert.Equal("/curvebs/mds/sbin/curvebs-mds", binaries["/data/core/core.1"])
+	assert.Equal("/usr/bin/etcd", binaries["/data/core/core.2"])
+}
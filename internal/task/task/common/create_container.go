@@ -48,7 +48,7 @@ const (
 type Step2GetService struct {
 	ServiceId   string
 	ContainerId *string
-	Storage     *storage.Storage
+	Storage     storage.Storage
 }
 
 type Step2InsertService struct {
@@ -56,7 +56,7 @@ type Step2InsertService struct {
 	ServiceId      string
 	ContainerId    *string
 	OldContainerId *string
-	Storage        *storage.Storage
+	Storage        storage.Storage
 }
 
 func (s *Step2GetService) Execute(ctx *context.Context) error {
@@ -238,6 +238,7 @@ func NewCreateContainerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 	device := ""
 	extraParam := ""
 	serviceMountDevice := false
+	volumes := []step.Volume{}
 	// update disk service(chunkserver) ID and get disk UUID for service device direct mounting
 	if role == topology.ROLE_CHUNKSERVER && len(curveadm.DiskRecords()) > 0 {
 		if err := curveadm.Storage().UpdateDiskChunkServerID(host, dataDir, serviceId); err != nil {
@@ -255,8 +256,17 @@ func NewCreateContainerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 			if err != nil {
 				return t, err
 			}
-			if diskUriProto == disks.DISK_URI_PROTO_FS_UUID {
+			switch diskUriProto {
+			case disks.DISK_URI_PROTO_FS_UUID:
 				extraParam = fmt.Sprintf("--disk UUID=%s", diskId)
+			case disks.DISK_URI_PROTO_BY_ID:
+				// the symlinks in /dev/disk/by-id are maintained by udev of host,
+				// so we bind it into container to resolve the device after reboot
+				extraParam = fmt.Sprintf("--disk %s/%s", disks.DEV_DISK_BY_ID_DIR, diskId)
+				volumes = append(volumes, step.Volume{
+					HostPath:      disks.DEV_DISK_BY_ID_DIR,
+					ContainerPath: disks.DEV_DISK_BY_ID_DIR,
+				})
 			}
 		}
 	}
@@ -281,7 +291,7 @@ func NewCreateContainerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 		Privileged:  true,
 		Restart:     getRestartPolicy(dc, serviceMountDevice),
 		Ulimits:     []string{"core=-1"},
-		Volumes:     getMountVolumes(dc, serviceMountDevice),
+		Volumes:     append(getMountVolumes(dc, serviceMountDevice), volumes...),
 		Out:         &containerId,
 		ExecOptions: curveadm.ExecOptions(),
 	})
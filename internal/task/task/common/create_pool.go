@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/build"
@@ -42,10 +43,26 @@ import (
 	"github.com/opencurve/curveadm/internal/utils"
 )
 
+var (
+	// creating pool fails transiently while mds is busy (e.g. leader just elected)
+	POLICY_CREATE_POOL = task.Policy{
+		Retries:    2,
+		Backoff:    5 * time.Second,
+		MaxBackoff: 20 * time.Second,
+	}
+
+	// every attempt waits about 30 seconds for all chunkservers online
+	POLICY_WAIT_CHUNKSERVERS = task.Policy{
+		Retries: 3,
+		Backoff: 10 * time.Second,
+		Timeout: 2 * time.Minute,
+	}
+)
+
 type step2SetClusterPool struct {
 	curveadm    *cli.CurveAdm
 	clusterPool string
-	storage     *storage.Storage
+	storage     storage.Storage
 }
 
 func getClusterPool(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (configure.CurveClusterTopo, error) {
@@ -254,27 +271,29 @@ func NewCreateTopologyTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*
 			Content:           &waitChunkserversScript,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
-		t.AddStep(&step.ContainerExec{ // wait all chunkservers online before create logical pool
+		t.AddStepWithPolicy(POLICY_WAIT_CHUNKSERVERS,
+			&step.ContainerExec{ // wait all chunkservers online before create logical pool
+				ContainerId: &containerId,
+				Command:     fmt.Sprintf("bash %s", waitChunkserversScriptPath),
+				Success:     &success,
+				Out:         &out,
+				ExecOptions: curveadm.ExecOptions(),
+			},
+			&step.Lambda{
+				Lambda: checkChunkserverOnline(&success, &out),
+			})
+	}
+	t.AddStepWithPolicy(POLICY_CREATE_POOL,
+		&step.ContainerExec{ // create topology
 			ContainerId: &containerId,
-			Command:     fmt.Sprintf("bash %s", waitChunkserversScriptPath),
 			Success:     &success,
 			Out:         &out,
+			Command:     genCreatePoolCommand(dc, pooltype, poolJSONPath),
 			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.Lambda{
+			Lambda: checkCreatePoolStatus(&success, &out),
 		})
-		t.AddStep(&step.Lambda{
-			Lambda: checkChunkserverOnline(&success, &out),
-		})
-	}
-	t.AddStep(&step.ContainerExec{ // create topology
-		ContainerId: &containerId,
-		Success:     &success,
-		Out:         &out,
-		Command:     genCreatePoolCommand(dc, pooltype, poolJSONPath),
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.Lambda{
-		Lambda: checkCreatePoolStatus(&success, &out),
-	})
 	if pooltype == comm.POOL_TYPE_LOGICAL {
 		t.AddStep(&step2SetClusterPool{
 			curveadm:    curveadm,
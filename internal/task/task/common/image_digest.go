@@ -0,0 +1,384 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	FORMAT_REPO_DIGESTS = "'{{json .RepoDigests}}'"
+)
+
+type (
+	// digest of image which pulled in host
+	PulledDigest struct {
+		Host   string
+		Digest string
+		Pinned string
+	}
+
+	ServiceImage struct {
+		Id            string
+		Host          string
+		Role          string
+		Image         string // image in topology
+		Pinned        string // digest pinned at commit time
+		RunningImage  string
+		RunningDigest string
+		Status        string
+	}
+
+	step2InspectRunningImage struct {
+		output      *string // {{.Image}} {{.Config.Image}}
+		running     *string
+		repoDigests *string
+		execOptions module.ExecOptions
+	}
+)
+
+/*
+ * ParseRepoDigest returns the digest of image from output of
+ * `docker image inspect --format '{{json .RepoDigests}}'`, e.g.
+ *
+ *   ["opencurvedocker/curvebs@sha256:4b6c..."] => sha256:4b6c...
+ *
+ * the digest of specified repository is preferred if image pulled from many repositories.
+ */
+func ParseRepoDigest(output, repository string) (string, error) {
+	repoDigests := []string{}
+	output = strings.TrimSpace(output)
+	if len(output) == 0 || output == "null" {
+		return "", nil
+	} else if err := json.Unmarshal([]byte(output), &repoDigests); err != nil {
+		return "", errno.ERR_DECODE_IMAGE_REPO_DIGESTS_FAILED.E(err)
+	}
+
+	digest := ""
+	for _, repoDigest := range repoDigests {
+		items := strings.SplitN(repoDigest, "@", 2)
+		if len(items) != 2 {
+			continue
+		} else if items[0] == repository {
+			return items[1], nil
+		} else if len(digest) == 0 {
+			digest = items[1]
+		}
+	}
+	return digest, nil
+}
+
+// PinnedImageDigests returns the digest of each image which pinned at commit time
+func PinnedImageDigests(curveadm *cli.CurveAdm) (map[string]string, error) {
+	digests, err := curveadm.Storage().GetImageDigests(curveadm.ClusterId())
+	if err != nil {
+		return nil, errno.ERR_GET_IMAGE_DIGESTS_FAILED.E(err)
+	}
+
+	m := map[string]string{}
+	for _, digest := range digests {
+		m[digest.Image] = digest.Digest
+	}
+	return m, nil
+}
+
+func repositoryInRegistry(curveadm *cli.CurveAdm, image string) string {
+	mirror := curveadm.Config().GetRegistryMirror()
+	return utils.ImageRepository(utils.MirrorImage(image, mirror))
+}
+
+func setResolvedDigest(curveadm *cli.CurveAdm, image, digest string) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]string{}
+		v := kv.Get(comm.KEY_RESOLVED_IMAGE_DIGESTS)
+		if v != nil {
+			m = v.(map[string]string)
+		}
+		m[image] = digest
+		kv.Set(comm.KEY_RESOLVED_IMAGE_DIGESTS, m)
+		return nil
+	})
+}
+
+func addPulledDigest(curveadm *cli.CurveAdm, image string, pulled PulledDigest) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string][]PulledDigest{}
+		v := kv.Get(comm.KEY_PULLED_IMAGE_DIGESTS)
+		if v != nil {
+			m = v.(map[string][]PulledDigest)
+		}
+		m[image] = append(m[image], pulled)
+		kv.Set(comm.KEY_PULLED_IMAGE_DIGESTS, m)
+		return nil
+	})
+}
+
+func setServiceImage(curveadm *cli.CurveAdm, id string, image ServiceImage) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]ServiceImage{}
+		v := kv.Get(comm.KEY_ALL_SERVICE_IMAGES)
+		if v != nil {
+			m = v.(map[string]ServiceImage)
+		}
+		m[id] = image
+		kv.Set(comm.KEY_ALL_SERVICE_IMAGES, m)
+		return nil
+	})
+}
+
+func step2RecordResolvedDigest(curveadm *cli.CurveAdm, image string, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		digest, err := ParseRepoDigest(*out, repositoryInRegistry(curveadm, image))
+		if err != nil {
+			return err
+		} else if len(digest) == 0 {
+			return errno.ERR_IMAGE_DIGEST_NOT_FOUND.F("image: %s", image)
+		}
+		setResolvedDigest(curveadm, image, digest)
+		return nil
+	}
+}
+
+func step2RecordPulledDigest(curveadm *cli.CurveAdm, dc *topology.DeployConfig,
+	pinned string, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		image := dc.GetContainerImage()
+		digest, err := ParseRepoDigest(*out, repositoryInRegistry(curveadm, image))
+		if err != nil {
+			return err
+		}
+		addPulledDigest(curveadm, image, PulledDigest{
+			Host:   dc.GetHost(),
+			Digest: digest,
+			Pinned: pinned,
+		})
+		return nil
+	}
+}
+
+func checkPulledDigests(curveadm *cli.CurveAdm) step.LambdaType {
+	return func(ctx *context.Context) error {
+		m := map[string][]PulledDigest{}
+		v := curveadm.MemStorage().Get(comm.KEY_PULLED_IMAGE_DIGESTS)
+		if v != nil {
+			m = v.(map[string][]PulledDigest)
+		}
+
+		images := []string{}
+		for image := range m {
+			images = append(images, image)
+		}
+		sort.Strings(images)
+		for _, image := range images {
+			if err := checkImageDigests(image, m[image]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// all hosts should have the same digest which equal to the pinned one (if any)
+func checkImageDigests(image string, pulled []PulledDigest) error {
+	items := []string{}
+	digests := map[string]bool{}
+	for _, p := range pulled {
+		if len(p.Digest) == 0 { // e.g. image loaded from bundle
+			continue
+		} else if len(p.Pinned) > 0 && p.Digest != p.Pinned {
+			return errno.ERR_IMAGE_DIGEST_MISMATCH.
+				F("image=%s host=%s digest=%s pinned=%s", image, p.Host, p.Digest, p.Pinned)
+		}
+		digests[p.Digest] = true
+		items = append(items, fmt.Sprintf("%s(%s)", p.Host, p.Digest))
+	}
+
+	if len(digests) > 1 {
+		return errno.ERR_IMAGE_DIGEST_MISMATCH.
+			F("image=%s %s", image, strings.Join(items, " "))
+	}
+	return nil
+}
+
+func (s *step2InspectRunningImage) Execute(ctx *context.Context) error {
+	items := strings.Fields(*s.output)
+	if len(items) != 2 {
+		return errno.ERR_INSPECT_CONTAINER_FAILED.
+			F("container image: %s", *s.output)
+	}
+
+	*s.running = items[1]
+	inspect := &step.InspectImage{
+		Image:       items[0], // image id
+		Format:      FORMAT_REPO_DIGESTS,
+		Out:         s.repoDigests,
+		ExecOptions: s.execOptions,
+	}
+	return inspect.Execute(ctx)
+}
+
+func step2InitServiceImage(curveadm *cli.CurveAdm, dc *topology.DeployConfig,
+	serviceId, pinned string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		setServiceImage(curveadm, serviceId, ServiceImage{
+			Id:            serviceId,
+			Host:          dc.GetHost(),
+			Role:          dc.GetRole(),
+			Image:         dc.GetContainerImage(),
+			Pinned:        pinned,
+			RunningImage:  "-",
+			RunningDigest: "-",
+			Status:        comm.SERVICE_STATUS_UNKNOWN,
+		})
+		return nil
+	}
+}
+
+func step2FormatServiceImage(curveadm *cli.CurveAdm, dc *topology.DeployConfig,
+	serviceId, pinned string, running, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		image := dc.GetContainerImage()
+		digest, err := ParseRepoDigest(*out, repositoryInRegistry(curveadm, image))
+		if err != nil {
+			return err
+		}
+
+		status := comm.IMAGE_STATUS_MATCHED
+		if *running != image || (len(pinned) > 0 && digest != pinned) {
+			status = comm.IMAGE_STATUS_MISMATCHED
+		} else if len(pinned) == 0 {
+			status = comm.IMAGE_STATUS_UNPINNED
+		}
+		setServiceImage(curveadm, serviceId, ServiceImage{
+			Id:            serviceId,
+			Host:          dc.GetHost(),
+			Role:          dc.GetRole(),
+			Image:         image,
+			Pinned:        pinned,
+			RunningImage:  *running,
+			RunningDigest: digest,
+			Status:        status,
+		})
+		return nil
+	}
+}
+
+func NewResolveImageDigestTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	image := dc.GetContainerImage()
+	subname := fmt.Sprintf("host=%s image=%s", dc.GetHost(), image)
+	t := task.NewTask("Resolve Image Digest", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.PullImage{
+		Image:       image,
+		Mirror:      curveadm.Config().GetRegistryMirror(),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InspectImage{
+		Image:       image,
+		Format:      FORMAT_REPO_DIGESTS,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: step2RecordResolvedDigest(curveadm, image, &out),
+	})
+
+	return t, nil
+}
+
+func NewVerifyImageDigestTask(curveadm *cli.CurveAdm, c interface{}) (*task.Task, error) {
+	t := task.NewTask("Verify Image Digest", "", nil)
+	t.AddStep(&step.Lambda{
+		Lambda: checkPulledDigests(curveadm),
+	})
+	return t, nil
+}
+
+func NewGetServiceImageTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	pinned, err := PinnedImageDigests(curveadm)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Service Image", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var output, running, repoDigests string
+	t.AddStep(&step.Lambda{
+		Lambda: step2InitServiceImage(curveadm, dc, serviceId, pinned[dc.GetContainerImage()]),
+	})
+	t.AddStep(&step.InspectContainer{
+		ContainerId: containerId,
+		Format:      "'{{.Image}} {{.Config.Image}}'",
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2InspectRunningImage{
+		output:      &output,
+		running:     &running,
+		repoDigests: &repoDigests,
+		execOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: step2FormatServiceImage(curveadm, dc, serviceId,
+			pinned[dc.GetContainerImage()], &running, &repoDigests),
+	})
+
+	return t, nil
+}
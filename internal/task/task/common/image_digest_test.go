@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRepoDigest(t *testing.T) {
+	assert := assert.New(t)
+	repo := "opencurvedocker/curvebs"
+
+	digest, err := ParseRepoDigest(`["opencurvedocker/curvebs@sha256:aaa"]`+"\n", repo)
+	assert.Nil(err)
+	assert.Equal("sha256:aaa", digest)
+
+	// prefer the digest of specified repository
+	digest, err = ParseRepoDigest(`["harbor.local/curvebs@sha256:bbb","opencurvedocker/curvebs@sha256:aaa"]`, repo)
+	assert.Nil(err)
+	assert.Equal("sha256:aaa", digest)
+	digest, err = ParseRepoDigest(`["harbor.local/curvebs@sha256:bbb"]`, repo)
+	assert.Nil(err)
+	assert.Equal("sha256:bbb", digest)
+
+	// image which built locally has no repo digest
+	for _, output := range []string{"", "[]", "null"} {
+		digest, err = ParseRepoDigest(output, repo)
+		assert.Nil(err)
+		assert.Equal("", digest)
+	}
+
+	_, err = ParseRepoDigest("not json", repo)
+	assert.Equal(errno.ERR_DECODE_IMAGE_REPO_DIGESTS_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestCheckImageDigests(t *testing.T) {
+	assert := assert.New(t)
+	image := "opencurvedocker/curvebs:v1.2"
+	code := errno.ERR_IMAGE_DIGEST_MISMATCH.GetCode()
+
+	// same digest
+	assert.Nil(checkImageDigests(image, []PulledDigest{
+		{Host: "host1", Digest: "sha256:aaa"},
+		{Host: "host2", Digest: "sha256:aaa"},
+		{Host: "host3", Digest: ""},
+	}))
+	assert.Nil(checkImageDigests(image, []PulledDigest{
+		{Host: "host1", Digest: "sha256:aaa", Pinned: "sha256:aaa"},
+	}))
+
+	// different digests between hosts
+	err := checkImageDigests(image, []PulledDigest{
+		{Host: "host1", Digest: "sha256:aaa"},
+		{Host: "host2", Digest: "sha256:bbb"},
+	})
+	assert.Equal(code, err.(*errno.ErrorCode).GetCode())
+
+	// different from pinned digest
+	err = checkImageDigests(image, []PulledDigest{
+		{Host: "host1", Digest: "sha256:bbb", Pinned: "sha256:aaa"},
+	})
+	assert.Equal(code, err.(*errno.ErrorCode).GetCode())
+}
@@ -24,14 +24,59 @@ package common
 
 import (
 	"fmt"
+	"math"
+	"path"
+	"time"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
-	"path"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
+type (
+	SupportS3Options struct {
+		Endpoint  string // http(s)://host:port
+		Bucket    string
+		Region    string
+		AccessKey string
+		SecretKey string
+	}
+
+	SupportOptions struct {
+		BundleDir      string        // /tmp/curve-support-my-cluster-20231018194017
+		Archive        string        // ./curve-support-my-cluster-20231018194017.tar.gz
+		Since          time.Duration // 0 means collect all logs
+		IncludeCores   bool
+		IncludeConfigs bool
+		CoreCollectors map[string]bool // service id -> whether collect cores
+		Secret         string          // encrypt the archive iff secret specified
+		UploadURL      string
+		S3             SupportS3Options
+	}
+)
+
+func getSupportOptions(curveadm *cli.CurveAdm) SupportOptions {
+	return curveadm.MemStorage().Get(comm.KEY_SUPPORT_OPTIONS).(SupportOptions)
+}
+
+// find expression which only matches files modified in time window
+func (options SupportOptions) findNewer() string {
+	if options.Since <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("-mmin -%d", int(math.Ceil(options.Since.Minutes())))
+}
+
+func localExecOptions(curveadm *cli.CurveAdm) module.ExecOptions {
+	options := curveadm.ExecOptions()
+	options.ExecWithSudo = false
+	options.ExecInLocal = true
+	return options
+}
+
 func NewInitSupportTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
 	// new task
 	kind := dc.GetKind()
@@ -40,51 +85,40 @@ func NewInitSupportTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*tas
 	t := task.NewTask("Init Support", subname, nil)
 
 	/*
-	 * 0d7a7103521da69c6331a96355142c3b
-	 *   data
-	 *     curveadm_db-encrypted.tar.gz
-	 *   report
-	 *     report-encrypted.tar.gz (curveadm hosts ls)
+	 * curve-support-my-cluster-20231018194017
+	 *   curveadm
+	 *     topology.yaml
+	 *     hosts.yaml
+	 *     curveadm.log
 	 *   service
 	 *     etcd
-	 *       7b510fb63730-encrypted.tar.gz
-	 *       978333085318-encrypted.tar.gz
+	 *       7b510fb63730
+	 *         logs
+	 *         conf
+	 *         cores
 	 *     mds
 	 *       ...
 	 *   client
-	 *     362d538778ad-encrypted.tar.gz
-	 *     b0d56cfaad14-encrypted.tar.gz
+	 *     362d538778ad
+	 *       logs
+	 *       conf
 	 */
 	roles := topology.CURVEBS_ROLES
 	if kind == topology.KIND_CURVEFS {
 		roles = topology.CURVEFS_ROLES
 	}
-	secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
-	urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-
-	options := curveadm.ExecOptions()
-	options.ExecInLocal = true
-	root := encodeSecret(secret)
+	root := getSupportOptions(curveadm).BundleDir
 	dirs := []string{
-		root,
-		path.Join(root, "data"),
-		path.Join(root, "report"),
-		path.Join(root, "service"),
+		path.Join(root, "curveadm"),
 		path.Join(root, "client"),
 	}
 	for _, role := range roles {
 		dirs = append(dirs, path.Join(root, "service", role))
 	}
-	// curl -F "mkdir=$DIR_NAME" http://localhost:8080/upload\?path=\/
-	for _, dir := range dirs {
-		t.AddStep(&step.Curl{
-			Url:         fmt.Sprintf(urlFormat, "/"),
-			Form:        fmt.Sprintf("mkdir=%s", dir),
-			Insecure:    true,
-			Silent:      true,
-			ExecOptions: options,
-		})
-	}
+	t.AddStep(&step.CreateDirectory{
+		Paths:       dirs,
+		ExecOptions: localExecOptions(curveadm),
+	})
 
 	return t, nil
 }
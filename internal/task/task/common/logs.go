@@ -0,0 +1,389 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	LOGS_REORDER_WINDOW = time.Second
+)
+
+var (
+	// I1018 19:38:04.123456 / I20231018 19:38:04.123456 (glog)
+	regexGlogTime = regexp.MustCompile(`^[IWEF](\d{4})?(\d{4} \d{2}:\d{2}:\d{2}\.\d{6})`)
+	// 2023-10-18T19:38:04.123456789Z (docker logs --timestamps)
+	regexRFC3339Time = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})`)
+	// 2023-10-18 19:38:04.123456 / 2023/10/18 19:38:04
+	regexDateTime = regexp.MustCompile(`^(\d{4})[-/](\d{2})[-/](\d{2})[ T](\d{2}:\d{2}:\d{2})(\.\d+)?`)
+	// {"level":"info","ts":"2023-10-18T19:38:04.123+0800",...} (zap json)
+	regexJSONTime = regexp.MustCompile(`"ts":"([^"]+)"`)
+)
+
+type (
+	LogsOptions struct {
+		Since  time.Duration
+		Tail   int // 0 means all lines
+		Follow bool
+		Grep   *regexp.Regexp
+	}
+
+	LogLine struct {
+		Time    time.Time
+		Host    string
+		Role    string
+		Id      string
+		Text    string
+		arrival time.Time
+	}
+
+	// LogMerger merges log lines from all services and orders them by timestamp,
+	// in follow mode the lines are reordered within a small window before output
+	LogMerger struct {
+		out    io.Writer
+		follow bool
+		lines  []LogLine
+		mutex  sync.Mutex
+		done   chan struct{}
+	}
+
+	// logWriter splits the output of remote command into lines,
+	// the line without timestamp (e.g. stack trace) inherits the previous one
+	logWriter struct {
+		host    string
+		role    string
+		id      string
+		options LogsOptions
+		merger  *LogMerger
+		buffer  bytes.Buffer
+		last    time.Time
+		now     time.Time
+		mutex   sync.Mutex // stdout and stderr share one writer
+	}
+
+	step2StreamLogs struct {
+		dc          *topology.DeployConfig
+		containerId string
+		curveadm    *cli.CurveAdm
+		execOptions module.ExecOptions
+	}
+)
+
+func ParseLogTime(line string, now time.Time) (time.Time, bool) {
+	if mu := regexRFC3339Time.FindString(line); len(mu) > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, mu); err == nil {
+			return t, true
+		} else if t, err := time.Parse("2006-01-02T15:04:05.999999999Z0700", mu); err == nil {
+			return t, true
+		}
+	}
+
+	if mu := regexGlogTime.FindStringSubmatch(line); len(mu) > 0 {
+		year := mu[1]
+		if len(year) == 0 {
+			year = strconv.Itoa(now.Year())
+		}
+		t, err := time.ParseInLocation("20060102 15:04:05.000000", year+mu[2], time.Local)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	if mu := regexDateTime.FindStringSubmatch(line); len(mu) > 0 {
+		value := fmt.Sprintf("%s-%s-%s %s%s", mu[1], mu[2], mu[3], mu[4], mu[5])
+		t, err := time.ParseInLocation("2006-01-02 15:04:05.999999999", value, time.Local)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	if mu := regexJSONTime.FindStringSubmatch(line); len(mu) > 0 {
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999Z0700"} {
+			if t, err := time.Parse(layout, mu[1]); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (line LogLine) String() string {
+	return fmt.Sprintf("%s | %s", line.Prefix(), line.Text)
+}
+
+func (line LogLine) Prefix() string {
+	return fmt.Sprintf("%s/%s/%s", line.Host, line.Role, line.Id)
+}
+
+func NewLogMerger(out io.Writer, follow bool) *LogMerger {
+	return &LogMerger{
+		out:    out,
+		follow: follow,
+		lines:  []LogLine{},
+		done:   make(chan struct{}),
+	}
+}
+
+func sortLogLines(lines []LogLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+}
+
+func (m *LogMerger) Add(line LogLine) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	line.arrival = time.Now()
+	m.lines = append(m.lines, line)
+}
+
+// flush outputs the lines which arrived before deadline
+func (m *LogMerger) flush(deadline time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	ready, pending := []LogLine{}, []LogLine{}
+	for _, line := range m.lines {
+		if line.arrival.Before(deadline) {
+			ready = append(ready, line)
+		} else {
+			pending = append(pending, line)
+		}
+	}
+	m.lines = pending
+
+	sortLogLines(ready)
+	for _, line := range ready {
+		fmt.Fprintln(m.out, line.String())
+	}
+}
+
+func (m *LogMerger) Start() {
+	if !m.follow {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(LOGS_REORDER_WINDOW / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.flush(time.Now().Add(-LOGS_REORDER_WINDOW))
+			case <-m.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop outputs all remaining lines
+func (m *LogMerger) Stop() {
+	if m.follow {
+		close(m.done)
+	}
+	m.flush(time.Now().Add(time.Hour))
+}
+
+// Lines returns all collected lines ordered by timestamp, it is used
+// for saving logs instead of output them
+func (m *LogMerger) Lines() []LogLine {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	lines := append([]LogLine{}, m.lines...)
+	sortLogLines(lines)
+	return lines
+}
+
+func (w *logWriter) add(text string) {
+	t, ok := ParseLogTime(text, w.now)
+	if ok {
+		w.last = t
+	} else {
+		t = w.last
+	}
+
+	options := w.options
+	if options.Since > 0 && (t.IsZero() || t.Before(w.now.Add(-options.Since))) {
+		return
+	} else if options.Grep != nil && !options.Grep.MatchString(text) {
+		return
+	}
+
+	w.merger.Add(LogLine{
+		Time: t,
+		Host: w.host,
+		Role: w.role,
+		Id:   w.id,
+		Text: text,
+	})
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer.Write(p)
+	for {
+		line, err := w.buffer.ReadString('\n')
+		if err != nil { // incomplete line, wait for more data
+			w.buffer.Reset()
+			w.buffer.WriteString(line)
+			break
+		}
+		w.add(strings.TrimRight(line, "\r\n"))
+	}
+	return len(p), nil
+}
+
+func (w *logWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.buffer.Len() > 0 {
+		w.add(w.buffer.String())
+		w.buffer.Reset()
+	}
+}
+
+func getTailLines(options LogsOptions) string {
+	if options.Tail <= 0 {
+		return "all"
+	}
+	return strconv.Itoa(options.Tail)
+}
+
+// the glog writes lines to INFO/WARNING/ERROR/FATAL files by severity, and the
+// INFO file contains all of them, so we only read it to avoid duplicate lines
+func getTailLogFilesCommand(logDir string, options LogsOptions) string {
+	find := fmt.Sprintf(`find %s -type f ! -name "*.WARNING*" ! -name "*.ERROR*" ! -name "*.FATAL*"`, logDir)
+	if options.Since > 0 {
+		find = fmt.Sprintf("%s -mmin -%d", find, int(math.Ceil(options.Since.Minutes())))
+	}
+
+	lines := getTailLines(options)
+	if lines == "all" {
+		lines = "+1"
+	}
+	tail := fmt.Sprintf("tail -q -n %s", lines)
+	if options.Follow {
+		tail = fmt.Sprintf("%s -F", tail)
+	}
+	return fmt.Sprintf(`/bin/bash -c 'files=$(%s | sort); [ -z "$files" ] || %s $files'`, find, tail)
+}
+
+func (s *step2StreamLogs) Execute(ctx *context.Context) error {
+	options := s.curveadm.MemStorage().Get(comm.KEY_LOGS_OPTIONS).(LogsOptions)
+	merger := s.curveadm.MemStorage().Get(comm.KEY_LOGS_MERGER).(*LogMerger)
+	newWriter := func() *logWriter {
+		return &logWriter{
+			host:    s.dc.GetHost(),
+			role:    s.dc.GetRole(),
+			id:      s.dc.GetId(),
+			options: options,
+			merger:  merger,
+			now:     time.Now(),
+		}
+	}
+
+	// (1) container logs (stdout/stderr)
+	var wg sync.WaitGroup
+	var err error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w := newWriter()
+		cli := ctx.Module().DockerCli().ContainerLogs(s.containerId).
+			AddOption("--timestamps").
+			AddOption("--tail %s", getTailLines(options))
+		if options.Since > 0 {
+			cli.AddOption("--since %ds", int(options.Since.Seconds()))
+		}
+		if options.Follow {
+			cli.AddOption("--follow")
+		}
+		err = cli.Stream(s.execOptions, w)
+		w.Flush()
+	}()
+
+	// (2) log files in service log directory, ignore error if no files
+	w := newWriter()
+	logDir := s.dc.GetProjectLayout().ServiceLogDir
+	command := getTailLogFilesCommand(logDir, options)
+	ctx.Module().DockerCli().ContainerExec(s.containerId, command).
+		Stream(s.execOptions, w)
+	w.Flush()
+
+	wg.Wait()
+	if err != nil {
+		return errno.ERR_GET_CONTAINER_LOGS_FAILED.E(err)
+	}
+	return nil
+}
+
+func NewStreamLogsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Stream Service Logs", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2StreamLogs{
+		dc:          dc,
+		containerId: containerId,
+		curveadm:    curveadm,
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogTime(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2023, 10, 18, 20, 0, 0, 0, time.Local)
+	expect := time.Date(2023, 10, 18, 19, 38, 4, 123456000, time.Local)
+
+	for _, line := range []string{
+		"I1018 19:38:04.123456 12345 chunkserver.cpp:100] start chunkserver",
+		"E20231018 19:38:04.123456 12345 chunkserver.cpp:100] read failed",
+		"2023-10-18 19:38:04.123456 I | etcdserver: published",
+		"2023/10/18 19:38:04.123456 [error] connect() failed",
+		`{"level":"info","ts":"` + expect.Format("2006-01-02T15:04:05.000000Z0700") + `","msg":"ready"}`,
+		expect.UTC().Format(time.RFC3339Nano) + " stdout line",
+	} {
+		ts, ok := ParseLogTime(line, now)
+		assert.True(ok, line)
+		assert.True(expect.Equal(ts), line)
+	}
+
+	_, ok := ParseLogTime("    at stack trace", now)
+	assert.False(ok)
+}
+
+func TestLogMerger(t *testing.T) {
+	assert := assert.New(t)
+	out := bytes.NewBufferString("")
+	merger := NewLogMerger(out, false)
+	base := time.Date(2023, 10, 18, 19, 38, 4, 0, time.Local)
+	merger.Add(LogLine{Time: base.Add(time.Second), Host: "host1", Role: "mds", Id: "a", Text: "2"})
+	merger.Add(LogLine{Time: base, Host: "host2", Role: "mds", Id: "b", Text: "1"})
+	merger.Add(LogLine{Time: base.Add(time.Second), Host: "host1", Role: "mds", Id: "a", Text: "3"})
+
+	lines := merger.Lines()
+	assert.Len(lines, 3)
+	assert.Equal("1", lines[0].Text)
+	assert.Equal("2", lines[1].Text)
+	merger.Stop()
+	assert.Equal("host2/mds/b | 1\nhost1/mds/a | 2\nhost1/mds/a | 3\n", out.String())
+}
+
+func TestLogWriter(t *testing.T) {
+	assert := assert.New(t)
+	merger := NewLogMerger(bytes.NewBufferString(""), false)
+	w := &logWriter{
+		options: LogsOptions{Since: 10 * time.Minute},
+		merger:  merger,
+		now:     time.Date(2023, 10, 18, 19, 40, 0, 0, time.Local),
+	}
+	w.add("I1018 19:20:00.000000 1 a.cpp:1] too old")
+	w.add("I1018 19:38:00.000000 1 a.cpp:1] crashed")
+	w.add("    at stack trace")
+	assert.Len(merger.Lines(), 2)
+}
@@ -0,0 +1,259 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	REDACTED_VALUE    = "******"
+	DEFAULT_S3_REGION = "us-east-1"
+)
+
+var (
+	// s3.ak=xxx, s3.sk: xxx, etcd.auth.password=xxx, access_key: xxx ...
+	regexSecretItem = regexp.MustCompile(`(?im)^(\s*-?\s*"?[\w.\-]*` +
+		`(password|passwd|secret|token|access_?key|secret_?key|\.ak|\.sk)` +
+		`[\w.\-]*"?[ \t]*[:=][ \t]*)(\S.*?)[ \t]*$`)
+
+	// the secret in the middle of log line, e.g. -s3_sk=xxx, "password":"xxx", --s3-secret-key xxx
+	regexInlineSecret = regexp.MustCompile(`(?i)([\w.\-]*` +
+		`(password|passwd|secret|token|access_?key|secret_?key|[._]ak\b|[._]sk\b)` +
+		`[\w.\-]*"?\s*[:=]\s*"?)([^\s"',;]+)`)
+	regexSecretFlag = regexp.MustCompile(`(?i)(--[\w.\-]*` +
+		`(password|secret|token|access-key|secret-key|\.ak|\.sk)\s+)([^\s\-][^\s"',;]*)`)
+)
+
+const (
+	BINARY_DETECT_SIZE = 8000 // same as git
+)
+
+type (
+	step2RedactBundle struct {
+		bundleDir string
+	}
+
+	step2WriteCurlConfig struct {
+		filename string
+		s3       SupportS3Options
+	}
+)
+
+// RedactSecrets replaces the value of secret items (e.g. S3 keys, etcd password)
+func RedactSecrets(content string) string {
+	content = regexSecretItem.ReplaceAllString(content, "${1}"+REDACTED_VALUE)
+	content = regexInlineSecret.ReplaceAllString(content, "${1}"+REDACTED_VALUE)
+	return regexSecretFlag.ReplaceAllString(content, "${1}"+REDACTED_VALUE)
+}
+
+// configures and logs may contain secrets, only core files are skipped
+func needRedact(relpath string) bool {
+	return !strings.Contains(relpath, "/cores/")
+}
+
+func isBinary(filename string) (bool, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	buffer := make([]byte, BINARY_DETECT_SIZE)
+	n, err := io.ReadFull(f, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	return bytes.IndexByte(buffer[:n], 0) >= 0, nil
+}
+
+// redact file line by line, because the log file may be large
+func redactFile(filename string) error {
+	src, err := os.Open(filename)
+	if err != nil {
+		return errno.ERR_READ_FILE_FAILED.E(err)
+	}
+	defer src.Close()
+	dst, err := os.CreateTemp(path.Dir(filename), ".redact-*")
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	defer os.Remove(dst.Name())
+	defer dst.Close()
+
+	reader := bufio.NewReader(src)
+	writer := bufio.NewWriter(dst)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if _, werr := writer.WriteString(RedactSecrets(line)); werr != nil {
+				return errno.ERR_WRITE_FILE_FAILED.E(werr)
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errno.ERR_READ_FILE_FAILED.E(err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	} else if err := dst.Close(); err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	} else if err := os.Rename(dst.Name(), filename); err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *step2RedactBundle) Execute(ctx *context.Context) error {
+	return filepath.Walk(s.bundleDir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errno.ERR_READ_FILE_FAILED.E(err)
+		} else if info.IsDir() || !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relpath := strings.TrimPrefix(filename, s.bundleDir)
+		if !needRedact(relpath) {
+			return nil
+		} else if binary, err := isBinary(filename); err != nil {
+			return errno.ERR_READ_FILE_FAILED.E(err)
+		} else if binary {
+			return nil
+		}
+		return redactFile(filename)
+	})
+}
+
+// write S3 credentials into curl config file to avoid exposing them in command line
+func (s *step2WriteCurlConfig) Execute(ctx *context.Context) error {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	content := fmt.Sprintf("user = \"%s:%s\"\n",
+		escaper.Replace(s.s3.AccessKey), escaper.Replace(s.s3.SecretKey))
+	err := utils.WriteFile(s.filename, content, 0600)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	return nil
+}
+
+func (options SupportOptions) s3Url() string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(options.S3.Endpoint, "/"),
+		options.S3.Bucket, path.Base(options.Archive))
+}
+
+func NewPackSupportBundleTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := getSupportOptions(curveadm)
+
+	// new task
+	subname := fmt.Sprintf("cluster=%s archive=%s",
+		curveadm.ClusterName(), options.Archive)
+	t := task.NewTask("Pack Support Bundle", subname, nil)
+
+	// add step to task
+	localOptions := localExecOptions(curveadm)
+	tarballPath := options.Archive
+	if len(options.Secret) > 0 {
+		tarballPath = options.BundleDir + ".tar.gz"
+	}
+	t.AddStep(&step2RedactBundle{
+		bundleDir: options.BundleDir,
+	})
+	t.AddStep(&step.Tar{
+		File:        path.Base(options.BundleDir),
+		Archive:     tarballPath,
+		Directory:   path.Dir(options.BundleDir),
+		Create:      true,
+		Gzip:        true,
+		ExecOptions: localOptions,
+	})
+	if len(options.Secret) > 0 {
+		t.AddStep(&step2EncryptFile{
+			source: tarballPath,
+			dest:   options.Archive,
+			secret: options.Secret,
+		})
+	}
+
+	// upload to user-configured url or S3 endpoint
+	if len(options.UploadURL) > 0 {
+		t.AddStep(&step.Curl{ // curl -F "file=@$FILE" http://localhost:8080/upload
+			Url:         options.UploadURL,
+			Form:        fmt.Sprintf("file=@%s", options.Archive),
+			Fail:        true,
+			Silent:      true,
+			ExecOptions: localOptions,
+		})
+	} else if len(options.S3.Endpoint) > 0 {
+		region := options.S3.Region
+		if len(region) == 0 {
+			region = DEFAULT_S3_REGION
+		}
+		config := options.BundleDir + ".curlrc"
+		t.AddStep(&step2WriteCurlConfig{
+			filename: config,
+			s3:       options.S3,
+		})
+		t.AddStep(&step.Curl{ // curl --aws-sigv4 aws:amz:us-east-1:s3 -T $FILE http://s3/bucket/
+			Url:         options.s3Url(),
+			UploadFile:  options.Archive,
+			AwsSigv4:    fmt.Sprintf("aws:amz:%s:s3", region),
+			Config:      config,
+			Fail:        true,
+			Silent:      true,
+			ExecOptions: localOptions,
+		})
+		t.AddPostStep(&step.RemoveFile{
+			Files:       []string{config},
+			ExecOptions: localOptions,
+		})
+	}
+
+	files := []string{options.BundleDir}
+	if tarballPath != options.Archive {
+		files = append(files, tarballPath)
+	}
+	t.AddPostStep(&step.RemoveFile{
+		Files:       files,
+		ExecOptions: localOptions,
+	})
+
+	return t, nil
+}
@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	assert := assert.New(t)
+	for input, expect := range map[string]string{
+		"s3.ak=0123456789":           "s3.ak=******",
+		"s3.sk = abcdef":             "s3.sk = ******",
+		"  s3.ak: 0123456789":        "  s3.ak: ******",
+		"etcd.auth.password=curve":   "etcd.auth.password=******",
+		"access_key: AKIAXXXX":       "access_key: ******",
+		"  - token: \"xxx\"":         "  - token: ******",
+		"s3.endpoint=127.0.0.1:9000": "s3.endpoint=127.0.0.1:9000",
+		"mds.listen.addr=:6700":      "mds.listen.addr=:6700",
+		"# password should be set":   "# password should be set",
+	} {
+		assert.Equal(expect, RedactSecrets(input), input)
+	}
+
+	assert.Equal("a=1\ns3.sk=******\nb=2\n", RedactSecrets("a=1\ns3.sk=secret\nb=2\n"))
+	assert.True(needRedact("/service/mds/7b510fb63730/conf/mds.conf"))
+	assert.True(needRedact("/curveadm/topology.yaml"))
+	assert.True(needRedact("/service/mds/7b510fb63730/logs/mds.INFO"))
+	assert.False(needRedact("/service/mds/7b510fb63730/cores/core.mds.1234"))
+}
+
+func TestRedactLogSecrets(t *testing.T) {
+	assert := assert.New(t)
+	for input, expect := range map[string]string{
+		"I1018 curvefs_tool create-fs -s3_ak=AK -s3_sk=SK -s3_endpoint=http://s3":    "I1018 curvefs_tool create-fs -s3_ak=****** -s3_sk=****** -s3_endpoint=http://s3",
+		`{"command":"curve_ops_tool list -userName=curve -password=secret"}`:         `{"command":"curve_ops_tool list -userName=curve -password=******"}`,
+		`{"detail":{"password":"secret","user":"curve"}}`:                            `{"detail":{"password":"******","user":"curve"}}`,
+		"curveadm support --s3-access-key AK --s3-secret-key SK --s3-bucket support": "curveadm support --s3-access-key ****** --s3-secret-key ****** --s3-bucket support",
+		"chunkserver.disk_size=10 use_skip=true":                                     "chunkserver.disk_size=10 use_skip=true",
+		"decrypt target credentials failed, please check the secret key":             "decrypt target credentials failed, please check the secret key",
+	} {
+		assert.Equal(expect, RedactSecrets(input), input)
+	}
+}
+
+func TestRedactBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	bundleDir := t.TempDir()
+	logfile := path.Join(bundleDir, "service", "logs", "mds.INFO")
+	corefile := path.Join(bundleDir, "service", "cores", "core.mds")
+	binfile := path.Join(bundleDir, "service", "logs", "mds.INFO.gz")
+	for filename, content := range map[string]string{
+		logfile:  "a=1\n-password=secret\n",
+		corefile: "password=secret\n",
+		binfile:  "\x1f\x8b\x00password=secret",
+	} {
+		assert.Nil(os.MkdirAll(path.Dir(filename), 0755))
+		assert.Nil(os.WriteFile(filename, []byte(content), 0644))
+	}
+
+	assert.Nil((&step2RedactBundle{bundleDir: bundleDir}).Execute(nil))
+	for filename, content := range map[string]string{
+		logfile:  "a=1\n-password=******\n",
+		corefile: "password=secret\n",
+		binfile:  "\x1f\x8b\x00password=secret",
+	} {
+		data, err := os.ReadFile(filename)
+		assert.Nil(err)
+		assert.Equal(content, string(data))
+	}
+}
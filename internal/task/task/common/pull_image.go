@@ -39,15 +39,33 @@ func NewPullImageTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.
 		return nil, err
 	}
 
+	pinned, err := PinnedImageDigests(curveadm)
+	if err != nil {
+		return nil, err
+	}
+
 	// new task
-	subname := fmt.Sprintf("host=%s image=%s", dc.GetHost(), dc.GetContainerImage())
+	image := dc.GetContainerImage()
+	subname := fmt.Sprintf("host=%s image=%s", dc.GetHost(), image)
 	t := task.NewTask("Pull Image", subname, hc.GetSSHConfig())
 
 	// add step to task
+	var out string
 	t.AddStep(&step.PullImage{
-		Image:       dc.GetContainerImage(),
+		Image:       image,
+		Mirror:      curveadm.Config().GetRegistryMirror(),
+		Digest:      pinned[image],
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step.InspectImage{
+		Image:       image,
+		Format:      FORMAT_REPO_DIGESTS,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: step2RecordPulledDigest(curveadm, dc, pinned[image], &out),
+	})
 
 	return t, nil
 }
@@ -0,0 +1,223 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	PREFIX_VOLUME_CONTAINER     = "curvebs-volume-"
+	PREFIX_FILESYSTEM_CONTAINER = "curvefs-filesystem-"
+)
+
+type (
+	step2ParseClientContainers struct {
+		host       string
+		out        *string
+		memStorage *utils.SafeMap
+	}
+
+	ClientContainer struct {
+		Host        string
+		Kind        string
+		ContainerId string
+		Name        string
+		Status      string
+	}
+
+	ClientReconcile struct {
+		Id          string
+		Kind        string
+		Host        string
+		ContainerId string
+		Status      string
+		State       string
+		AuxInfo     string
+	}
+)
+
+func setClientContainers(memStorage *utils.SafeMap, host string, containers []ClientContainer) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string][]ClientContainer{}
+		v := kv.Get(comm.KEY_ALL_CLIENT_CONTAINERS)
+		if v != nil {
+			m = v.(map[string][]ClientContainer)
+		}
+		m[host] = containers
+		kv.Set(comm.KEY_ALL_CLIENT_CONTAINERS, m)
+		return nil
+	})
+}
+
+func container2Kind(name string) string {
+	if strings.HasPrefix(name, PREFIX_VOLUME_CONTAINER) {
+		return topology.KIND_CURVEBS
+	} else if strings.HasPrefix(name, PREFIX_FILESYSTEM_CONTAINER) {
+		return topology.KIND_CURVEFS
+	}
+	return ""
+}
+
+// output line: CONTAINER_ID NAME STATUS
+func ParseClientContainers(host, out string) []ClientContainer {
+	containers := []ClientContainer{}
+	for _, line := range strings.Split(out, "\n") {
+		items := strings.SplitN(strings.TrimSpace(line), " ", 3)
+		if len(items) != 3 {
+			continue
+		}
+
+		kind := container2Kind(items[1])
+		if len(kind) == 0 {
+			continue
+		}
+		containers = append(containers, ClientContainer{
+			Host:        host,
+			Kind:        kind,
+			ContainerId: items[0],
+			Name:        items[1],
+			Status:      items[2],
+		})
+	}
+	return containers
+}
+
+func (s *step2ParseClientContainers) Execute(ctx *context.Context) error {
+	setClientContainers(s.memStorage, s.host, ParseClientContainers(s.host, *s.out))
+	return nil
+}
+
+func NewScanClientContainersTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	subname := fmt.Sprintf("host=%s", hc.GetHost())
+	t := task.NewTask("Scan Client Containers", subname, hc.GetSSHConfig())
+
+	// add step
+	var out string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.ID}} {{.Names}} {{.Status}}"`,
+		Filter:      "name=curve",
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2ParseClientContainers{
+		host:       hc.GetHost(),
+		out:        &out,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
+
+func NewStartClientContainerTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	client := v.(storage.Client)
+	hc, err := curveadm.GetHost(client.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	containerId := client.ContainerId
+	subname := fmt.Sprintf("host=%s kind=%s containerId=%s",
+		hc.GetHost(), client.Kind, tui.TrimContainerId(containerId))
+	t := task.NewTask("Start Client Container", subname, hc.GetSSHConfig())
+
+	// add step
+	t.AddStep(&step.StartContainer{
+		ContainerId: &containerId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+func matchContainer(client storage.Client, container ClientContainer) bool {
+	return client.Host == container.Host &&
+		len(container.ContainerId) > 0 &&
+		strings.HasPrefix(client.ContainerId, container.ContainerId)
+}
+
+// ReconcileClients compares the clients recorded in database with the
+// containers which scanned from hosts, the result contains:
+//
+//	OK: client recorded and its container is running
+//	Stopped: client recorded but its container isn't running
+//	Stale: client recorded but its container is losed
+//	Unrecorded: container exists but no client recorded for it
+func ReconcileClients(clients []storage.Client, containers []ClientContainer) []ClientReconcile {
+	items := []ClientReconcile{}
+	matched := map[int]bool{}
+	for _, client := range clients {
+		item := ClientReconcile{
+			Id:          client.Id,
+			Kind:        client.Kind,
+			Host:        client.Host,
+			ContainerId: client.ContainerId,
+			Status:      comm.CLIENT_STATUS_LOSED,
+			State:       comm.CLIENT_RECONCILE_STALE,
+			AuxInfo:     client.AuxInfo,
+		}
+		for i, container := range containers {
+			if matched[i] || !matchContainer(client, container) {
+				continue
+			}
+			matched[i] = true
+			item.Status = container.Status
+			if strings.HasPrefix(container.Status, "Up") {
+				item.State = comm.CLIENT_RECONCILE_OK
+			} else {
+				item.State = comm.CLIENT_RECONCILE_STOPPED
+			}
+			break
+		}
+		items = append(items, item)
+	}
+
+	for i, container := range containers {
+		if matched[i] {
+			continue
+		}
+		items = append(items, ClientReconcile{
+			Id:          "-",
+			Kind:        container.Kind,
+			Host:        container.Host,
+			ContainerId: container.ContainerId,
+			Status:      container.Status,
+			State:       comm.CLIENT_RECONCILE_UNRECORDED,
+			AuxInfo:     container.Name,
+		})
+	}
+	return items
+}
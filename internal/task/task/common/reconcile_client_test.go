@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClientContainers(t *testing.T) {
+	assert := assert.New(t)
+	out := "1e1b0a5c2f3d curvebs-volume-4a5b Up 3 hours\n" +
+		"2f2c1b6d3e4a curvefs-filesystem-9c8d Exited (137) 2 days ago\n" +
+		"3a3d2c7e4f5b curvebs-chunkserver-abcd Up 3 hours\n"
+	containers := ParseClientContainers("host1", out)
+	assert.Len(containers, 2)
+	assert.Equal("curvebs", containers[0].Kind)
+	assert.Equal("Up 3 hours", containers[0].Status)
+	assert.Equal("curvefs", containers[1].Kind)
+	assert.Equal("curvefs-filesystem-9c8d", containers[1].Name)
+}
+
+func TestReconcileClients(t *testing.T) {
+	assert := assert.New(t)
+	clients := []storage.Client{
+		{Id: "c1", Kind: "curvebs", Host: "host1", ContainerId: "1e1b0a5c2f3d0000"},
+		{Id: "c2", Kind: "curvefs", Host: "host1", ContainerId: "2f2c1b6d3e4a0000"},
+		{Id: "c3", Kind: "curvebs", Host: "host2", ContainerId: "1e1b0a5c2f3d0000"},
+	}
+	containers := []ClientContainer{
+		{Host: "host1", Kind: "curvebs", ContainerId: "1e1b0a5c2f3d", Status: "Up 3 hours"},
+		{Host: "host1", Kind: "curvefs", ContainerId: "2f2c1b6d3e4a", Status: "Exited (137) 2 days ago"},
+		{Host: "host2", Kind: "curvefs", ContainerId: "4b4e3d8f5a6c", Name: "curvefs-filesystem-1234", Status: "Up 1 hour"},
+	}
+
+	items := ReconcileClients(clients, containers)
+	assert.Len(items, 4)
+	assert.Equal(comm.CLIENT_RECONCILE_OK, items[0].State)
+	assert.Equal(comm.CLIENT_RECONCILE_STOPPED, items[1].State)
+	assert.Equal(comm.CLIENT_RECONCILE_STALE, items[2].State)
+	assert.Equal(comm.CLIENT_STATUS_LOSED, items[2].Status)
+	assert.Equal(comm.CLIENT_RECONCILE_UNRECORDED, items[3].State)
+	assert.Equal("curvefs-filesystem-1234", items[3].AuxInfo)
+}
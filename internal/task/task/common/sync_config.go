@@ -30,10 +30,13 @@ import (
 
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/scripts"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
@@ -42,6 +45,19 @@ const (
 	TOOLS_V2_CONFIG_DELIMITER = ":"
 
 	CURVE_CRONTAB_FILE = "/tmp/curve_crontab"
+
+	CMD_REMOVE_REPORT_CRONTAB = "bash -c '[[ -z $(which crontab) ]] || crontab -l 2>/dev/null | grep -v %s | crontab -'"
+)
+
+type (
+	// apply crontab to running service, the report entry is removed if usage report disabled
+	step2SyncCrontab struct {
+		containerId      string
+		status           *string
+		reportEnabled    bool
+		reportScriptPath string
+		execOptions      module.ExecOptions
+	}
 )
 
 func NewMutate(dc *topology.DeployConfig, delimiter string, forceRender bool) step.Mutate {
@@ -100,21 +116,46 @@ func newToolV2Mutate(dc *topology.DeployConfig, delimiter string, forceRender bo
 	}
 }
 
-func newCrontab(uuid string, dc *topology.DeployConfig, reportScriptPath string) string {
+// the usage is reported only if both telemetry (curveadm.cfg) and report_usage (topology) enabled
+func reportEnabled(curveadm *cli.CurveAdm, dc *topology.DeployConfig) bool {
+	return curveadm.Config().GetTelemetry() && dc.GetReportUsage()
+}
+
+func newCrontab(curveadm *cli.CurveAdm, dc *topology.DeployConfig, reportScriptPath string) string {
 	var period, command string
-	if dc.GetReportUsage() {
+	cfg := curveadm.Config()
+	if reportEnabled(curveadm, dc) {
 		period = func(minute, hour, day, month, week string) string {
 			return fmt.Sprintf("%s %s %s %s %s", minute, hour, day, month, week)
 		}("0", "*", "*", "*", "*") // every hour
 
 		command = func(format string, args ...interface{}) string {
 			return fmt.Sprintf(format, args...)
-		}("bash %s %s %s %s", reportScriptPath, dc.GetKind(), uuid, dc.GetRole())
+		}("bash %s %s %s %s '%s'", reportScriptPath, dc.GetKind(),
+			curveadm.ClusterUUId(), dc.GetRole(), cfg.GetReportURL())
 	}
 
 	return fmt.Sprintf("%s %s\n", period, command)
 }
 
+// the crontab of stopped service will be applied when it starts
+func (s *step2SyncCrontab) Execute(ctx *context.Context) error {
+	if !strings.HasPrefix(*s.status, "Up") {
+		return nil
+	}
+
+	command := fmt.Sprintf(CMD_ADD_CONTABLE, CURVE_CRONTAB_FILE)
+	if !s.reportEnabled {
+		command = fmt.Sprintf(CMD_REMOVE_REPORT_CRONTAB, s.reportScriptPath)
+	}
+	dockerCli := ctx.Module().DockerCli().ContainerExec(s.containerId, command)
+	out, err := dockerCli.Execute(s.execOptions)
+	if err != nil {
+		return errno.ERR_START_CRONTAB_IN_CONTAINER_FAILED.S(out)
+	}
+	return nil
+}
+
 func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
 	serviceId := curveadm.GetServiceId(dc.GetId())
 	containerId, err := curveadm.GetContainerId(serviceId)
@@ -134,12 +175,12 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task
 	t := task.NewTask("Sync Config", subname, hc.GetSSHConfig())
 
 	// add step to task
-	var out string
+	var out, status string
 	layout := dc.GetProjectLayout()
 	role := dc.GetRole()
 	reportScript := scripts.SCRIPT_REPORT
 	reportScriptPath := fmt.Sprintf("%s/report.sh", layout.ToolsBinDir)
-	crontab := newCrontab(curveadm.ClusterUUId(), dc, reportScriptPath)
+	crontab := newCrontab(curveadm, dc, reportScriptPath)
 	delimiter := DEFAULT_CONFIG_DELIMITER
 	if role == topology.ROLE_ETCD {
 		delimiter = ETCD_CONFIG_DELIMITER
@@ -196,6 +237,20 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task
 		Content:           &crontab,
 		ExecOptions:       curveadm.ExecOptions(),
 	})
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.Status}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &status,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2SyncCrontab{
+		containerId:      containerId,
+		status:           &status,
+		reportEnabled:    reportEnabled(curveadm, dc),
+		reportScriptPath: reportScriptPath,
+		execOptions:      curveadm.ExecOptions(),
+	})
 
 	return t, nil
 }
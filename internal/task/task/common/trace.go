@@ -0,0 +1,260 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	TRACE_TOOL_BT     = "bt"
+	TRACE_TOOL_PERF   = "perf"
+	TRACE_TOOL_OFFCPU = "offcpu"
+
+	TRACE_WORK_DIR         = "/curveadm"
+	FORMAT_CONTAINER_IMAGE = "'{{.Config.Image}}'"
+
+	CLIENT_FUSE_BINARY = "/curvefs/client/sbin/curve-fuse"
+	CLIENT_NEBD_BINARY = "/curvebs/nebd/sbin/nebd-server"
+)
+
+var (
+	TRACE_TOOLS = []string{TRACE_TOOL_BT, TRACE_TOOL_PERF, TRACE_TOOL_OFFCPU}
+
+	// commands which provided by trace tool, any of them is enough
+	TRACE_TOOL_COMMANDS = map[string][]string{
+		TRACE_TOOL_BT:     {"bpftrace"},
+		TRACE_TOOL_PERF:   {"perf"},
+		TRACE_TOOL_OFFCPU: {"offcputime-bpfcc", "offcputime", "/usr/share/bcc/tools/offcputime"},
+	}
+)
+
+type (
+	TraceOptions struct {
+		Id          string // service id or client id
+		Host        string
+		ContainerId string
+		Binary      string // executable path in container which to trace
+		Tool        string
+		Duration    time.Duration
+		Image       string // image which tracing tools installed in, empty means the traced container's image
+		Output      string // local directory which trace result saved in
+		Name        string // directory name of trace result
+	}
+
+	// make sure the trace tool installed in image before tracing
+	step2CheckTraceTool struct {
+		image       *string
+		options     TraceOptions
+		execOptions module.ExecOptions
+	}
+
+	// run tracer alongside the traced container, shares its pid namespace
+	step2RunTracer struct {
+		image       *string
+		options     TraceOptions
+		scriptPath  string
+		execOptions module.ExecOptions
+	}
+)
+
+func getTraceOptions(curveadm *cli.CurveAdm) TraceOptions {
+	return curveadm.MemStorage().Get(comm.KEY_TRACE_OPTIONS).(TraceOptions)
+}
+
+// ClientBinary returns the executable path of client in container
+func ClientBinary(kind string) string {
+	if kind == topology.KIND_CURVEFS {
+		return CLIENT_FUSE_BINARY
+	}
+	return CLIENT_NEBD_BINARY
+}
+
+// TraceToolCheckCommand returns the command for bash which succeeds only if
+// the trace tool is installed
+func TraceToolCheckCommand(tool string) string {
+	checks := []string{}
+	for _, command := range TRACE_TOOL_COMMANDS[tool] {
+		checks = append(checks, fmt.Sprintf("command -v %s", command))
+	}
+	return fmt.Sprintf("-c '%s'", strings.Join(checks, " || "))
+}
+
+// the specified image is preferred, otherwise the traced container's image
+func traceImage(options TraceOptions, inspected *string) string {
+	if len(options.Image) > 0 {
+		return options.Image
+	}
+	return strings.TrimSpace(*inspected)
+}
+
+func (s *step2CheckTraceTool) Execute(ctx *context.Context) error {
+	var success bool
+	image := traceImage(s.options, s.image)
+	checker := &step.RunContainer{
+		Image:       image,
+		Command:     TraceToolCheckCommand(s.options.Tool),
+		Entrypoint:  "/bin/bash",
+		Remove:      true,
+		Success:     &success,
+		ExecOptions: s.execOptions,
+	}
+	if err := checker.Execute(ctx); err != nil {
+		return err
+	} else if !success {
+		return errno.ERR_TRACE_TOOL_NOT_FOUND.
+			F("tool: %s (%s), image: %s", s.options.Tool,
+				strings.Join(TRACE_TOOL_COMMANDS[s.options.Tool], "/"), image)
+	}
+	return nil
+}
+
+func (s *step2RunTracer) Execute(ctx *context.Context) error {
+	options := s.options
+	image := traceImage(options, s.image)
+
+	// tracing lasts for the duration, so extend the timeout
+	execOptions := s.execOptions
+	if execOptions.ExecTimeoutSec > 0 {
+		execOptions.ExecTimeoutSec += int(options.Duration.Seconds())
+	}
+	runner := &step.RunContainer{
+		Image: image,
+		Command: fmt.Sprintf("%s %s %s %d %s",
+			path.Join(TRACE_WORK_DIR, path.Base(s.scriptPath)), options.Tool, options.Binary,
+			int(options.Duration.Seconds()), path.Join(TRACE_WORK_DIR, options.Name)),
+		Entrypoint: "/bin/bash",
+		Pid:        fmt.Sprintf("container:%s", options.ContainerId),
+		Privileged: true,
+		Volumes: []step.Volume{
+			{HostPath: path.Dir(s.scriptPath), ContainerPath: TRACE_WORK_DIR},
+			{HostPath: "/sys/kernel/debug", ContainerPath: "/sys/kernel/debug"},
+			{HostPath: "/lib/modules", ContainerPath: "/lib/modules"},
+			{HostPath: "/usr/src", ContainerPath: "/usr/src"},
+		},
+		Remove:      true,
+		ExecOptions: execOptions,
+	}
+	return runner.Execute(ctx)
+}
+
+/*
+ * remote: /tmp/<random>/<name>/{bt.txt,perf.*,offcpu.*} -> /tmp/<random>/<name>.tar.gz
+ * local:  <output>/<name>.tar.gz -> <output>/<name>/
+ */
+func NewTraceTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	options := getTraceOptions(curveadm)
+	hc, err := curveadm.GetHost(options.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s id=%s containerId=%s tool=%s duration=%s",
+		options.Host, options.Id, tui.TrimContainerId(options.ContainerId),
+		options.Tool, options.Duration)
+	t := task.NewTask("Trace Process", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var image string
+	script := scripts.SCRIPT_TRACE
+	remoteBaseDir := path.Join(TEMP_DIR, fmt.Sprintf("curve-trace-%s", utils.RandString(5)))
+	remoteScriptPath := path.Join(remoteBaseDir, "trace.sh")
+	remoteTarballPath := path.Join(remoteBaseDir, options.Name+".tar.gz")
+	localTarballPath := path.Join(options.Output, options.Name+".tar.gz")
+	localOptions := localExecOptions(curveadm)
+
+	if len(options.Image) == 0 {
+		t.AddStep(&step.InspectContainer{
+			ContainerId: options.ContainerId,
+			Format:      FORMAT_CONTAINER_IMAGE,
+			Out:         &image,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step2CheckTraceTool{
+		image:       &image,
+		options:     options,
+		execOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{path.Join(remoteBaseDir, options.Name)},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallFile{
+		Content:      &script,
+		HostDestPath: remoteScriptPath,
+		ExecOptions:  curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2RunTracer{
+		image:       &image,
+		options:     options,
+		scriptPath:  remoteScriptPath,
+		execOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Tar{
+		File:        options.Name,
+		Archive:     remoteTarballPath,
+		Directory:   remoteBaseDir,
+		Create:      true,
+		Gzip:        true,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.DownloadFile{
+		RemotePath:  remoteTarballPath,
+		LocalPath:   localTarballPath,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Tar{
+		Archive:     localTarballPath,
+		Directory:   options.Output,
+		Extract:     true,
+		UnGzip:      true,
+		ExecOptions: localOptions,
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{remoteBaseDir},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{localTarballPath},
+		ExecOptions: localOptions,
+	})
+
+	return t, nil
+}
@@ -0,0 +1,54 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceToolCheckCommand(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("-c 'command -v bpftrace'", TraceToolCheckCommand(TRACE_TOOL_BT))
+	assert.Equal("-c 'command -v perf'", TraceToolCheckCommand(TRACE_TOOL_PERF))
+	assert.Equal("-c 'command -v offcputime-bpfcc || command -v offcputime || "+
+		"command -v /usr/share/bcc/tools/offcputime'", TraceToolCheckCommand(TRACE_TOOL_OFFCPU))
+	for _, tool := range TRACE_TOOLS {
+		assert.NotEmpty(TRACE_TOOL_COMMANDS[tool])
+	}
+}
+
+func TestTraceImage(t *testing.T) {
+	assert := assert.New(t)
+	inspected := "opencurvedocker/curvefs:latest\n"
+	assert.Equal("opencurvedocker/curvefs:latest", traceImage(TraceOptions{}, &inspected))
+	assert.Equal("perf:latest", traceImage(TraceOptions{Image: "perf:latest"}, &inspected))
+}
+
+func TestClientBinary(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(CLIENT_FUSE_BINARY, ClientBinary(topology.KIND_CURVEFS))
+	assert.Equal(CLIENT_NEBD_BINARY, ClientBinary(topology.KIND_CURVEBS))
+}
@@ -0,0 +1,348 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	FILESYSTEM_ACTION_LIST   = "list"
+	FILESYSTEM_ACTION_CREATE = "create"
+	FILESYSTEM_ACTION_QUOTA  = "quota"
+	FILESYSTEM_ACTION_DELETE = "delete"
+	FILESYSTEM_ACTION_USAGE  = "usage"
+
+	FILESYSTEM_TYPE_S3     = "s3"
+	FILESYSTEM_TYPE_VOLUME = "volume"
+	FILESYSTEM_TYPE_HYBRID = "hybrid"
+)
+
+type (
+	FilesystemOptions struct {
+		Action        string
+		Name          string
+		Type          string
+		Capacity      uint64 // bytes, 0 means unlimited
+		BlockSize     uint64 // bytes
+		S3AccessKey   string
+		S3SecretKey   string
+		S3Address     string
+		S3BucketName  string
+		QuotaCapacity uint64 // GiB
+		QuotaInodes   uint64
+	}
+
+	Filesystem struct {
+		Id          string
+		Name        string
+		Status      string
+		Type        string
+		Capacity    uint64
+		BlockSize   uint64
+		Bucket      string
+		MountNum    int
+		MountPoints []string
+	}
+
+	// columns are same as the table printed by `curve fs quota get`
+	FilesystemUsage struct {
+		Name    string
+		Columns []string
+		Values  []string
+	}
+
+	step2ParseFilesystemOutput struct {
+		action     string
+		name       string
+		output     *string
+		memStorage *utils.SafeMap
+	}
+)
+
+// the S3 info is passed by file, which will be removed by script after read
+func getFilesystemArgs(options FilesystemOptions, s3InfoPath string) []string {
+	args := []string{options.Action}
+	switch options.Action {
+	case FILESYSTEM_ACTION_CREATE:
+		args = append(args, options.Name, options.Type,
+			strconv.FormatUint(options.Capacity, 10),
+			strconv.FormatUint(options.BlockSize, 10))
+		if options.Type == FILESYSTEM_TYPE_S3 {
+			args = append(args, s3InfoPath)
+		}
+	case FILESYSTEM_ACTION_QUOTA:
+		args = append(args, options.Name,
+			strconv.FormatUint(options.QuotaCapacity, 10),
+			strconv.FormatUint(options.QuotaInodes, 10))
+	case FILESYSTEM_ACTION_DELETE,
+		FILESYSTEM_ACTION_USAGE:
+		args = append(args, options.Name)
+	}
+	return args
+}
+
+// one item per line: access key, secret key, address, bucket name
+func getS3Info(options FilesystemOptions) string {
+	return strings.Join([]string{
+		options.S3AccessKey,
+		options.S3SecretKey,
+		options.S3Address,
+		options.S3BucketName,
+	}, "\n") + "\n"
+}
+
+func trimQuote(value string) string {
+	return strings.Trim(strings.TrimSpace(value), "\"")
+}
+
+/*
+ * Output Example (curvefs_tool list-fs):
+ * fsInfo {
+ *   fsId: 1
+ *   fsName: "test"
+ *   status: INITED
+ *   rootInodeId: 1
+ *   capacity: 18446744073709551615
+ *   blockSize: 1048576
+ *   mountNum: 1
+ *   mountpoints {
+ *     hostname: "curve-client"
+ *     port: 9000
+ *     path: "/curvefs/client/mnt/data"
+ *   }
+ *   fsType: TYPE_S3
+ *   detail {
+ *     s3Info {
+ *       bucketname: "curvefs"
+ *       ...
+ *     }
+ *   }
+ * }
+ */
+func ParseFilesystems(output string) []Filesystem {
+	filesystems := []Filesystem{}
+	pattern := regexp.MustCompile(`^([A-Za-z_]+):\s*(.*)$`)
+
+	var fs *Filesystem
+	var mountpoint []string
+	depth := 0
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasSuffix(line, "{"):
+			depth++
+			if depth == 1 && strings.HasPrefix(line, "fsInfo") {
+				fs = &Filesystem{MountPoints: []string{}}
+			} else if depth == 2 && strings.HasPrefix(line, "mountpoints") {
+				mountpoint = []string{"", "", ""}
+			}
+			continue
+		case line == "}":
+			if depth == 2 && mountpoint != nil {
+				fs.MountPoints = append(fs.MountPoints, strings.Join(mountpoint, ":"))
+				mountpoint = nil
+			} else if depth == 1 && fs != nil {
+				filesystems = append(filesystems, *fs)
+				fs = nil
+			}
+			depth--
+			continue
+		}
+
+		mu := pattern.FindStringSubmatch(line)
+		if fs == nil || len(mu) == 0 {
+			continue
+		}
+		key, value := mu[1], trimQuote(mu[2])
+		if mountpoint != nil {
+			switch key {
+			case "hostname":
+				mountpoint[0] = value
+			case "port":
+				mountpoint[1] = value
+			case "path":
+				mountpoint[2] = value
+			}
+			continue
+		}
+
+		switch key {
+		case "fsId":
+			fs.Id = value
+		case "fsName":
+			fs.Name = value
+		case "status":
+			fs.Status = value
+		case "capacity":
+			fs.Capacity, _ = strconv.ParseUint(value, 10, 64)
+		case "blockSize":
+			fs.BlockSize, _ = strconv.ParseUint(value, 10, 64)
+		case "mountNum":
+			fs.MountNum, _ = strconv.Atoi(value)
+		case "mountpoints": // old version: mountpoints: "host:port:path"
+			fs.MountPoints = append(fs.MountPoints, value)
+		case "fsType":
+			fs.Type = strings.ToLower(strings.TrimPrefix(value, "TYPE_"))
+		case "bucketname":
+			fs.Bucket = value
+		}
+	}
+
+	return filesystems
+}
+
+/*
+ * Output Example (curve fs quota get):
+ * +----+------+----------+------+-------+--------+-------+--------+
+ * | ID | NAME | CAPACITY | USED | USE%  | INODES | IUSED | IUSE%  |
+ * +----+------+----------+------+-------+--------+-------+--------+
+ * | 1  | test | 100 GiB  | 1GiB | 1     | 1000   | 10    | 1      |
+ * +----+------+----------+------+-------+--------+-------+--------+
+ */
+func ParseFilesystemUsage(name, output string) *FilesystemUsage {
+	usage := &FilesystemUsage{Name: name}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		items := strings.Split(strings.Trim(line, "|"), "|")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		if usage.Columns == nil {
+			usage.Columns = items
+		} else if usage.Values == nil {
+			usage.Values = items
+		}
+	}
+	return usage
+}
+
+func (s *step2ParseFilesystemOutput) Execute(ctx *context.Context) error {
+	switch s.action {
+	case FILESYSTEM_ACTION_LIST:
+		s.memStorage.Set(comm.KEY_ALL_FILESYSTEMS, ParseFilesystems(*s.output))
+	case FILESYSTEM_ACTION_USAGE:
+		s.memStorage.Set(comm.KEY_FILESYSTEM_USAGE, ParseFilesystemUsage(s.name, *s.output))
+	}
+	return nil
+}
+
+func checkManageFilesystemStatus(action string, success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_MANAGE_FILESYSTEM_FAILED.S(*out)
+		} else if action == FILESYSTEM_ACTION_LIST || action == FILESYSTEM_ACTION_USAGE {
+			return nil
+		}
+
+		lines := strings.SplitN(*out, "\n", 2)
+		if lines[0] == scripts.STATUS_OK {
+			return nil
+		}
+		return errno.ERR_MANAGE_FILESYSTEM_FAILED.S(*out)
+	}
+}
+
+// NOTE: the tool commands run in the mds container
+func NewManageFilesystemTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_FILESYSTEM_OPTIONS).(FilesystemOptions)
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	name := utils.Choose(options.Action == FILESYSTEM_ACTION_LIST, "List Filesystems",
+		fmt.Sprintf("%s Filesystem", strings.Title(options.Action)))
+	subname := fmt.Sprintf("host=%s fs=%s containerId=%s",
+		dc.GetHost(), options.Name, tui.TrimContainerId(containerId))
+	t := task.NewTask(name, subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	layout := dc.GetProjectLayout()
+	script := scripts.SCRIPT_FILESYSTEM
+	scriptPath := fmt.Sprintf("%s/filesystem.sh", layout.ToolsBinDir)
+	s3InfoPath := fmt.Sprintf("%s.s3", utils.RandFilename(layout.ToolsBinDir))
+	command := fmt.Sprintf("bash %s %s %s %s", scriptPath,
+		layout.ToolsBinaryPath, layout.ToolsV2BinaryPath,
+		strings.Join(getFilesystemArgs(options, s3InfoPath), " "))
+	t.AddStep(&step.InstallFile{ // install filesystem script
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	if options.Action == FILESYSTEM_ACTION_CREATE && options.Type == FILESYSTEM_TYPE_S3 {
+		s3Info := getS3Info(options)
+		t.AddStep(&step.InstallFile{ // install S3 info, including secret key
+			ContainerId:       &containerId,
+			ContainerDestPath: s3InfoPath,
+			Content:           &s3Info,
+			Secret:            true,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkManageFilesystemStatus(options.Action, &success, &out),
+	})
+	t.AddStep(&step2ParseFilesystemOutput{
+		action:     options.Action,
+		name:       options.Name,
+		output:     &out,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
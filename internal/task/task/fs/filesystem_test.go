@@ -0,0 +1,129 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilesystems(t *testing.T) {
+	assert := assert.New(t)
+
+	filesystems := ParseFilesystems(`
+fsInfo {
+  fsId: 1
+  fsName: "test1"
+  status: INITED
+  rootInodeId: 1
+  capacity: 18446744073709551615
+  blockSize: 1048576
+  mountNum: 1
+  mountpoints {
+    hostname: "curve-client"
+    port: 9000
+    path: "/curvefs/client/mnt/data"
+  }
+  fsType: TYPE_S3
+  detail {
+    s3Info {
+      ak: "ak"
+      bucketname: "curvefs"
+    }
+  }
+}
+fsInfo {
+  fsId: 2
+  fsName: "test2"
+  status: INITED
+  capacity: 107374182400
+  blockSize: 4194304
+  mountNum: 0
+  fsType: TYPE_VOLUME
+}
+`)
+	assert.Equal([]Filesystem{
+		{
+			Id:          "1",
+			Name:        "test1",
+			Status:      "INITED",
+			Type:        "s3",
+			Capacity:    18446744073709551615,
+			BlockSize:   1048576,
+			Bucket:      "curvefs",
+			MountNum:    1,
+			MountPoints: []string{"curve-client:9000:/curvefs/client/mnt/data"},
+		},
+		{
+			Id:          "2",
+			Name:        "test2",
+			Status:      "INITED",
+			Type:        "volume",
+			Capacity:    107374182400,
+			BlockSize:   4194304,
+			MountPoints: []string{},
+		},
+	}, filesystems)
+
+	assert.Len(ParseFilesystems(""), 0)
+}
+
+func TestParseFilesystemUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	usage := ParseFilesystemUsage("test", `
++----+------+----------+------+------+--------+-------+-------+
+| ID | NAME | CAPACITY | USED | USE% | INODES | IUSED | IUSE% |
++----+------+----------+------+------+--------+-------+-------+
+| 1  | test | 100 GiB  | 1GiB | 1    | 1000   | 10    | 1     |
++----+------+----------+------+------+--------+-------+-------+
+`)
+	assert.Equal("test", usage.Name)
+	assert.Equal([]string{"ID", "NAME", "CAPACITY", "USED", "USE%", "INODES", "IUSED", "IUSE%"}, usage.Columns)
+	assert.Equal([]string{"1", "test", "100 GiB", "1GiB", "1", "1000", "10", "1"}, usage.Values)
+}
+
+func TestGetFilesystemArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	options := FilesystemOptions{
+		Action:       FILESYSTEM_ACTION_CREATE,
+		Name:         "test",
+		Type:         FILESYSTEM_TYPE_S3,
+		BlockSize:    1048576,
+		S3AccessKey:  "ak",
+		S3SecretKey:  "s'k",
+		S3Address:    "http://127.0.0.1:9000",
+		S3BucketName: "curvefs",
+	}
+	args := getFilesystemArgs(options, "/tmp/filesystem.s3")
+	assert.Equal([]string{"create", "test", "s3", "0", "1048576", "/tmp/filesystem.s3"}, args)
+	assert.NotContains(strings.Join(args, " "), "s'k")
+	assert.Equal("ak\ns'k\nhttp://127.0.0.1:9000\ncurvefs\n", getS3Info(options))
+
+	options.Type = FILESYSTEM_TYPE_VOLUME
+	assert.Equal([]string{"create", "test", "volume", "0", "1048576"},
+		getFilesystemArgs(options, "/tmp/filesystem.s3"))
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/scripts"
 	"github.com/opencurve/curveadm/internal/task/step"
@@ -65,8 +66,15 @@ type (
 		MountFSName string
 		MountFSType string
 		MountPoint  string
+		Persistent  bool
+		// replace the stale client record which has the same id
+		Replace bool
 	}
 
+	// MountOptionsSet holds mount options for each client configure,
+	// which used to mount multiple filesystems in one playbook
+	MountOptionsSet map[*configure.ClientConfig]MountOptions
+
 	step2InsertClient struct {
 		curveadm    *cli.CurveAdm
 		options     MountOptions
@@ -74,9 +82,12 @@ type (
 		containerId *string
 	}
 
+	// the mount options are saved for re-mounting the stale client
 	AuxInfo struct {
 		FSName     string `json:"fsname"`
+		FSType     string `json:"fstype,omitempty"`
 		MountPoint string `json:"mount_point,"`
+		Persistent bool   `json:"persistent,omitempty"`
 		Config     string `json:"config,omitempty"` // TODO(P1)
 	}
 
@@ -281,15 +292,24 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 
 	auxInfo := &AuxInfo{
 		FSName:     options.MountFSName,
+		FSType:     options.MountFSType,
 		MountPoint: options.MountPoint,
+		Persistent: options.Persistent,
 	}
 	bytes, err := json.Marshal(auxInfo)
 	if err != nil {
 		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
 	}
 
-	err = curveadm.Storage().InsertClient(fsId, config.GetKind(),
-		options.Host, *s.containerId, string(bytes))
+	err = curveadm.Storage().Transaction(func(tx storage.Storage) error {
+		if options.Replace {
+			if err := tx.DeleteClient(fsId); err != nil {
+				return err
+			}
+		}
+		return tx.InsertClient(fsId, config.GetKind(),
+			options.Host, *s.containerId, string(bytes))
+	})
 	if err != nil {
 		return errno.ERR_INSERT_CLIENT_FAILED.E(err)
 	}
@@ -307,8 +327,16 @@ func checkStartContainerStatus(success *bool, out *string) step.LambdaType {
 	}
 }
 
+func loadMountOptions(curveadm *cli.CurveAdm, cc *configure.ClientConfig) MountOptions {
+	v := curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS)
+	if set, ok := v.(MountOptionsSet); ok {
+		return set[cc]
+	}
+	return v.(MountOptions)
+}
+
 func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS).(MountOptions)
+	options := loadMountOptions(curveadm, cc)
 	hc, err := curveadm.GetHost(options.Host)
 	if err != nil {
 		return nil, err
@@ -351,6 +379,7 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 	})
 	t.AddStep(&step.PullImage{
 		Image:       cc.GetContainerImage(),
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.CreateContainer{
@@ -443,6 +472,13 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 		Restart:     comm.POLICY_UNLESS_STOPPED,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	if options.Persistent {
+		t.AddStep(&step.InstallContainerUnit{
+			ContainerName: containerName,
+			Description:   fmt.Sprintf("CurveAdm mount %s to %s", mountFSName, mountPoint),
+			ExecOptions:   curveadm.ExecOptions(),
+		})
+	}
 
 	return t, nil
 
@@ -115,7 +115,10 @@ func (s *step2RemoveContainer) Execute(ctx *context.Context) error {
 }
 
 func NewUmountFSTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS).(MountOptions)
+	options, ok := v.(MountOptions)
+	if !ok {
+		options = curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS).(MountOptions)
+	}
 	fsId := curveadm.GetFilesystemId(options.Host, options.MountPoint)
 	containerId, err := curveadm.Storage().GetClientContainerId(fsId)
 	if err != nil {
@@ -133,6 +136,10 @@ func NewUmountFSTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error)
 
 	// add step to task
 	var status string
+	t.AddStep(&step.RemoveContainerUnit{
+		ContainerName: mountPoint2ContainerName(mountPoint),
+		ExecOptions:   curveadm.ExecOptions(),
+	})
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      "'{{.Status}}'",
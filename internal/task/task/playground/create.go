@@ -109,6 +109,7 @@ func NewCreatePlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundCo
 	})
 	t.AddStep(&step.PullImage{
 		Image:       containerImage,
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: execOptions(curveadm),
 	})
 	t.AddStep(&step.CreateContainer{
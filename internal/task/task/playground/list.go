@@ -24,6 +24,7 @@ package playground
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -63,9 +64,17 @@ func setPlaygroundStatus(memStorage *utils.SafeMap, id string, status Playground
 }
 
 func (s *step2FormatPlaygroundStatus) Execute(ctx *context.Context) error {
-	status := *s.status
+	status := strings.TrimSpace(*s.status)
 	if len(status) == 0 { // container losed
 		status = comm.PLAYGROUDN_STATUS_LOSED
+	} else if lines := strings.Split(status, "\n"); len(lines) > 1 { // multi-node
+		up := 0
+		for _, line := range lines {
+			if strings.HasPrefix(line, "Up") {
+				up++
+			}
+		}
+		status = fmt.Sprintf("%d nodes, %d up", len(lines), up)
 	}
 
 	playground := s.playground
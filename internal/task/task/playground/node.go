@@ -0,0 +1,319 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package playground
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/playground/script"
+	"github.com/opencurve/curveadm/pkg/module"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	PLAYGROUND_DIR          = "playground"
+	PLAYGROUND_PRIVATE_KEY  = "id_rsa"
+	PLAYGROUND_NODE_SCRIPT  = "/playground-node.sh"
+	PLAYGROUND_SSH_KEY_BITS = 2048
+)
+
+type (
+	step2GenerateSSHKey struct {
+		keyPath   string
+		publicKey *string
+	}
+
+	step2InitNode struct {
+		containerId string
+		publicKey   *string
+		execOptions module.ExecOptions
+	}
+
+	step2RegisterNodes struct {
+		curveadm  *cli.CurveAdm
+		cfg       *configure.PlaygroundConfig
+		addresses map[string]*string
+	}
+
+	step2UnregisterNodes struct {
+		curveadm *cli.CurveAdm
+		keyPath  string
+	}
+
+	step2RemoveNodes struct {
+		containerIds *string
+		network      string
+		curveadm     *cli.CurveAdm
+	}
+
+	step2CleanPlayground struct {
+		curveadm   *cli.CurveAdm
+		playground storage.Playground
+	}
+)
+
+// the ssh key of multi-node playground also marks the playground is multi-node
+func GetPrivateKeyFile(curveadm *cli.CurveAdm, name string) string {
+	return path.Join(curveadm.DataDir(), PLAYGROUND_DIR, name, PLAYGROUND_PRIVATE_KEY)
+}
+
+func IsMultiNode(curveadm *cli.CurveAdm, name string) bool {
+	_, err := os.Stat(GetPrivateKeyFile(curveadm, name))
+	return err == nil
+}
+
+func (s *step2GenerateSSHKey) Execute(ctx *context.Context) error {
+	key, err := rsa.GenerateKey(rand.Reader, PLAYGROUND_SSH_KEY_BITS)
+	if err != nil {
+		return errno.ERR_GENERATE_PLAYGROUND_SSH_KEY_FAILED.E(err)
+	}
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return errno.ERR_GENERATE_PLAYGROUND_SSH_KEY_FAILED.E(err)
+	}
+
+	err = os.MkdirAll(path.Dir(s.keyPath), 0700)
+	if err != nil {
+		return errno.ERR_GENERATE_PLAYGROUND_SSH_KEY_FAILED.E(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	err = os.WriteFile(s.keyPath, data, 0600)
+	if err != nil {
+		return errno.ERR_GENERATE_PLAYGROUND_SSH_KEY_FAILED.E(err)
+	}
+	*s.publicKey = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	return nil
+}
+
+func (s *step2InitNode) Execute(ctx *context.Context) error {
+	command := fmt.Sprintf("/bin/sh %s '%s'", PLAYGROUND_NODE_SCRIPT, *s.publicKey)
+	dockerCli := ctx.Module().DockerCli().ContainerExec(s.containerId, command)
+	out, err := dockerCli.Execute(s.execOptions)
+	if err != nil {
+		return errno.ERR_INIT_PLAYGROUND_NODE_FAILED.
+			F("container: %s", s.containerId).S(out)
+	}
+	return nil
+}
+
+func (s *step2RegisterNodes) Execute(ctx *context.Context) error {
+	cfg := s.cfg
+	items := []map[string]interface{}{}
+	for _, node := range cfg.GetNodes() {
+		items = append(items, map[string]interface{}{
+			"host":             node,
+			"hostname":         strings.TrimSpace(*s.addresses[node]),
+			"user":             "root",
+			"ssh_port":         22,
+			"private_key_file": cfg.GetPrivateKeyFile(),
+		})
+	}
+
+	data, err := hosts.AppendHosts(s.curveadm.Hosts(), items)
+	if err != nil {
+		return err
+	} else if _, err = hosts.ParseHosts(data); err != nil {
+		return err
+	}
+	err = s.curveadm.Storage().SetHosts(data)
+	if err != nil {
+		return errno.ERR_UPDATE_HOSTS_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *step2UnregisterNodes) Execute(ctx *context.Context) error {
+	data, err := hosts.RemoveHosts(s.curveadm.Hosts(), func(item map[string]interface{}) bool {
+		return item["private_key_file"] == s.keyPath
+	})
+	if err != nil {
+		return err
+	}
+	err = s.curveadm.Storage().SetHosts(data)
+	if err != nil {
+		return errno.ERR_UPDATE_HOSTS_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *step2RemoveNodes) Execute(ctx *context.Context) error {
+	options := execOptions(s.curveadm)
+	for _, containerId := range strings.Fields(*s.containerIds) {
+		steps := []task.Step{
+			&step.StopContainer{ContainerId: containerId, ExecOptions: options},
+			&step.RemoveContainer{ContainerId: containerId, ExecOptions: options},
+		}
+		for _, step := range steps {
+			if err := step.Execute(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	// ignore error if network already removed
+	command := fmt.Sprintf("%s network rm %s", options.ExecWithEngine, s.network)
+	ctx.Module().Shell().Command(command).Execute(options)
+	return nil
+}
+
+// remove the cluster and ssh key which created by playground
+func (s *step2CleanPlayground) Execute(ctx *context.Context) error {
+	name := s.playground.Name
+	err := s.curveadm.Storage().DeleteCluster(name)
+	if err != nil {
+		return errno.ERR_DELETE_CLUSTER_FAILED.E(err)
+	}
+	err = os.RemoveAll(path.Dir(GetPrivateKeyFile(s.curveadm, name)))
+	if err != nil {
+		return errno.ERR_REMOVE_FILES_OR_DIRECTORIES_FAILED.E(err)
+	}
+	return nil
+}
+
+func NewCreatePlaygroundNodesTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) (*task.Task, error) {
+	kind := cfg.GetKind()
+	name := cfg.GetName()
+	image := cfg.GetNodeImage()
+
+	// new task
+	subname := fmt.Sprintf("kind=%s name=%s nodes=%d image=%s", kind, name, len(cfg.GetNodes()), image)
+	t := task.NewTask("Create Playground Nodes", subname, nil)
+
+	// add step to task
+	var publicKey string
+	addresses := map[string]*string{}
+	options := execOptions(curveadm)
+	t.AddStep(&step2GenerateSSHKey{
+		keyPath:   cfg.GetPrivateKeyFile(),
+		publicKey: &publicKey,
+	})
+	t.AddStep(&step.PullImage{
+		Image:       image,
+		Mirror:      curveadm.Config().GetRegistryMirror(),
+		ExecOptions: options,
+	})
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("%s network create %s", options.ExecWithEngine, cfg.GetNetwork()),
+		ExecOptions: options,
+	})
+	for _, node := range cfg.GetNodes() {
+		var containerId, address string
+		containerName := cfg.GetNodeContainerName(node)
+		addresses[node] = &address
+		t.AddStep(&step.CreateContainer{
+			Image:       image,
+			Name:        containerName,
+			Hostname:    node,
+			Network:     cfg.GetNetwork(),
+			Volumes:     []step.Volume{{HostPath: "/lib/modules", ContainerPath: "/lib/modules"}},
+			Privileged:  true,
+			Out:         &containerId,
+			ExecOptions: options,
+		})
+		t.AddStep(&step.StartContainer{
+			ContainerId: &containerId,
+			ExecOptions: options,
+		})
+		t.AddStep(&step.InstallFile{ // install node.sh
+			ContainerId:       &containerName,
+			ContainerDestPath: PLAYGROUND_NODE_SCRIPT,
+			Content:           &script.NODE,
+			ExecOptions:       options,
+		})
+		t.AddStep(&step2InitNode{
+			containerId: containerName,
+			publicKey:   &publicKey,
+			execOptions: options,
+		})
+		t.AddStep(&step.InspectContainer{
+			ContainerId: containerName,
+			Format:      "'{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}'",
+			Out:         &address,
+			ExecOptions: options,
+		})
+	}
+	t.AddStep(&step2RegisterNodes{
+		curveadm:  curveadm,
+		cfg:       cfg,
+		addresses: addresses,
+	})
+	t.AddStep(&step2InsertPlayGround{
+		curveadm: curveadm,
+		cfg:      cfg,
+	})
+
+	return t, nil
+}
+
+func newRemovePlaygroundNodesTask(curveadm *cli.CurveAdm, playground storage.Playground) (*task.Task, error) {
+	// new task
+	subname := fmt.Sprintf("name=%s", playground.Name)
+	t := task.NewTask("Remove Playground", subname, nil)
+
+	// add step to task
+	var containerIds string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.ID}}'",
+		Filter:      fmt.Sprintf("network=%s", playground.Name),
+		Out:         &containerIds,
+		ExecOptions: execOptions(curveadm),
+	})
+	t.AddStep(&step2RemoveNodes{
+		containerIds: &containerIds,
+		network:      playground.Name,
+		curveadm:     curveadm,
+	})
+	t.AddStep(&step2UnregisterNodes{
+		curveadm: curveadm,
+		keyPath:  GetPrivateKeyFile(curveadm, playground.Name),
+	})
+	t.AddStep(&step2CleanPlayground{
+		curveadm:   curveadm,
+		playground: playground,
+	})
+	t.AddStep(&step2DeletePlayground{
+		plaground: playground,
+		curveadm:  curveadm,
+	})
+
+	return t, nil
+}
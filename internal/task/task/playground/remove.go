@@ -94,6 +94,9 @@ func (s *step2DeletePlayground) Execute(ctx *context.Context) error {
 func NewRemovePlaygroundTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 	// new task
 	playground := v.(storage.Playground)
+	if IsMultiNode(curveadm, playground.Name) {
+		return newRemovePlaygroundNodesTask(curveadm, playground)
+	}
 	subname := fmt.Sprintf("name=%s", playground.Name)
 	t := task.NewTask("Remove Playground", subname, nil)
 
@@ -0,0 +1,48 @@
+#!/bin/sh
+
+# Usage: node.sh PUBLIC_KEY
+# Prepare the playground node which acts as a host:
+#   1) install sshd/bash/sudo iff not exist
+#   2) authorize the public key of curveadm for root
+#   3) wait the container engine in node ready
+
+g_public_key="$1"
+
+install_packages() {
+    if command -v sshd >/dev/null 2>&1 && command -v bash >/dev/null 2>&1 && \
+       command -v sudo >/dev/null 2>&1; then
+        return 0
+    fi
+
+    if command -v apk >/dev/null 2>&1; then
+        apk add --no-cache openssh bash sudo
+    elif command -v apt-get >/dev/null 2>&1; then
+        apt-get update && apt-get install -y openssh-server sudo
+    else
+        echo "unsupport package manager"
+        return 1
+    fi
+}
+
+start_sshd() {
+    mkdir -p /root/.ssh /run/sshd
+    chmod 700 /root/.ssh
+    echo "${g_public_key}" > /root/.ssh/authorized_keys
+    chmod 600 /root/.ssh/authorized_keys
+    # the locked root account can't login even with public key
+    sed -i 's/^root:!/root:*/' /etc/shadow
+    ssh-keygen -A && $(command -v sshd)
+}
+
+wait_engine() {
+    for i in $(seq 1 60); do
+        if docker info >/dev/null 2>&1; then
+            return 0
+        fi
+        sleep 1
+    done
+    echo "container engine in node is not ready"
+    return 1
+}
+
+install_packages && start_sshd && wait_engine
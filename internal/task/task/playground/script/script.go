@@ -35,4 +35,13 @@ var (
 
 	//go:embed entrypoint.sh
 	ENTRYPOINT string
+
+	//go:embed node.sh
+	NODE string
+
+	//go:embed cluster-curvebs.yaml
+	CLUSTER_CURVEBS string
+
+	//go:embed cluster-curvefs.yaml
+	CLUSTER_CURVEFS string
 )
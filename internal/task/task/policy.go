@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package task
+
+import (
+	"time"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+)
+
+type (
+	// Policy decides how the step executed, only the step which is safe to
+	// execute again (idempotent) should declare retries.
+	Policy struct {
+		Retries    int           // retry times after the first attempt failed
+		Backoff    time.Duration // wait time before the first retry, doubled after each retry
+		MaxBackoff time.Duration // upper limit of backoff, 0 means no limit
+		Timeout    time.Duration // timeout of every command in step, 0 means the global one (ExecTimeoutSec)
+	}
+
+	// PolicyOverride overrides the policy declared by steps, only the fields
+	// which set (non-nil) take effect, e.g. Retries=0 disables the retry
+	PolicyOverride struct {
+		Retries    *int
+		Backoff    *time.Duration
+		MaxBackoff *time.Duration
+		Timeout    *time.Duration
+	}
+
+	// PolicyStep is implemented by the step which declares its own policy
+	PolicyStep interface {
+		Step
+		Policy() Policy
+	}
+
+	// steps which executed and retried together, e.g. execute a command
+	// and check its output
+	stepGroup struct {
+		steps  []Step
+		policy Policy
+	}
+)
+
+func (p Policy) IsZero() bool {
+	return p == Policy{}
+}
+
+func (o PolicyOverride) IsZero() bool {
+	return o == PolicyOverride{}
+}
+
+// override the fields of policy by the fields which set in override
+func (p Policy) Merge(o PolicyOverride) Policy {
+	if o.Retries != nil {
+		p.Retries = *o.Retries
+	}
+	if o.Backoff != nil {
+		p.Backoff = *o.Backoff
+	}
+	if o.MaxBackoff != nil {
+		p.MaxBackoff = *o.MaxBackoff
+	}
+	if o.Timeout != nil {
+		p.Timeout = *o.Timeout
+	}
+	return p
+}
+
+// e.g. backoff=1s, maxBackoff=5s: 1s, 2s, 4s, 5s, 5s...
+func (p Policy) backoff(attempt int) time.Duration {
+	backoff := p.Backoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+func (g *stepGroup) Policy() Policy {
+	return g.policy
+}
+
+func (g *stepGroup) Execute(ctx *context.Context) error {
+	lc := ctx.Module().LogContext()
+	for _, step := range g.steps {
+		lc.Step = stepName(step)
+		if err := step.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -26,10 +26,14 @@ package task
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
 	"github.com/opencurve/curveadm/pkg/module"
 )
 
@@ -51,6 +55,8 @@ type (
 		steps     []Step
 		postSteps []Step
 		sshConfig *module.SSHConfig
+		policy    PolicyOverride // overrides the policy declared by steps
+		cid       int64          // correlation id, it equals to the audit id of operation
 	}
 )
 
@@ -62,6 +68,7 @@ func NewTask(name, subname string, sshConfig *module.SSHConfig) *Task {
 		name:      name,
 		subname:   subname,
 		sshConfig: sshConfig,
+		cid:       -1,
 	}
 }
 
@@ -93,6 +100,11 @@ func (t *Task) SetSubname(name string) {
 	t.subname = name
 }
 
+// SetCorrelationId correlates all log lines of task with the operation
+func (t *Task) SetCorrelationId(id int64) {
+	t.cid = id
+}
+
 func (t *Task) AddStep(step Step) {
 	t.steps = append(t.steps, step)
 }
@@ -101,8 +113,72 @@ func (t *Task) AddPostStep(step Step) {
 	t.postSteps = append(t.postSteps, step)
 }
 
+// AddStepWithPolicy adds steps which executed and retried together as one step
+func (t *Task) AddStepWithPolicy(policy Policy, steps ...Step) {
+	t.steps = append(t.steps, &stepGroup{steps: steps, policy: policy})
+}
+
+// SetPolicy overrides the policy of steps which declared one,
+// the other steps are never retried for they may be not idempotent
+func (t *Task) SetPolicy(policy PolicyOverride) {
+	t.policy = policy
+}
+
+// step name is its type name, e.g. step.PullImage
+func stepName(step Step) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", step), "*")
+}
+
+func (t *Task) stepPolicy(step Step) Policy {
+	if s, ok := step.(PolicyStep); ok {
+		return s.Policy().Merge(t.policy)
+	}
+	return Policy{}
+}
+
+// execute step with its policy, every attempt of step which declared a policy is logged
+func (t *Task) executeStep(ctx *context.Context, step Step) error {
+	policy := t.stepPolicy(step)
+	if policy.IsZero() {
+		return step.Execute(ctx)
+	}
+
+	lc := ctx.Module().LogContext()
+	ctx.Module().SetTimeout(policy.Timeout)
+	defer ctx.Module().SetTimeout(0)
+	for attempt := 1; ; attempt++ {
+		err := step.Execute(ctx)
+		if err == ERR_TASK_DONE || err == ERR_SKIP_TASK {
+			return err
+		}
+
+		retry := err != nil && attempt <= policy.Retries
+		backoff := time.Duration(0)
+		if retry {
+			backoff = policy.backoff(attempt)
+		}
+		log.SwitchLevel(err)("Execute step",
+			log.Field("tid", lc.Tid),
+			log.Field("ptid", lc.Ptid),
+			log.Field("host", lc.Host),
+			log.Field("task", lc.Task),
+			log.Field("step", lc.Step),
+			log.CorrelationField(lc.CorrelationId),
+			log.Field("attempt", fmt.Sprintf("%d/%d", attempt, policy.Retries+1)),
+			log.Field("timeout", policy.Timeout.String()),
+			log.Field("backoff", backoff.String()),
+			log.Field("error", err))
+		if !retry {
+			return err
+		}
+		time.Sleep(backoff)
+	}
+}
+
 func (t *Task) executePost(ctx *context.Context) {
+	lc := ctx.Module().LogContext()
 	for _, step := range t.postSteps {
+		lc.Step = stepName(step)
 		err := step.Execute(ctx)
 		if err != nil {
 			return
@@ -110,7 +186,22 @@ func (t *Task) executePost(ctx *context.Context) {
 	}
 }
 
-func (t *Task) Execute() error {
+func (t *Task) Execute() (err error) {
+	host := "-"
+	if t.sshConfig != nil {
+		host = t.sshConfig.Host
+	}
+	defer func() {
+		log.SwitchLevel(err)("Execute task",
+			log.Field("tid", t.tid),
+			log.Field("ptid", t.ptid),
+			log.Field("host", host),
+			log.Field("task", t.name),
+			log.Field("subname", t.subname),
+			log.CorrelationField(t.cid),
+			log.Field("error", err))
+	}()
+
 	var sshClient *module.SSHClient
 	if t.sshConfig != nil {
 		client, err := module.NewSSHClient(*t.sshConfig)
@@ -127,8 +218,12 @@ func (t *Task) Execute() error {
 	defer ctx.Close()
 	defer t.executePost(ctx)
 
+	lc := ctx.Module().LogContext()
+	lc.Tid, lc.Ptid, lc.Host, lc.Task = t.tid, t.ptid, host, t.name
+	lc.CorrelationId = t.cid
 	for _, step := range t.steps {
-		err := step.Execute(ctx)
+		lc.Step = stepName(step)
+		err := t.executeStep(ctx, step)
 		if err == ERR_TASK_DONE {
 			break
 		} else if err != nil {
@@ -0,0 +1,165 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransient = errors.New("transient error")
+
+// fails for the first n attempts
+type flakyStep struct {
+	failures int
+	attempts int
+}
+
+func (s *flakyStep) Execute(ctx *context.Context) error {
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errTransient
+	}
+	return nil
+}
+
+type retryableStep struct {
+	flakyStep
+	policy Policy
+}
+
+func (s *retryableStep) Policy() Policy {
+	return s.policy
+}
+
+type commandStep struct {
+	command string
+}
+
+func (s *commandStep) Execute(ctx *context.Context) error {
+	_, err := ctx.Module().Shell().Command(s.command).
+		Execute(module.ExecOptions{ExecInLocal: true, ExecTimeoutSec: 10})
+	return err
+}
+
+func (s *commandStep) Policy() Policy {
+	return Policy{Timeout: time.Second}
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	assert := assert.New(t)
+	policy := Policy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	for i, expect := range []time.Duration{1, 2, 4, 5, 5} {
+		assert.Equal(expect*time.Second, policy.backoff(i+1))
+	}
+
+	policy = Policy{Backoff: time.Second}
+	assert.Equal(8*time.Second, policy.backoff(4))
+}
+
+func TestPolicy_Merge(t *testing.T) {
+	assert := assert.New(t)
+	retries, timeout := 5, time.Minute
+	policy := Policy{Retries: 3, Backoff: time.Second}.Merge(PolicyOverride{Retries: &retries, Timeout: &timeout})
+	assert.Equal(Policy{Retries: 5, Backoff: time.Second, Timeout: time.Minute}, policy)
+	assert.True(Policy{}.IsZero())
+
+	// zero value which set explicitly also overrides
+	retries = 0
+	policy = Policy{Retries: 3, Backoff: time.Second}.Merge(PolicyOverride{Retries: &retries})
+	assert.Equal(Policy{Retries: 0, Backoff: time.Second}, policy)
+	assert.Equal(Policy{Retries: 3}, Policy{Retries: 3}.Merge(PolicyOverride{}))
+	assert.True(PolicyOverride{}.IsZero())
+}
+
+func TestTask_RetryStep(t *testing.T) {
+	assert := assert.New(t)
+
+	// retry until success
+	s := &retryableStep{flakyStep{failures: 2}, Policy{Retries: 3, Backoff: time.Millisecond}}
+	task := NewTask("test", "", nil)
+	task.AddStep(s)
+	assert.Nil(task.Execute())
+	assert.Equal(3, s.attempts)
+
+	// retries exhausted
+	s = &retryableStep{flakyStep{failures: 5}, Policy{Retries: 2, Backoff: time.Millisecond}}
+	task = NewTask("test", "", nil)
+	task.AddStep(s)
+	assert.Equal(errTransient, task.Execute())
+	assert.Equal(3, s.attempts)
+
+	// overridden by task
+	retries := 3
+	s = &retryableStep{flakyStep{failures: 3}, Policy{Retries: 1, Backoff: time.Millisecond}}
+	task = NewTask("test", "", nil)
+	task.AddStep(s)
+	task.SetPolicy(PolicyOverride{Retries: &retries})
+	assert.Nil(task.Execute())
+	assert.Equal(4, s.attempts)
+
+	// retry disabled by task
+	retries = 0
+	s = &retryableStep{flakyStep{failures: 1}, Policy{Retries: 3, Backoff: time.Millisecond}}
+	task = NewTask("test", "", nil)
+	task.AddStep(s)
+	task.SetPolicy(PolicyOverride{Retries: &retries})
+	assert.Equal(errTransient, task.Execute())
+	assert.Equal(1, s.attempts)
+}
+
+func TestTask_NeverRetryStepWithoutPolicy(t *testing.T) {
+	assert := assert.New(t)
+	s := &flakyStep{failures: 1}
+	task := NewTask("test", "", nil)
+	task.AddStep(s)
+	retries := 3
+	task.SetPolicy(PolicyOverride{Retries: &retries})
+	assert.Equal(errTransient, task.Execute())
+	assert.Equal(1, s.attempts)
+}
+
+func TestTask_RetryStepGroup(t *testing.T) {
+	assert := assert.New(t)
+	s1 := &flakyStep{}
+	s2 := &flakyStep{failures: 1}
+	task := NewTask("test", "", nil)
+	task.AddStepWithPolicy(Policy{Retries: 1, Backoff: time.Millisecond}, s1, s2)
+	assert.Nil(task.Execute())
+	assert.Equal(2, s1.attempts)
+	assert.Equal(2, s2.attempts)
+}
+
+func TestTask_StepTimeout(t *testing.T) {
+	assert := assert.New(t)
+	task := NewTask("test", "", nil)
+	task.AddStep(&commandStep{command: "sleep 3"})
+	err := task.Execute()
+	assert.IsType(&module.TimeoutError{}, err)
+	assert.Contains(err.Error(), "timeout: 1 seconds")
+}
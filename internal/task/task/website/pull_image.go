@@ -45,6 +45,7 @@ func NewPullImageTask(curveadm *cli.CurveAdm, cfg *configure.WebsiteConfig) (*ta
 	// add step to task
 	t.AddStep(&step.PullImage{
 		Image:       image,
+		Mirror:      curveadm.Config().GetRegistryMirror(),
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	return t, nil
@@ -38,10 +38,12 @@ import (
 
 type (
 	ExecOptions struct {
-		Concurrency   uint
-		SilentMainBar bool
-		SilentSubBar  bool
-		SkipError     bool
+		Concurrency     uint
+		SilentMainBar   bool
+		SilentSubBar    bool
+		SkipError       bool
+		ContinueOnError bool                // execute next playbook step even if this one failed
+		Policy          task.PolicyOverride // overrides the policy of steps which declared one
 	}
 
 	Tasks struct {
@@ -245,6 +247,9 @@ func (ts *Tasks) Execute(options ExecOptions) error {
 			if bar != nil {
 				id = bar.ID()
 			}
+			if !options.Policy.IsZero() {
+				t.SetPolicy(options.Policy)
+			}
 			err := t.Execute()
 			ts.monitor.set(id, err)
 		}(t)
@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransient = errors.New("transient error")
+
+// fails for the first n attempts, and declares retries
+type flakyStep struct {
+	failures int
+	attempts int
+}
+
+func (s *flakyStep) Execute(ctx *context.Context) error {
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errTransient
+	}
+	return nil
+}
+
+func (s *flakyStep) Policy() task.Policy {
+	return task.Policy{Retries: 2, Backoff: time.Millisecond}
+}
+
+func TestTasks_ExecuteWithPolicy(t *testing.T) {
+	assert := assert.New(t)
+	execute := func(s *flakyStep, policy task.PolicyOverride) error {
+		t := task.NewTask("test", "", nil)
+		t.AddStep(s)
+		ts := NewTasks()
+		ts.AddTask(t)
+		return ts.Execute(ExecOptions{
+			SilentMainBar: true,
+			SilentSubBar:  true,
+			Policy:        policy,
+		})
+	}
+
+	// retried by the policy declared by step
+	s := &flakyStep{failures: 1}
+	assert.Nil(execute(s, task.PolicyOverride{}))
+	assert.Equal(2, s.attempts)
+
+	// retry disabled by execute options
+	retries := 0
+	s = &flakyStep{failures: 1}
+	assert.Equal(errTransient, execute(s, task.PolicyOverride{Retries: &retries}))
+	assert.Equal(1, s.attempts)
+}
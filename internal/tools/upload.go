@@ -1,55 +0,0 @@
-/*
- *  Copyright (c) 2021 NetEase Inc.
- *
- *  Licensed under the Apache License, Version 2.0 (the "License");
- *  you may not use this file except in compliance with the License.
- *  You may obtain a copy of the License at
- *
- *      http://www.apache.org/licenses/LICENSE-2.0
- *
- *  Unless required by applicable law or agreed to in writing, software
- *  distributed under the License is distributed on an "AS IS" BASIS,
- *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
- *  See the License for the specific language governing permissions and
- *  limitations under the License.
- */
-
-/*
- * Project: CurveAdm
- * Created Date: 2021-11-26
- * Author: Jingli Chen (Wine93)
- */
-
-// __SIGN_BY_WINE93__
-
-package tools
-
-import (
-	"fmt"
-	"os"
-
-	"github.com/go-resty/resty/v2"
-)
-
-const (
-	URL_UPLOAD_SUPPORT_TARBALL = "http://curveadm.aspirer.wang:19301/upload?path=/"
-)
-
-func Upload(filepath string) error {
-	file, err := os.Open(filepath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	client := resty.New()
-	resp, err := client.R().
-		SetFile(filepath, filepath).
-		Post(URL_UPLOAD_SUPPORT_TARBALL)
-	if err != nil {
-		return err
-	} else if resp.StatusCode() != 200 {
-		return fmt.Errorf("upload failed, return %d", resp.StatusCode())
-	}
-	return nil
-}
@@ -23,7 +23,12 @@
 package tui
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/fatih/color"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -55,10 +60,49 @@ func statusDecorate(message string) string {
 	return message
 }
 
-func FormatAuditLogs(auditLogs []storage.AuditLog, verbose bool) string {
+func AuditStatus(status int) string {
+	if v, ok := code2str[status]; ok {
+		return v
+	}
+	return "UNKNOWN"
+}
+
+func auditCluster(auditLog storage.AuditLog, clusters map[int]string) string {
+	if name, ok := clusters[auditLog.ClusterId]; ok {
+		return name
+	} else if auditLog.ClusterId <= 0 {
+		return "-"
+	}
+	return strconv.Itoa(auditLog.ClusterId)
+}
+
+func auditOperator(auditLog storage.AuditLog) string {
+	operator := auditLog.User
+	if len(operator) == 0 {
+		operator = "-"
+	}
+	if len(auditLog.Client) > 0 {
+		operator = fmt.Sprintf("%s@%s", operator, auditLog.Client)
+	}
+	return operator
+}
+
+// e.g. 3 -> 4
+func auditRevision(auditLog storage.AuditLog) string {
+	if auditLog.RevisionBefore == auditLog.RevisionAfter {
+		return strconv.Itoa(auditLog.RevisionBefore)
+	}
+	return fmt.Sprintf("%d -> %d", auditLog.RevisionBefore, auditLog.RevisionAfter)
+}
+
+func FormatAuditLogs(auditLogs []storage.AuditLog, clusters map[int]string, verbose bool) string {
 	lines := [][]interface{}{}
 	title := []string{"Id", "Status", "Execute Time", "Command"}
 	if verbose {
+		title = append(title, "Operator")
+		title = append(title, "Cluster")
+		title = append(title, "Revision")
+		title = append(title, "Duration")
 		title = append(title, "Work Directory")
 		title = append(title, "Error Code")
 	}
@@ -73,10 +117,7 @@ func FormatAuditLogs(auditLogs []storage.AuditLog, verbose bool) string {
 		// id
 		line = append(line, strconv.Itoa(auditLog.Id))
 		// status
-		status := "UNKNOWN"
-		if v, ok := code2str[auditLog.Status]; ok {
-			status = v
-		}
+		status := AuditStatus(auditLog.Status)
 		line = append(line, tuicommon.DecorateMessage{Message: status, Decorate: statusDecorate})
 		// execute time
 		line = append(line, auditLog.ExecuteTime.Format("2006-01-02 15:04:05"))
@@ -84,6 +125,14 @@ func FormatAuditLogs(auditLogs []storage.AuditLog, verbose bool) string {
 		line = append(line, auditLog.Command)
 
 		if verbose {
+			// operator
+			line = append(line, auditOperator(auditLog))
+			// cluster
+			line = append(line, auditCluster(auditLog, clusters))
+			// topology revision
+			line = append(line, auditRevision(auditLog))
+			// duration
+			line = append(line, auditLog.Duration.Round(time.Millisecond).String())
 			// work directory
 			line = append(line, auditLog.WorkDirectory)
 			// error code
@@ -96,3 +145,83 @@ func FormatAuditLogs(auditLogs []storage.AuditLog, verbose bool) string {
 	output := tuicommon.FixedFormat(lines, 2)
 	return output
 }
+
+type auditRecord struct {
+	Id             int    `json:"id"`
+	ExecuteTime    string `json:"executeTime"`
+	Command        string `json:"command"`
+	Status         string `json:"status"`
+	ErrorCode      int    `json:"errorCode"`
+	User           string `json:"user"`
+	Client         string `json:"client"`
+	Token          string `json:"token"`
+	Cluster        string `json:"cluster"`
+	RevisionBefore int    `json:"revisionBefore"`
+	RevisionAfter  int    `json:"revisionAfter"`
+	Duration       int64  `json:"durationMs"`
+	WorkDirectory  string `json:"workDirectory"`
+}
+
+var (
+	AUDIT_RECORD_FIELDS = []string{
+		"id", "executeTime", "command", "status", "errorCode",
+		"user", "client", "token", "cluster",
+		"revisionBefore", "revisionAfter", "durationMs", "workDirectory",
+	}
+)
+
+func newAuditRecord(auditLog storage.AuditLog, clusters map[int]string) auditRecord {
+	return auditRecord{
+		Id:             auditLog.Id,
+		ExecuteTime:    auditLog.ExecuteTime.Format(time.RFC3339),
+		Command:        auditLog.Command,
+		Status:         AuditStatus(auditLog.Status),
+		ErrorCode:      auditLog.ErrorCode,
+		User:           auditLog.User,
+		Client:         auditLog.Client,
+		Token:          auditLog.Token,
+		Cluster:        auditCluster(auditLog, clusters),
+		RevisionBefore: auditLog.RevisionBefore,
+		RevisionAfter:  auditLog.RevisionAfter,
+		Duration:       auditLog.Duration.Milliseconds(),
+		WorkDirectory:  auditLog.WorkDirectory,
+	}
+}
+
+func FormatAuditLogsJSON(auditLogs []storage.AuditLog, clusters map[int]string) (string, error) {
+	records := []auditRecord{}
+	for _, auditLog := range auditLogs {
+		records = append(records, newAuditRecord(auditLog, clusters))
+	}
+	bytes, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes) + "\n", nil
+}
+
+func FormatAuditLogsCSV(auditLogs []storage.AuditLog, clusters map[int]string) (string, error) {
+	buffer := bytes.NewBufferString("")
+	w := csv.NewWriter(buffer)
+	w.Write(AUDIT_RECORD_FIELDS)
+	for _, auditLog := range auditLogs {
+		record := newAuditRecord(auditLog, clusters)
+		w.Write([]string{
+			strconv.Itoa(record.Id),
+			record.ExecuteTime,
+			record.Command,
+			record.Status,
+			strconv.Itoa(record.ErrorCode),
+			record.User,
+			record.Client,
+			record.Token,
+			record.Cluster,
+			strconv.Itoa(record.RevisionBefore),
+			strconv.Itoa(record.RevisionAfter),
+			strconv.FormatInt(record.Duration, 10),
+			record.WorkDirectory,
+		})
+	}
+	w.Flush()
+	return buffer.String(), w.Error()
+}
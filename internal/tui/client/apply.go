@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package service
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	APPLY_RESULT_OK        = "OK"
+	APPLY_RESULT_FAILED    = "Failed"
+	APPLY_RESULT_PENDING   = "Pending"
+	APPLY_RESULT_UNCHANGED = "Unchanged"
+)
+
+type ApplyItem struct {
+	Action string
+	Kind   string
+	Host   string
+	Name   string // volume or mount point
+	Result string
+}
+
+func resultDecorate(result string) string {
+	switch result {
+	case APPLY_RESULT_OK:
+		return color.GreenString(result)
+	case APPLY_RESULT_FAILED:
+		return color.RedString(result)
+	case APPLY_RESULT_PENDING:
+		return color.YellowString(result)
+	}
+	return result
+}
+
+func sortApplyItems(items []ApplyItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		s1, s2 := items[i], items[j]
+		if s1.Action == s2.Action {
+			return s1.Host < s2.Host
+		}
+		return s1.Action < s2.Action
+	})
+}
+
+func FormatApply(items []ApplyItem) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Action",
+		"Kind",
+		"Host",
+		"Name",
+		"Result",
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// apply items
+	sortApplyItems(items)
+	for _, item := range items {
+		lines = append(lines, []interface{}{
+			item.Action,
+			item.Kind,
+			item.Host,
+			item.Name,
+			tui.DecorateMessage{Message: item.Result, Decorate: resultDecorate},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
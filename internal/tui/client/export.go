@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package service
+
+import (
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func FormatExports(statuses []task.ClientStatus) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Id",
+		"Host",
+		"Volume",
+		"Protocol",
+		"Endpoint",
+		"Container Id",
+		"Status",
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// exports
+	sortStatues(statuses)
+	for _, status := range statuses {
+		volume, protocol, endpoint := "-", "-", "-"
+		auxInfo, err := bs.ParseExportAuxInfo(status.AuxInfo)
+		if err == nil {
+			volume = auxInfo.User + ":" + auxInfo.Volume
+			protocol = auxInfo.Protocol
+			endpoint = auxInfo.Endpoint
+		}
+		lines = append(lines, []interface{}{
+			status.Id,
+			status.Host,
+			volume,
+			protocol,
+			endpoint,
+			tui.TrimContainerId(status.ContainerId),
+			tui.DecorateMessage{Message: status.Status, Decorate: statusDecorate},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package service
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	comm "github.com/opencurve/curveadm/internal/common"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func stateDecorate(state string) string {
+	switch state {
+	case comm.CLIENT_RECONCILE_OK:
+		return color.GreenString(state)
+	case comm.CLIENT_RECONCILE_STOPPED:
+		return color.YellowString(state)
+	case comm.CLIENT_RECONCILE_STALE, comm.CLIENT_RECONCILE_UNRECORDED:
+		return color.RedString(state)
+	}
+	return state
+}
+
+func sortReconciles(items []task.ClientReconcile) {
+	sort.SliceStable(items, func(i, j int) bool {
+		s1, s2 := items[i], items[j]
+		if s1.Kind == s2.Kind {
+			return s1.Host < s2.Host
+		}
+		return s1.Kind < s2.Kind
+	})
+}
+
+func FormatReconcile(items []task.ClientReconcile) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Id",
+		"Kind",
+		"Host",
+		"Container Id",
+		"Status",
+		"State",
+		"Aux Info",
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// reconcile items
+	sortReconciles(items)
+	for _, item := range items {
+		lines = append(lines, []interface{}{
+			item.Id,
+			item.Kind,
+			item.Host,
+			tui.TrimContainerId(item.ContainerId),
+			tui.DecorateMessage{Message: item.Status, Decorate: statusDecorate},
+			tui.DecorateMessage{Message: item.State, Decorate: stateDecorate},
+			item.AuxInfo,
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
@@ -50,11 +50,18 @@ const (
 `
 
 	PROMPT_COLLECT_SERVICE = `FYI:
-  > We will collect service logs for troubleshooting, 
-  > and send these logs to the curve center.
-  > Please don't worry about the data security,
-  > we guarantee that all logs are encrypted
-  > and only you have the secret key.
+  > We will collect service logs for troubleshooting
+  > and save them into a local support bundle.
+  > The secrets in configure files are redacted,
+  > and the bundle is only uploaded to the url or
+  > S3 bucket which you specified.
+`
+
+	PROMPT_REPLAY_AUDIT = `{{.warning}}
+  - Audit id: {{.id}}
+  - Command: {{.command}}
+  - Cluster: {{.cluster}}
+  - Work directory: {{.directory}}
 `
 
 	PROMPT_TOPOLOGY_CHANGE_NOTICE = `
@@ -190,12 +197,40 @@ func PromptCollectService() string {
 	return prompt.Build()
 }
 
+func PromptReplayAudit(id int, command, cluster, directory string) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_REPLAY_AUDIT) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["warning"] = "WARNING: the command of audit log will be executed again"
+	prompt.data["id"] = id
+	prompt.data["command"] = command
+	prompt.data["cluster"] = cluster
+	prompt.data["directory"] = directory
+	return prompt.Build()
+}
+
 func PromptIncrementFormat() string {
 	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
 	prompt.data["warning"] = "WARNING: increment format will stop chunkserver service"
 	return prompt.Build()
 }
 
+func PromptDeleteVolume(volume string, force bool) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
+	if force {
+		prompt.data["warning"] = fmt.Sprintf("WARNING: volume '%s' will be deleted permanently,\n"+
+			"and it can't be restored from recycle bin", volume)
+	} else {
+		prompt.data["warning"] = fmt.Sprintf("WARNING: volume '%s' will be moved into recycle bin", volume)
+	}
+	return prompt.Build()
+}
+
+func PromptDeleteFilesystem(name string) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["warning"] = fmt.Sprintf("WARNING: filesystem '%s' will be deleted,\n"+
+		"and all data in it will be cleaned up", name)
+	return prompt.Build()
+}
+
 func prettyClue(clue string) string {
 	items := strings.Split(clue, "\n")
 	for {
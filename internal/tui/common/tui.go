@@ -31,6 +31,7 @@ import (
 	"strings"
 
 	"github.com/opencurve/curveadm/internal/utils"
+	"golang.org/x/term"
 )
 
 type DecorateMessage struct {
@@ -166,3 +167,13 @@ func ConfirmYes(format string, a ...interface{}) bool {
 		return false
 	}
 }
+
+func PromptPassword(format string, a ...interface{}) string {
+	fmt.Print(fmt.Sprintf(format, a...) + ": ")
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return ""
+	}
+	return string(password)
+}
@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"path"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func FormatCores(cores []task.CoreFile) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Host", "Role", "Service Id", "Binary", "Core File", "Size", "Time"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// newest first
+	sort.Slice(cores, func(i, j int) bool {
+		c1, c2 := cores[i], cores[j]
+		if c1.Time.Equal(c2.Time) {
+			return c1.Id < c2.Id
+		}
+		return c1.Time.After(c2.Time)
+	})
+	for _, core := range cores {
+		binary := "-"
+		if len(core.Binary) > 0 {
+			binary = path.Base(core.Binary)
+		}
+		lines = append(lines, []interface{}{
+			core.Id,
+			core.Host,
+			core.Role,
+			core.ServiceId,
+			binary,
+			core.Path,
+			humanize.IBytes(core.Size),
+			core.Time.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -23,10 +23,15 @@
 package tui
 
 import (
+	"fmt"
 	"sort"
 
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/configure/disks"
 	"github.com/opencurve/curveadm/internal/storage"
 	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 func SortDiskRecords(diskRecords []storage.Disk) {
@@ -67,3 +72,54 @@ func FormatDisks(diskRecords []storage.Disk) string {
 
 	return tuicommon.FixedFormat(lines, 2)
 }
+
+func decorateDiscoverStatus(status string) func(string) string {
+	return func(message string) string {
+		switch status {
+		case disks.DISCOVER_STATUS_AVAILABLE:
+			return color.GreenString(message)
+		case disks.DISCOVER_STATUS_COMMITTED:
+			return color.BlueString(message)
+		}
+		return color.HiBlackString(message)
+	}
+}
+
+func FormatBlockDevices(devices []*disks.BlockDevice) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Host",
+		"Device Path",
+		"Device Size",
+		"Type",
+		"Model",
+		"Serial",
+		"Device URI",
+		"Status",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, bd := range devices {
+		status := bd.Status
+		if len(bd.Reason) > 0 {
+			status = fmt.Sprintf("%s (%s)", bd.Status, bd.Reason)
+		}
+		lines = append(lines, []interface{}{
+			bd.Host,
+			bd.Device,
+			humanize.IBytes(bd.Size),
+			bd.GetDiskType(),
+			utils.Choose(len(bd.Model) > 0, bd.Model, "-"),
+			utils.Choose(len(bd.Serial) > 0, bd.Serial, "-"),
+			bd.GetURI(),
+			tuicommon.DecorateMessage{
+				Message:  status,
+				Decorate: decorateDiscoverStatus(bd.Status),
+			},
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func formatCapacity(capacity uint64) string {
+	if capacity == 0 || capacity == math.MaxUint64 {
+		return "unlimited"
+	}
+	return humanize.IBytes(capacity)
+}
+
+func FormatFilesystems(filesystems []fs.Filesystem) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Name",
+		"Status",
+		"Type",
+		"Capacity",
+		"Block Size",
+		"Bucket",
+		"Mount Num",
+		"Mount Points",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(filesystems, func(i, j int) bool {
+		id1, _ := strconv.Atoi(filesystems[i].Id)
+		id2, _ := strconv.Atoi(filesystems[j].Id)
+		return id1 < id2
+	})
+	for _, fs := range filesystems {
+		mountPoints := "-"
+		if len(fs.MountPoints) > 0 {
+			mountPoints = strings.Join(fs.MountPoints, ",")
+		}
+		bucket := fs.Bucket
+		if len(bucket) == 0 {
+			bucket = "-"
+		}
+		lines = append(lines, []interface{}{
+			fs.Id,
+			fs.Name,
+			fs.Status,
+			fs.Type,
+			formatCapacity(fs.Capacity),
+			humanize.IBytes(fs.BlockSize),
+			bucket,
+			fs.MountNum,
+			mountPoints,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+func FormatFilesystemUsage(usage *fs.FilesystemUsage) string {
+	lines := [][]interface{}{}
+	first, second := tuicommon.FormatTitle(usage.Columns)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	line := []interface{}{}
+	for i := range usage.Columns {
+		value := "-"
+		if i < len(usage.Values) {
+			value = usage.Values[i]
+		}
+		line = append(line, value)
+	}
+	lines = append(lines, line)
+
+	return tuicommon.FixedFormat(lines, 2)
+}
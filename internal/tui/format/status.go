@@ -43,7 +43,7 @@ func FormatStatus(statuses []bs.FormatStatus) string {
 	lines := [][]interface{}{}
 
 	// title
-	title := []string{"Host", "Device", "MountPoint", "Formatted", "Status"}
+	title := []string{"Host", "Device", "MountPoint", "Formatted", "Status", "ETA"}
 	first, second := tui.FormatTitle(title)
 	lines = append(lines, first)
 	lines = append(lines, second)
@@ -57,6 +57,7 @@ func FormatStatus(statuses []bs.FormatStatus) string {
 			status.MountPoint,
 			status.Formatted,
 			status.Status,
+			status.ETA,
 		}
 		lines = append(lines, line)
 	}
@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"strconv"
+
+	"github.com/opencurve/curveadm/internal/storage"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func FormatHistories(histories []storage.History) string {
+	lines := [][]interface{}{}
+	title := []string{" ", "Revision", "Kind", "Author", "Audit Id", "Create Time"}
+	first, second := tuicommon.FormatTitle(title)
+	second[0] = ""
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for i := 0; i < len(histories); i++ {
+		line := []interface{}{}
+		history := histories[i]
+
+		// the latest revision is current configure
+		if i == len(histories)-1 {
+			line = append(line, tuicommon.DecorateMessage{Message: "*", Decorate: currentDecorate})
+		} else {
+			line = append(line, " ")
+		}
+		line = append(line, strconv.Itoa(history.Revision))
+		line = append(line, history.Kind)
+		line = append(line, history.Author)
+		auditId := "-"
+		if history.AuditId >= 0 {
+			auditId = strconv.FormatInt(history.AuditId, 10)
+		}
+		line = append(line, auditId)
+		line = append(line, history.CreateTime.Format("2006-01-02 15:04:05"))
+
+		lines = append(lines, line)
+	}
+
+	output := tuicommon.FixedFormat(lines, 2)
+	return output
+}
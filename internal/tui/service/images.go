@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	comm "github.com/opencurve/curveadm/internal/common"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	SHORT_DIGEST_LENGTH = 12
+)
+
+func imageStatusDecorate(status string) string {
+	switch status {
+	case comm.IMAGE_STATUS_UNPINNED:
+		return color.YellowString(status)
+	case comm.IMAGE_STATUS_MISMATCHED, comm.SERVICE_STATUS_UNKNOWN:
+		return color.RedString(status)
+	}
+	return status
+}
+
+func sortServiceImages(images []task.ServiceImage) {
+	sort.Slice(images, func(i, j int) bool {
+		i1, i2 := images[i], images[j]
+		if i1.Role == i2.Role {
+			if i1.Host == i2.Host {
+				return i1.Id < i2.Id
+			}
+			return i1.Host < i2.Host
+		}
+		return ROLE_SCORE[i1.Role] < ROLE_SCORE[i2.Role]
+	})
+}
+
+// sha256:4b6c25a1e7f4d0... => sha256:4b6c25a1e7f4
+func shortDigest(digest string, verbose bool) string {
+	if len(digest) == 0 {
+		return "-"
+	} else if verbose {
+		return digest
+	}
+
+	items := strings.SplitN(digest, ":", 2)
+	if len(items) == 2 && len(items[1]) > SHORT_DIGEST_LENGTH {
+		return items[0] + ":" + items[1][:SHORT_DIGEST_LENGTH]
+	}
+	return digest
+}
+
+func FormatServiceImages(images []task.ServiceImage, verbose bool) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Id",
+		"Role",
+		"Host",
+		"Image",
+		"Pinned Digest",
+		"Running Image",
+		"Running Digest",
+		"Status",
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// images
+	sortServiceImages(images)
+	for _, image := range images {
+		lines = append(lines, []interface{}{
+			image.Id,
+			image.Role,
+			image.Host,
+			image.Image,
+			shortDigest(image.Pinned, verbose),
+			image.RunningImage,
+			shortDigest(image.RunningDigest, verbose),
+			tui.DecorateMessage{Message: image.Status, Decorate: imageStatusDecorate},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
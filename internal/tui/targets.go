@@ -24,6 +24,7 @@ package tui
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/tui/common"
@@ -37,9 +38,16 @@ func sortTargets(targets []step.Target) {
 	})
 }
 
+func formatTargetItems(items []string, empty string) string {
+	if len(items) == 0 {
+		return empty
+	}
+	return strings.Join(items, ",")
+}
+
 func FormatTargets(targets []step.Target) string {
 	lines := [][]interface{}{}
-	title := []string{"Tid", "Host", "Target Name", "Store", "Portal"}
+	title := []string{"Tid", "Host", "Target Name", "Store", "Portal", "CHAP", "ACL"}
 	first, second := tuicommon.FormatTitle(title)
 	lines = append(lines, first)
 	lines = append(lines, second)
@@ -52,6 +60,8 @@ func FormatTargets(targets []step.Target) string {
 			target.Name,
 			target.Store,
 			target.Portal,
+			formatTargetItems(target.Accounts, "-"),
+			formatTargetItems(target.ACL, "-"),
 		})
 	}
 
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func formatTimestamp(us uint64) string {
+	if us == 0 {
+		return "-"
+	}
+	return time.UnixMicro(int64(us)).Format("2006-01-02 15:04:05")
+}
+
+func formatStatus(status int, names map[int]string) string {
+	if name, ok := names[status]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", status)
+}
+
+func FormatVolumes(volumes []bs.Volume) string {
+	lines := [][]interface{}{}
+	title := []string{"Volume", "Owner", "Size", "Poolset", "Status"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	for _, volume := range volumes {
+		lines = append(lines, []interface{}{
+			volume.Name,
+			volume.Owner,
+			humanize.IBytes(volume.Length),
+			volume.Poolset,
+			volume.Status,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+func FormatSnapshots(snapshots []bs.Snapshot) string {
+	lines := [][]interface{}{}
+	title := []string{"UUID", "User", "Volume", "Snapshot Name", "Size", "Status", "Progress", "Create Time"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		s1, s2 := snapshots[i], snapshots[j]
+		if s1.File == s2.File {
+			return s1.Time < s2.Time
+		}
+		return s1.File < s2.File
+	})
+	for _, snapshot := range snapshots {
+		lines = append(lines, []interface{}{
+			snapshot.UUID,
+			snapshot.User,
+			snapshot.File,
+			snapshot.Name,
+			humanize.IBytes(snapshot.FileLength),
+			formatStatus(snapshot.Status, bs.SNAPSHOT_STATUS),
+			fmt.Sprintf("%d%%", snapshot.Progress),
+			formatTimestamp(snapshot.Time),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+func FormatCloneTasks(clones []bs.CloneTask) string {
+	lines := [][]interface{}{}
+	title := []string{"UUID", "User", "Source", "Destination", "Lazy", "Status", "Progress", "Create Time"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(clones, func(i, j int) bool {
+		return clones[i].Time < clones[j].Time
+	})
+	for _, clone := range clones {
+		lines = append(lines, []interface{}{
+			clone.UUID,
+			clone.User,
+			clone.Src,
+			clone.File,
+			clone.IsLazy,
+			formatStatus(clone.TaskStatus, bs.CLONE_STATUS),
+			fmt.Sprintf("%d%%", clone.Progress),
+			formatTimestamp(clone.Time),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -29,6 +29,8 @@ import (
 	"crypto/cipher"
 	"crypto/md5"
 	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -168,6 +170,16 @@ func Min(nums ...int) int {
 	return ret
 }
 
+func Max(nums ...int) int {
+	ret := nums[0]
+	for _, num := range nums {
+		if num > ret {
+			ret = num
+		}
+	}
+	return ret
+}
+
 func copy(src, dest map[string]interface{}) {
 	for key, value := range src {
 		switch src[key].(type) {
@@ -327,3 +339,48 @@ func EncryptFile(srcfile, dstfile, secret string) error {
 	outfile.Write(iv)
 	return nil
 }
+
+func newGCM(secret string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptText encrypts text with AES-256-GCM, the nonce is prepended
+// to ciphertext and the result is base64 encoded
+func EncryptText(text, secret string) (string, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(crand.Reader, nonce); err != nil {
+		return "", err
+	}
+	ciphertext := gcm.Seal(nonce, nonce, []byte(text), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func DecryptText(text, secret string) (string, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", err
+	} else if len(ciphertext) < gcm.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"strings"
+)
+
+// the first component of image name is registry iff it contains '.' or ':' or it is localhost,
+// e.g. quay.io/coreos/etcd, 127.0.0.1:5000/curvebs, localhost/curvebs
+func splitImageRegistry(image string) (string, string) {
+	items := strings.SplitN(image, "/", 2)
+	if len(items) == 2 &&
+		(strings.ContainsAny(items[0], ".:") || items[0] == "localhost") {
+		return items[0], items[1]
+	}
+	return "", image
+}
+
+// MirrorImage returns the image name in private registry mirror, e.g.
+//
+//	opencurvedocker/curvebs:v1.2 => harbor.local:5000/opencurvedocker/curvebs:v1.2
+//	ubuntu:20.04                 => harbor.local:5000/library/ubuntu:20.04
+//	quay.io/coreos/etcd:v3.5     => harbor.local:5000/coreos/etcd:v3.5
+func MirrorImage(image, mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if len(mirror) == 0 {
+		return image
+	}
+
+	_, name := splitImageRegistry(image)
+	if !strings.Contains(name, "/") {
+		name = "library/" + name // official image in docker hub
+	}
+	return mirror + "/" + name
+}
+
+// ImageRepository returns the image name without tag and digest, e.g.
+//
+//	opencurvedocker/curvebs:v1.2         => opencurvedocker/curvebs
+//	127.0.0.1:5000/curvebs@sha256:abc... => 127.0.0.1:5000/curvebs
+func ImageRepository(image string) string {
+	if n := strings.Index(image, "@"); n >= 0 {
+		image = image[:n]
+	}
+	if n := strings.LastIndex(image, ":"); n > strings.LastIndex(image, "/") {
+		image = image[:n]
+	}
+	return image
+}
+
+// DigestImage returns the image reference which pinned by digest,
+// e.g. opencurvedocker/curvebs@sha256:abc...
+func DigestImage(image, digest string) string {
+	return ImageRepository(image) + "@" + digest
+}
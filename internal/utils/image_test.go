@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorImage(t *testing.T) {
+	assert := assert.New(t)
+	mirror := "harbor.local:5000/"
+	for image, expect := range map[string]string{
+		"opencurvedocker/curvebs:v1.2": "harbor.local:5000/opencurvedocker/curvebs:v1.2",
+		"ubuntu:20.04":                 "harbor.local:5000/library/ubuntu:20.04",
+		"quay.io/coreos/etcd:v3.5":     "harbor.local:5000/coreos/etcd:v3.5",
+		"localhost/curvebs:latest":     "harbor.local:5000/library/curvebs:latest",
+		"127.0.0.1:5000/curve/bs:v1":   "harbor.local:5000/curve/bs:v1",
+	} {
+		assert.Equal(expect, MirrorImage(image, mirror), image)
+	}
+	assert.Equal("ubuntu:20.04", MirrorImage("ubuntu:20.04", ""))
+}
+
+func TestImageRepository(t *testing.T) {
+	assert := assert.New(t)
+	for image, expect := range map[string]string{
+		"opencurvedocker/curvebs:v1.2":            "opencurvedocker/curvebs",
+		"opencurvedocker/curvebs":                 "opencurvedocker/curvebs",
+		"127.0.0.1:5000/curvebs:v1":               "127.0.0.1:5000/curvebs",
+		"127.0.0.1:5000/curvebs":                  "127.0.0.1:5000/curvebs",
+		"opencurvedocker/curvebs@sha256:abc":      "opencurvedocker/curvebs",
+		"opencurvedocker/curvebs:v1.2@sha256:abc": "opencurvedocker/curvebs",
+	} {
+		assert.Equal(expect, ImageRepository(image), image)
+	}
+	assert.Equal("ubuntu@sha256:abc", DigestImage("ubuntu:20.04", "sha256:abc"))
+}
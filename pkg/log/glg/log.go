@@ -26,14 +26,48 @@ package glg
 
 import (
 	"fmt"
-	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/kpango/glg"
 )
 
 const (
-	INDENT_SPACE = 4
-	SPACE        = " "
+	KEY_MESSAGE        = "message"
+	KEY_CORRELATION_ID = "correlation_id"
+)
+
+type (
+	LogField struct {
+		Key   string
+		Value interface{}
+	}
+
+	RotateConfig struct {
+		MaxSize    int // megabytes
+		MaxAge     int // days
+		MaxBackups int
+	}
+
+	// Entry is the detail of one log line, it will be encoded as json:
+	//
+	//   {"date":"...","level":"INFO","file":"module.go:160","detail":{"message":"Execute command",...}}
+	Entry map[string]interface{}
+)
+
+var (
+	// correlation id of current process, it equals to the audit id of the
+	// command, the http service which handles many operations concurrently
+	// should set correlation id by field for each log line instead
+	correlationId int64 = -1
+
+	// the configured level, lines below it are dropped unless correlated
+	level = uint32(glg.ERR)
+
+	// logger is initialized once for each log file, the http service
+	// creates curveadm for each request which initializes logger again
+	mutex  sync.Mutex
+	writer *FileWriter
 )
 
 func convertLevel(level string) glg.LEVEL {
@@ -51,64 +85,133 @@ func convertLevel(level string) glg.LEVEL {
 	}
 }
 
-func Init(level, filename string) error {
-	writer := glg.FileWriter(filename, 0666)
+func Init(lvl, filename string, rotate RotateConfig) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// all levels are passed to glg, and filtered by enabled()
+	atomic.StoreUint32(&level, uint32(convertLevel(lvl)))
+	if writer != nil && writer.filename == filename {
+		return nil
+	}
 
+	w, err := NewFileWriter(filename, rotate)
+	if err != nil {
+		return err
+	}
 	glg.Get().
 		SetMode(glg.WRITER). // default is STD
-		SetLevel(convertLevel(level)).
+		SetLevel(glg.DEBG).
 		SetLineTraceMode(glg.TraceLineShort).
-		AddLevelWriter(glg.DEBG, writer).
-		AddLevelWriter(glg.INFO, writer).
-		AddLevelWriter(glg.WARN, writer).
-		AddLevelWriter(glg.ERR, writer)
+		SetCallerDepth(glg.DefaultCallerDepth+2). // skip the wrappers in this file
+		EnableJSON().
+		SetLevelWriter(glg.DEBG, w).
+		SetLevelWriter(glg.INFO, w).
+		SetLevelWriter(glg.WARN, w).
+		SetLevelWriter(glg.ERR, w)
+	if writer != nil {
+		writer.Close()
+	}
+	writer = w
 
 	return nil
 }
 
-func Field(key string, val interface{}) string {
-	switch val := val.(type) {
-	case bool:
-		return fmt.Sprintf("%s: %s", key, strconv.FormatBool(val))
-	case string:
-		return fmt.Sprintf("%s: %s", key, val)
+/*
+ * the lines of audited operation (e.g. "Start operation", "Execute command")
+ * are always written unless they are debug lines, so `audit show` can display
+ * the detail of operation even if the log level is error.
+ */
+func enabled(l glg.LEVEL, entry Entry) bool {
+	if l >= glg.LEVEL(atomic.LoadUint32(&level)) {
+		return true
+	}
+	_, correlated := entry[KEY_CORRELATION_ID]
+	return correlated && l >= glg.INFO
+}
+
+// SetLevel changes the configured level, e.g. debug the deploy progress
+func SetLevel(lvl string) {
+	atomic.StoreUint32(&level, uint32(convertLevel(lvl)))
+}
+
+// CorrelationField returns the field which correlates the log line with the
+// operation, it is used when many operations run in one process
+func CorrelationField(id int64) LogField {
+	return LogField{Key: KEY_CORRELATION_ID, Value: id}
+}
+
+func SetCorrelationId(id int64) {
+	atomic.StoreInt64(&correlationId, id)
+}
+
+func CorrelationId() int64 {
+	return atomic.LoadInt64(&correlationId)
+}
+
+func Field(key string, val interface{}) LogField {
+	switch v := val.(type) {
+	case nil, bool, string, int, int64, uint64, float64:
+		return LogField{Key: key, Value: v}
 	case []byte:
-		return fmt.Sprintf("%s: %s", key, string(val))
-	case int:
-	case int64:
-		return fmt.Sprintf("%s: %d", key, val)
+		return LogField{Key: key, Value: string(v)}
 	case error:
-		return fmt.Sprintf("%s: %s", key, val.Error())
+		return LogField{Key: key, Value: v.Error()}
+	}
+	return LogField{Key: key, Value: fmt.Sprintf("%v", val)}
+}
+
+func NewEntry(message string, fields ...LogField) Entry {
+	entry := Entry{KEY_MESSAGE: message}
+	if id := CorrelationId(); id >= 0 {
+		entry[KEY_CORRELATION_ID] = id
 	}
-	return fmt.Sprintf("%s: %v", key, val)
+	for _, field := range fields {
+		entry[field.Key] = field.Value
+	}
+
+	// the correlation id specified by field overrides the process's one,
+	// and negative id means the line is not correlated with any operation
+	if id, ok := entry[KEY_CORRELATION_ID].(int64); ok && id < 0 {
+		delete(entry, KEY_CORRELATION_ID)
+	}
+	return entry
 }
 
-func format(message string, val ...string) string {
-	output := message + "\n"
-	for _, v := range val {
-		output = output + fmt.Sprintf("%*s%s\n", INDENT_SPACE, SPACE, v)
+func output(l glg.LEVEL, message string, fields ...LogField) error {
+	entry := NewEntry(message, fields...)
+	if !enabled(l, entry) {
+		return nil
 	}
 
-	return output
+	switch l {
+	case glg.DEBG:
+		return glg.Debug(entry)
+	case glg.INFO:
+		return glg.Info(entry)
+	case glg.WARN:
+		return glg.Warn(entry)
+	}
+	return glg.Error(entry)
 }
 
-func Debug(message string, val ...string) error {
-	return glg.Debug(format(message, val...))
+func Debug(message string, fields ...LogField) error {
+	return output(glg.DEBG, message, fields...)
 }
 
-func Info(message string, val ...string) error {
-	return glg.Info(format(message, val...))
+func Info(message string, fields ...LogField) error {
+	return output(glg.INFO, message, fields...)
 }
 
-func Warn(message string, val ...string) error {
-	return glg.Warn(format(message, val...))
+func Warn(message string, fields ...LogField) error {
+	return output(glg.WARN, message, fields...)
 }
 
-func Error(message string, val ...string) error {
-	return glg.Error(format(message, val...))
+func Error(message string, fields ...LogField) error {
+	return output(glg.ERR, message, fields...)
 }
 
-func SwitchLevel(err error) func(message string, val ...string) error {
+func SwitchLevel(err error) func(message string, fields ...LogField) error {
 	if err != nil {
 		return Error
 	}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package glg
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	MAX_LINE_SIZE = 16 * 1024 * 1024 // command output may be large
+)
+
+// Record is one log line which written in json format
+type Record struct {
+	Date   string `json:"date"`
+	Level  string `json:"level"`
+	File   string `json:"file"`
+	Detail Entry  `json:"detail"`
+}
+
+// ParseRecord returns false if the line isn't a json log line, e.g. written by old version
+func ParseRecord(line []byte) (Record, bool) {
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil || record.Detail == nil {
+		return record, false
+	}
+	return record, true
+}
+
+func (r Record) Message() string {
+	message, _ := r.Detail[KEY_MESSAGE].(string)
+	return message
+}
+
+func (r Record) CorrelationId() int64 {
+	id, ok := r.Detail[KEY_CORRELATION_ID].(float64) // json number
+	if !ok {
+		return -1
+	}
+	return int64(id)
+}
+
+// String formats record as: DATE LEVEL MESSAGE key1=value1 key2=value2 ...
+func (r Record) String() string {
+	keys := []string{}
+	for key := range r.Detail {
+		if key != KEY_MESSAGE && key != KEY_CORRELATION_ID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	items := []string{r.Date, r.Level, r.Message()}
+	for _, key := range keys {
+		items = append(items, fmt.Sprintf("%s=%v", key, r.Detail[key]))
+	}
+	return strings.Join(items, " ")
+}
+
+// FilterRecords returns records which belong to the operation specified by correlation id
+func FilterRecords(reader io.Reader, correlationId int64) ([]Record, error) {
+	return FilterRecordsFunc(reader, func(record Record) bool {
+		return record.CorrelationId() == correlationId
+	})
+}
+
+// FilterRecordsFunc returns records which satisfy match
+func FilterRecordsFunc(reader io.Reader, match func(Record) bool) ([]Record, error) {
+	records := []Record{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), MAX_LINE_SIZE)
+	for scanner.Scan() {
+		record, ok := ParseRecord(scanner.Bytes())
+		if ok && match(record) {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// ListLogFiles returns current and rotated log files in the order of modification time
+func ListLogFiles(logDir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(logDir, "curveadm*.log"))
+	if err != nil {
+		return nil, err
+	}
+
+	mtimes := map[string]int64{}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		mtimes[file] = info.ModTime().UnixNano()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return mtimes[files[i]] < mtimes[files[j]]
+	})
+	return files, nil
+}
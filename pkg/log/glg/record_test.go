@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package glg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEntry(t *testing.T) {
+	assert := assert.New(t)
+
+	SetCorrelationId(-1)
+	entry := NewEntry("Execute command", Field("command", "ls"), Field("error", nil))
+	assert.Equal(Entry{"message": "Execute command", "command": "ls", "error": nil}, entry)
+
+	SetCorrelationId(12)
+	defer SetCorrelationId(-1)
+	entry = NewEntry("Execute command", Field("output", []byte("ok")), Field("error", errors.New("failed")))
+	assert.Equal(int64(12), entry[KEY_CORRELATION_ID])
+	assert.Equal("ok", entry["output"])
+	assert.Equal("failed", entry["error"])
+
+	// correlation id of operation overrides the process's one
+	entry = NewEntry("Execute command", CorrelationField(13))
+	assert.Equal(int64(13), entry[KEY_CORRELATION_ID])
+	entry = NewEntry("Execute command", CorrelationField(-1))
+	assert.NotContains(entry, KEY_CORRELATION_ID)
+}
+
+func TestFilterRecords(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := []string{
+		`{"date":"2023-10-23 10:00:00","level":"INFO","file":"cli.go:154","detail":{"message":"Init logger success"}}`,
+		`{"date":"2023-10-23 10:00:01","level":"INFO","file":"module.go:160","detail":{"message":"Execute command","correlation_id":12,"tid":"abc","command":"ls"}}`,
+		`2023-10-23 10:00:02 [INFO]: Execute command`, // old text format
+		`{"date":"2023-10-23 10:00:03","level":"ERR","file":"module.go:160","detail":{"message":"Execute command","correlation_id":13}}`,
+	}
+	records, err := FilterRecords(strings.NewReader(strings.Join(lines, "\n")), 12)
+	assert.Nil(err)
+	assert.Len(records, 1)
+	assert.Equal("Execute command", records[0].Message())
+	assert.Equal("2023-10-23 10:00:01 INFO Execute command command=ls tid=abc", records[0].String())
+
+	records, err = FilterRecords(strings.NewReader(strings.Join(lines, "\n")), 14)
+	assert.Nil(err)
+	assert.Len(records, 0)
+}
@@ -0,0 +1,175 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package glg
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	BACKUP_TIME_FORMAT = "2006-01-02T15-04-05.000"
+	DEFAULT_MAX_SIZE   = 100 // megabytes
+)
+
+/*
+ * FileWriter is a rotating log writer which can be shared by many curveadm
+ * processes, e.g. commands running concurrently and the http service:
+ *   (1) each entry is appended by one write(2) on file opened with O_APPEND
+ *   (2) rotating is serialized by flock(2) on the lock file, and the writer
+ *       reopens the log file if it has been rotated by other process
+ *
+ * the backup is named like lumberjack: curveadm-2023-10-23T10-00-00.000.log
+ */
+type FileWriter struct {
+	filename string
+	rotate   RotateConfig
+	mutex    sync.Mutex
+	file     *os.File
+	lock     *os.File
+}
+
+func NewFileWriter(filename string, rotate RotateConfig) (*FileWriter, error) {
+	if rotate.MaxSize <= 0 {
+		rotate.MaxSize = DEFAULT_MAX_SIZE
+	}
+	lock, err := os.OpenFile(filename+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileWriter{filename: filename, rotate: rotate, lock: lock}, nil
+}
+
+func (w *FileWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := syscall.Flock(int(w.lock.Fd()), syscall.LOCK_EX); err != nil {
+		return 0, err
+	}
+	defer syscall.Flock(int(w.lock.Fd()), syscall.LOCK_UN)
+
+	info, err := w.openFile()
+	if err != nil {
+		return 0, err
+	} else if info.Size() > 0 && info.Size()+int64(len(p)) > int64(w.rotate.MaxSize)*1024*1024 {
+		if err := w.rotateFile(); err != nil {
+			return 0, err
+		}
+	}
+	return w.file.Write(p)
+}
+
+func (w *FileWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+	return w.lock.Close()
+}
+
+// (re)open the log file if it's not opened or has been rotated by other process
+func (w *FileWriter) openFile() (os.FileInfo, error) {
+	info, err := os.Stat(w.filename)
+	if err == nil && w.file != nil {
+		if opened, err := w.file.Stat(); err == nil && os.SameFile(info, opened) {
+			return info, nil
+		}
+	}
+
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	w.file = file
+	return file.Stat()
+}
+
+func (w *FileWriter) backupName(t time.Time) string {
+	dir := filepath.Dir(w.filename)
+	ext := filepath.Ext(w.filename)
+	prefix := strings.TrimSuffix(filepath.Base(w.filename), ext)
+	return filepath.Join(dir, prefix+"-"+t.Format(BACKUP_TIME_FORMAT)+ext)
+}
+
+func fileExist(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
+
+func (w *FileWriter) rotateFile() error {
+	w.file.Close()
+	w.file = nil
+	// make sure not to overwrite the backup which rotated in the same millisecond
+	now := time.Now()
+	backup := w.backupName(now)
+	for ; fileExist(backup); backup = w.backupName(now) {
+		now = now.Add(time.Millisecond)
+	}
+	if err := os.Rename(w.filename, backup); err != nil {
+		return err
+	}
+	if _, err := w.openFile(); err != nil {
+		return err
+	}
+	w.removeBackups()
+	return nil
+}
+
+// remove backups which exceed the max backups or max age, the newest are kept
+func (w *FileWriter) removeBackups() {
+	ext := filepath.Ext(w.filename)
+	prefix := strings.TrimSuffix(w.filename, ext) + "-"
+	backups, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+
+	// backup name contains the rotated time, so it can be sorted as string
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	kept := 0
+	for _, backup := range backups {
+		rotated, err := time.ParseInLocation(BACKUP_TIME_FORMAT,
+			strings.TrimSuffix(strings.TrimPrefix(backup, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		if (w.rotate.MaxBackups > 0 && kept >= w.rotate.MaxBackups) ||
+			(w.rotate.MaxAge > 0 && time.Since(rotated) > time.Duration(w.rotate.MaxAge)*24*time.Hour) {
+			os.Remove(backup)
+			continue
+		}
+		kept++
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-18
+ * Author: agent
+ */
+
+package glg
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kpango/glg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWriter_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+	filename := filepath.Join(t.TempDir(), "curveadm.log")
+	line := strings.Repeat("x", 1023) + "\n"
+
+	// each writer acts as one curveadm process
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		w, err := NewFileWriter(filename, RotateConfig{MaxSize: 1, MaxBackups: 10})
+		assert.Nil(err)
+		wg.Add(1)
+		go func(w *FileWriter) {
+			defer wg.Done()
+			defer w.Close()
+			for j := 0; j < 640; j++ {
+				_, err := w.Write([]byte(line))
+				assert.Nil(err)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// 2.5MB in total: every file is less than 1MB and no line is interleaved
+	files, err := ListLogFiles(filepath.Dir(filename))
+	assert.Nil(err)
+	assert.Len(files, 3)
+	assert.Equal(filename, files[len(files)-1])
+	lines := 0
+	for _, file := range files {
+		info, err := os.Stat(file)
+		assert.Nil(err)
+		assert.LessOrEqual(info.Size(), int64(1024*1024))
+
+		f, err := os.Open(file)
+		assert.Nil(err)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			assert.Equal(line, scanner.Text()+"\n")
+			lines++
+		}
+		f.Close()
+	}
+	assert.Equal(4*640, lines)
+}
+
+func TestFileWriter_RemoveBackups(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "curveadm.log")
+	for i := 1; i <= 5; i++ {
+		backup := filepath.Join(dir, fmt.Sprintf("curveadm-2023-10-2%dT10-00-00.000.log", i))
+		assert.Nil(os.WriteFile(backup, []byte("backup\n"), 0644))
+	}
+
+	w, err := NewFileWriter(filename, RotateConfig{MaxBackups: 2})
+	assert.Nil(err)
+	defer w.Close()
+	w.removeBackups()
+	files, err := filepath.Glob(filepath.Join(dir, "curveadm-*.log"))
+	assert.Nil(err)
+	assert.Equal([]string{
+		filepath.Join(dir, "curveadm-2023-10-24T10-00-00.000.log"),
+		filepath.Join(dir, "curveadm-2023-10-25T10-00-00.000.log"),
+	}, files)
+
+	// older than max age
+	w.rotate = RotateConfig{MaxAge: 1}
+	w.removeBackups()
+	files, err = filepath.Glob(filepath.Join(dir, "curveadm-*.log"))
+	assert.Nil(err)
+	assert.Len(files, 0)
+}
+
+func TestEnabled(t *testing.T) {
+	assert := assert.New(t)
+	defer func(l uint32) { level = l }(level)
+
+	level = uint32(glg.ERR)
+	assert.True(enabled(glg.ERR, Entry{}))
+	assert.False(enabled(glg.INFO, Entry{}))
+	assert.True(enabled(glg.INFO, Entry{KEY_CORRELATION_ID: int64(12)}))
+	assert.False(enabled(glg.DEBG, Entry{KEY_CORRELATION_ID: int64(12)}))
+
+	SetLevel("debug")
+	assert.True(enabled(glg.DEBG, Entry{}))
+}
@@ -26,14 +26,21 @@ package module
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
+	"time"
 )
 
 const (
 	TEMPLATE_DOCKER_INFO         = "{{.engine}} info"
 	TEMPLATE_PULL_IMAGE          = "{{.engine}} pull {{.options}} {{.name}}"
+	TEMPLATE_TAG_IMAGE           = "{{.engine}} tag {{.options}} {{.source}} {{.target}}"
+	TEMPLATE_SAVE_IMAGES         = "{{.engine}} save {{.options}} {{.images}}"
+	TEMPLATE_LOAD_IMAGE          = "{{.engine}} load {{.options}}"
+	TEMPLATE_INSPECT_IMAGE       = "{{.engine}} image inspect {{.options}} {{.image}}"
 	TEMPLATE_CREATE_CONTAINER    = "{{.engine}} create {{.options}} {{.image}} {{.command}}"
+	TEMPLATE_RUN_CONTAINER       = "{{.engine}} run {{.options}} {{.image}} {{.command}}"
 	TEMPLATE_START_CONTAINER     = "{{.engine}} start {{.options}} {{.containers}}"
 	TEMPLATE_STOP_CONTAINER      = "{{.engine}} stop {{.options}} {{.containers}}"
 	TEMPLATE_RESTART_CONTAINER   = "{{.engine}} restart {{.options}} {{.containers}}"
@@ -49,10 +56,12 @@ const (
 )
 
 type DockerCli struct {
-	sshClient *SSHClient
-	options   []string
-	tmpl      *template.Template
-	data      map[string]interface{}
+	sshClient  *SSHClient
+	logContext *LogContext
+	timeout    *time.Duration
+	options    []string
+	tmpl       *template.Template
+	data       map[string]interface{}
 }
 
 func NewDockerCli(sshClient *SSHClient) *DockerCli {
@@ -72,7 +81,22 @@ func (s *DockerCli) AddOption(format string, args ...interface{}) *DockerCli {
 func (cli *DockerCli) Execute(options ExecOptions) (string, error) {
 	cli.data["options"] = strings.Join(cli.options, " ")
 	cli.data["engine"] = options.ExecWithEngine
-	return execCommand(cli.sshClient, cli.tmpl, cli.data, options)
+	options = overrideTimeout(options, cli.timeout)
+	return execCommand(cli.sshClient, cli.logContext, cli.tmpl, cli.data, options)
+}
+
+// ExecuteWithInput executes command with the reader as its standard input
+func (cli *DockerCli) ExecuteWithInput(options ExecOptions, r io.Reader) (string, error) {
+	cli.data["options"] = strings.Join(cli.options, " ")
+	cli.data["engine"] = options.ExecWithEngine
+	options = overrideTimeout(options, cli.timeout)
+	return inputCommand(cli.sshClient, cli.logContext, cli.tmpl, cli.data, options, r)
+}
+
+func (cli *DockerCli) Stream(options ExecOptions, w io.Writer) error {
+	cli.data["options"] = strings.Join(cli.options, " ")
+	cli.data["engine"] = options.ExecWithEngine
+	return streamCommand(cli.sshClient, cli.logContext, cli.tmpl, cli.data, options, w)
 }
 
 func (cli *DockerCli) DockerInfo() *DockerCli {
@@ -86,6 +110,30 @@ func (cli *DockerCli) PullImage(image string) *DockerCli {
 	return cli
 }
 
+func (cli *DockerCli) TagImage(source, target string) *DockerCli {
+	cli.tmpl = template.Must(template.New("TagImage").Parse(TEMPLATE_TAG_IMAGE))
+	cli.data["source"] = source
+	cli.data["target"] = target
+	return cli
+}
+
+func (cli *DockerCli) SaveImages(images ...string) *DockerCli {
+	cli.tmpl = template.Must(template.New("SaveImages").Parse(TEMPLATE_SAVE_IMAGES))
+	cli.data["images"] = strings.Join(images, " ")
+	return cli
+}
+
+func (cli *DockerCli) LoadImage() *DockerCli {
+	cli.tmpl = template.Must(template.New("LoadImage").Parse(TEMPLATE_LOAD_IMAGE))
+	return cli
+}
+
+func (cli *DockerCli) InspectImage(image string) *DockerCli {
+	cli.tmpl = template.Must(template.New("InspectImage").Parse(TEMPLATE_INSPECT_IMAGE))
+	cli.data["image"] = image
+	return cli
+}
+
 func (cli *DockerCli) CreateContainer(image, command string) *DockerCli {
 	cli.tmpl = template.Must(template.New("CreateContainer").Parse(TEMPLATE_CREATE_CONTAINER))
 	cli.data["image"] = image
@@ -93,6 +141,13 @@ func (cli *DockerCli) CreateContainer(image, command string) *DockerCli {
 	return cli
 }
 
+func (cli *DockerCli) RunContainer(image, command string) *DockerCli {
+	cli.tmpl = template.Must(template.New("RunContainer").Parse(TEMPLATE_RUN_CONTAINER))
+	cli.data["image"] = image
+	cli.data["command"] = command
+	return cli
+}
+
 func (cli *DockerCli) StartContainer(containerId ...string) *DockerCli {
 	cli.tmpl = template.Must(template.New("StartContainer").Parse(TEMPLATE_START_CONTAINER))
 	cli.data["containers"] = strings.Join(containerId, " ")
@@ -41,7 +41,8 @@ var (
 )
 
 type FileManager struct {
-	sshClient *SSHClient
+	sshClient  *SSHClient
+	logContext *LogContext
 }
 
 func NewFileManager(sshClient *SSHClient) *FileManager {
@@ -54,11 +55,11 @@ func (f *FileManager) Upload(localPath, remotePath string) error {
 	}
 
 	err := f.sshClient.Client().Upload(localPath, remotePath)
-	log.SwitchLevel(err)("UploadFile",
+	log.SwitchLevel(err)("UploadFile", logFields(f.logContext,
 		log.Field("remoteAddress", remoteAddr(f.sshClient)),
 		log.Field("localPath", localPath),
 		log.Field("remotePath", remotePath),
-		log.Field("error", err))
+		log.Field("error", err))...)
 	return err
 }
 
@@ -68,11 +69,11 @@ func (f *FileManager) Download(remotePath, localPath string) error {
 	}
 
 	err := f.sshClient.Client().Download(remotePath, localPath)
-	log.SwitchLevel(err)("DownloadFile",
+	log.SwitchLevel(err)("DownloadFile", logFields(f.logContext,
 		log.Field("remoteAddress", remoteAddr(f.sshClient)),
 		log.Field("remotePath", remotePath),
 		log.Field("localPath", localPath),
-		log.Field("error", err))
+		log.Field("error", err))...)
 	return err
 }
 
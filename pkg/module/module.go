@@ -28,6 +28,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"text/template"
@@ -39,7 +40,20 @@ import (
 
 type (
 	Module struct {
-		sshClient *SSHClient
+		sshClient  *SSHClient
+		logContext *LogContext
+		timeout    *time.Duration
+	}
+
+	// LogContext is attached to every command log line, which correlates
+	// the command with the task and step which executing it
+	LogContext struct {
+		Tid           string
+		Ptid          string
+		Host          string
+		Task          string
+		Step          string
+		CorrelationId int64 // audit id of the operation, -1 if not audited
 	}
 
 	ExecOptions struct {
@@ -61,19 +75,37 @@ func (e *TimeoutError) Error() string {
 }
 
 func NewModule(sshClient *SSHClient) *Module {
-	return &Module{sshClient: sshClient}
+	return &Module{sshClient: sshClient, logContext: &LogContext{CorrelationId: -1}, timeout: new(time.Duration)}
+}
+
+func (m *Module) LogContext() *LogContext {
+	return m.logContext
+}
+
+// SetTimeout overrides the ExecOptions.ExecTimeoutSec of commands which
+// executed by module, zero means no overriding
+func (m *Module) SetTimeout(timeout time.Duration) {
+	*m.timeout = timeout
 }
 
 func (m *Module) Shell() *Shell {
-	return NewShell(m.sshClient)
+	shell := NewShell(m.sshClient)
+	shell.logContext = m.logContext
+	shell.timeout = m.timeout
+	return shell
 }
 
 func (m *Module) File() *FileManager {
-	return NewFileManager(m.sshClient)
+	file := NewFileManager(m.sshClient)
+	file.logContext = m.logContext
+	return file
 }
 
 func (m *Module) DockerCli() *DockerCli {
-	return NewDockerCli(m.sshClient)
+	cli := NewDockerCli(m.sshClient)
+	cli.logContext = m.logContext
+	cli.timeout = m.timeout
+	return cli
 }
 
 // common utils
@@ -86,7 +118,28 @@ func remoteAddr(client *SSHClient) string {
 	return fmt.Sprintf("%s@%s:%d", config.User, config.Host, config.Port)
 }
 
-func execCommand(sshClient *SSHClient,
+func logFields(lc *LogContext, fields ...log.LogField) []log.LogField {
+	if lc == nil {
+		return fields
+	}
+	return append([]log.LogField{
+		log.Field("tid", lc.Tid),
+		log.Field("ptid", lc.Ptid),
+		log.Field("host", lc.Host),
+		log.Field("task", lc.Task),
+		log.Field("step", lc.Step),
+		log.CorrelationField(lc.CorrelationId),
+	}, fields...)
+}
+
+func overrideTimeout(options ExecOptions, timeout *time.Duration) ExecOptions {
+	if timeout != nil && *timeout > 0 {
+		options.ExecTimeoutSec = int((*timeout + time.Second - 1) / time.Second)
+	}
+	return options
+}
+
+func renderCommand(sshClient *SSHClient,
 	tmpl *template.Template,
 	data map[string]interface{},
 	options ExecOptions) (string, error) {
@@ -117,6 +170,18 @@ func execCommand(sshClient *SSHClient,
 			command = strings.Join([]string{become, command}, " ")
 		}
 	}
+	return command, nil
+}
+
+func execCommand(sshClient *SSHClient,
+	lc *LogContext,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions) (string, error) {
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return "", err
+	}
 
 	// (4) create context for timeout
 	ctx := context.Background()
@@ -128,7 +193,6 @@ func execCommand(sshClient *SSHClient,
 
 	// (5) execute command
 	var out []byte
-	var err error
 	if options.ExecInLocal {
 		cmd := exec.CommandContext(ctx, "bash", "-c", command)
 		cmd.Env = []string{"LANG=en_US.UTF-8"}
@@ -146,10 +210,96 @@ func execCommand(sshClient *SSHClient,
 		err = &TimeoutError{options.ExecTimeoutSec}
 	}
 
-	log.SwitchLevel(err)("Execute command",
+	log.SwitchLevel(err)("Execute command", logFields(lc,
+		log.Field("remoteAddr", remoteAddr(sshClient)),
+		log.Field("command", command),
+		log.Field("output", strings.TrimSuffix(string(out), "\n")),
+		log.Field("error", err))...)
+	return string(out), err
+}
+
+// streamCommand executes command and writes its combined output to the writer
+// until the command exits, it is used for long-running command (e.g. tail -f)
+func streamCommand(sshClient *SSHClient,
+	lc *LogContext,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions,
+	w io.Writer) error {
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return err
+	}
+
+	if options.ExecInLocal {
+		cmd := exec.Command("bash", "-c", command)
+		cmd.Env = []string{"LANG=en_US.UTF-8"}
+		cmd.Stdout = w
+		cmd.Stderr = w
+		err = cmd.Run()
+	} else {
+		var cmd *goph.Cmd
+		cmd, err = sshClient.Client().Command(command)
+		if err == nil {
+			cmd.Env = []string{"LANG=en_US.UTF-8"}
+			cmd.Stdout = w
+			cmd.Stderr = w
+			err = cmd.Run()
+		}
+	}
+
+	log.SwitchLevel(err)("Stream command", logFields(lc,
+		log.Field("remoteAddr", remoteAddr(sshClient)),
+		log.Field("command", command),
+		log.Field("error", err))...)
+	return err
+}
+
+// inputCommand executes command with the reader as its standard input,
+// it is used for feeding large content (e.g. image archive) to command
+// without saving it in remote host first
+func inputCommand(sshClient *SSHClient,
+	lc *LogContext,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions,
+	r io.Reader) (string, error) {
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	if options.ExecTimeoutSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(options.ExecTimeoutSec)*time.Second)
+		defer cancel()
+	}
+
+	var out []byte
+	if options.ExecInLocal {
+		cmd := exec.CommandContext(ctx, "bash", "-c", command)
+		cmd.Env = []string{"LANG=en_US.UTF-8"}
+		cmd.Stdin = r
+		out, err = cmd.CombinedOutput()
+	} else {
+		var cmd *goph.Cmd
+		cmd, err = sshClient.Client().CommandContext(ctx, command)
+		if err == nil {
+			cmd.Env = []string{"LANG=en_US.UTF-8"}
+			cmd.Stdin = r
+			out, err = cmd.CombinedOutput()
+		}
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		err = &TimeoutError{options.ExecTimeoutSec}
+	}
+
+	log.SwitchLevel(err)("Execute command with input", logFields(lc,
 		log.Field("remoteAddr", remoteAddr(sshClient)),
 		log.Field("command", command),
 		log.Field("output", strings.TrimSuffix(string(out), "\n")),
-		log.Field("error", err))
+		log.Field("error", err))...)
 	return string(out), err
 }
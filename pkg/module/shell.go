@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 )
 
 const (
@@ -70,6 +71,9 @@ const (
 	TEMPLATE_RPM  = "rpm {{.options}}"
 	TEMPLATE_SCP  = "scp {{.options}} {{.source}} {{.user}}@{{.host}}:{{.target}}"
 
+	// systemd
+	TEMPLATE_SYSTEMCTL = "systemctl {{.options}} {{.action}} {{.units}}"
+
 	// bash
 	TEMPLATE_COMMAND     = "{{.command}}"
 	TEMPLATE_BASH_SCEIPT = "{{.scriptPath}} {{.arguments}}"
@@ -77,10 +81,12 @@ const (
 
 // TODO(P1): support command pipe
 type Shell struct {
-	sshClient *SSHClient
-	options   []string
-	tmpl      *template.Template
-	data      map[string]interface{}
+	sshClient  *SSHClient
+	logContext *LogContext
+	timeout    *time.Duration
+	options    []string
+	tmpl       *template.Template
+	data       map[string]interface{}
 }
 
 func NewShell(sshClient *SSHClient) *Shell {
@@ -109,7 +115,8 @@ func (s *Shell) String() (string, error) {
 
 func (s *Shell) Execute(options ExecOptions) (string, error) {
 	s.data["options"] = strings.Join(s.options, " ")
-	return execCommand(s.sshClient, s.tmpl, s.data, options)
+	options = overrideTimeout(options, s.timeout)
+	return execCommand(s.sshClient, s.logContext, s.tmpl, s.data, options)
 }
 
 // text
@@ -289,6 +296,14 @@ func (s *Shell) Scp(source, user, host, target string) *Shell {
 	return s
 }
 
+// systemd
+func (s *Shell) Systemctl(action string, units ...string) *Shell {
+	s.tmpl = template.Must(template.New("systemctl").Parse(TEMPLATE_SYSTEMCTL))
+	s.data["action"] = action
+	s.data["units"] = strings.Join(units, " ")
+	return s
+}
+
 func (s *Shell) Command(command string) *Shell {
 	s.tmpl = template.Must(template.New("command").Parse(TEMPLATE_COMMAND))
 	s.data["command"] = command
@@ -61,10 +61,14 @@ log_level = error
 sudo_alias = "sudo"
 timeout = 300
 auto_upgrade = true
+telemetry = true
 
 [ssh_connections]
 retries = 3
 timeout = 10
+
+[database]
+url = "sqlite://$g_curveadm_home/data/curveadm.db"
 __EOF__
     fi
 